@@ -59,7 +59,9 @@ import (
 	"github.com/containerd/containerd/v2/core/transfer"
 	transferproxy "github.com/containerd/containerd/v2/core/transfer/proxy"
 	"github.com/containerd/containerd/v2/defaults"
+	"github.com/containerd/containerd/v2/internal/kmutex"
 	"github.com/containerd/containerd/v2/pkg/dialer"
+	"github.com/containerd/containerd/v2/pkg/imageverifier"
 	"github.com/containerd/containerd/v2/pkg/namespaces"
 	ptypes "github.com/containerd/containerd/v2/pkg/protobuf/types"
 	"github.com/containerd/containerd/v2/pkg/tracing"
@@ -111,6 +113,7 @@ func New(address string, opts ...Opt) (*Client, error) {
 
 	c := &Client{
 		defaultns: copts.defaultns,
+		pullDedup: kmutex.New(),
 	}
 
 	if copts.defaultRuntime != "" {
@@ -156,6 +159,11 @@ func New(address string, opts ...Opt) (*Client, error) {
 			gopts = append(gopts, grpc.WithChainUnaryInterceptor(unary))
 			gopts = append(gopts, grpc.WithChainStreamInterceptor(stream))
 		}
+		retryPolicy := defaultRetryPolicy
+		if copts.retryPolicySet {
+			retryPolicy = copts.retryPolicy
+		}
+		gopts = append(gopts, grpc.WithChainUnaryInterceptor(retryUnaryClientInterceptor(retryPolicy)))
 
 		connector := func() (*grpc.ClientConn, error) {
 			conn, err := grpc.NewClient(dialer.DialAddress(address), gopts...)
@@ -199,6 +207,7 @@ func NewWithConn(conn *grpc.ClientConn, opts ...Opt) (*Client, error) {
 		defaultns: copts.defaultns,
 		conn:      conn,
 		runtime:   defaults.DefaultRuntime,
+		pullDedup: kmutex.New(),
 	}
 
 	if copts.defaultPlatform != nil {
@@ -232,6 +241,17 @@ type Client struct {
 	defaultns string
 	platform  platforms.MatchComparer
 	connector func() (*grpc.ClientConn, error)
+
+	// pullDedup makes sure that concurrent Pull calls for the same ref
+	// within this process share a single fetch instead of racing to fetch
+	// and unpack the same image in parallel.
+	pullDedup kmutex.KeyedLocker
+
+	// templatesMu guards templates.
+	templatesMu sync.Mutex
+	// templates holds container templates registered with
+	// RegisterContainerTemplate, keyed by name.
+	templates map[string]ContainerTemplate
 }
 
 // Reconnect re-establishes the GRPC connection to the containerd daemon
@@ -414,6 +434,16 @@ type RemoteContext struct {
 	// ChildLabelMap sets the labels used to reference child objects in the content
 	// store. By default, all GC reference labels will be set for all fetched content.
 	ChildLabelMap func(ocispec.Descriptor) []string
+
+	// ProgressCallback, if set, is called periodically during Pull with the
+	// ingest status of each blob currently being fetched. See WithPullProgress.
+	ProgressCallback func(PullProgress)
+
+	// VerifierPolicy verifies an image against a set of named
+	// ImageVerifier plugins, scoped by namespace, before it is fetched.
+	// It is unset by default: Client.Pull does not verify images unless
+	// WithImageVerifierPolicy is passed. See WithImageVerifierPolicy.
+	VerifierPolicy imageverifier.Policy
 }
 
 func defaultRemoteContext() *RemoteContext {
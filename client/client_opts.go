@@ -17,11 +17,15 @@
 package client
 
 import (
+	"context"
 	"time"
 
 	"github.com/containerd/containerd/v2/core/images"
 	"github.com/containerd/containerd/v2/core/remotes"
+	"github.com/containerd/containerd/v2/core/remotes/docker"
+	"github.com/containerd/containerd/v2/core/remotes/docker/config"
 	"github.com/containerd/containerd/v2/core/snapshots"
+	"github.com/containerd/containerd/v2/pkg/imageverifier"
 	"github.com/containerd/platforms"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"golang.org/x/sync/semaphore"
@@ -38,6 +42,8 @@ type clientOpts struct {
 	extraDialOpts   []grpc.DialOption
 	callOptions     []grpc.CallOption
 	timeout         time.Duration
+	retryPolicy     retryPolicy
+	retryPolicySet  bool
 }
 
 // Opt allows callers to set options on the containerd client
@@ -118,6 +124,24 @@ func WithTimeout(d time.Duration) Opt {
 	}
 }
 
+// WithMaxRetries overrides the number of times the client retries an
+// idempotent call (Get/List/Stat/...) that fails with codes.Unavailable,
+// in place of the default retry policy. A value of 0 disables retries
+// entirely, so a single transient failure is returned to the caller
+// immediately instead of being retried.
+func WithMaxRetries(maxAttempts int) Opt {
+	return func(c *clientOpts) error {
+		c.retryPolicySet = true
+		if maxAttempts == 0 {
+			c.retryPolicy = retryPolicy{}
+			return nil
+		}
+		c.retryPolicy = defaultRetryPolicy
+		c.retryPolicy.maxAttempts = maxAttempts
+		return nil
+	}
+}
+
 // RemoteOpt allows the caller to set distribution options for a remote
 type RemoteOpt func(*Client, *RemoteContext) error
 
@@ -174,6 +198,18 @@ func WithPullSnapshotter(snapshotterName string, opts ...snapshots.Opt) RemoteOp
 	}
 }
 
+// WithPullProgress registers a callback that is invoked periodically during
+// Pull with the current ingest status of each blob being fetched, so callers
+// don't each have to reimplement polling the content store's ingest
+// statuses themselves. The callback may be invoked from a different
+// goroutine than the one calling Pull, and must not block.
+func WithPullProgress(cb func(PullProgress)) RemoteOpt {
+	return func(_ *Client, c *RemoteContext) error {
+		c.ProgressCallback = cb
+		return nil
+	}
+}
+
 // WithPullLabel sets a label to be associated with a pulled reference
 func WithPullLabel(key, value string) RemoteOpt {
 	return func(_ *Client, rc *RemoteContext) error {
@@ -220,6 +256,34 @@ func WithResolver(resolver remotes.Resolver) RemoteOpt {
 	}
 }
 
+// WithHostsDir configures the resolver used for this call to read registry
+// host configuration (hosts.toml or the legacy certs.d layout) from dir,
+// the same layout used by the containerd daemon's own `config_path` and by
+// `ctr`'s `--hosts-dir` flag. It is equivalent to building a resolver with
+// docker.NewResolver and config.ConfigureHosts(config.HostOptions{HostDir:
+// config.HostDirFromRoot(dir)}) and passing it via WithResolver, for callers
+// that just want a per-call hosts directory without doing that wiring
+// themselves.
+func WithHostsDir(dir string) RemoteOpt {
+	return WithRegistryHosts(config.ConfigureHosts(context.Background(), config.HostOptions{
+		HostDir: config.HostDirFromRoot(dir),
+	}))
+}
+
+// WithRegistryHosts configures the resolver used for this call to use the
+// given docker.RegistryHosts function, e.g. one built with
+// config.ConfigureHosts. This lets a single process use different registry
+// mirrors or credentials for different calls without having to mutate
+// global state or build a brand new resolver type.
+func WithRegistryHosts(hosts docker.RegistryHosts) RemoteOpt {
+	return func(client *Client, c *RemoteContext) error {
+		c.Resolver = docker.NewResolver(docker.ResolverOptions{
+			Hosts: hosts,
+		})
+		return nil
+	}
+}
+
 // WithImageHandler adds a base handler to be called on dispatch.
 func WithImageHandler(h images.Handler) RemoteOpt {
 	return func(client *Client, c *RemoteContext) error {
@@ -275,3 +339,13 @@ func WithAllMetadata() RemoteOpt {
 		return nil
 	}
 }
+
+// WithImageVerifierPolicy sets the policy used to verify an image against a
+// set of named ImageVerifier plugins, scoped by namespace, before it is
+// fetched.
+func WithImageVerifierPolicy(policy imageverifier.Policy) RemoteOpt {
+	return func(_ *Client, c *RemoteContext) error {
+		c.VerifierPolicy = policy
+		return nil
+	}
+}
@@ -0,0 +1,211 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/containerd/containerd/v2/core/containers"
+	"github.com/containerd/containerd/v2/pkg/oci"
+	"github.com/containerd/errdefs"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// TemplateDevice describes a host device to make available in a container
+// created from a ContainerTemplate. It mirrors the arguments accepted by
+// oci.WithDevices.
+type TemplateDevice struct {
+	// Path is the path of the device on the host.
+	Path string `json:"path"`
+	// ContainerPath is the path of the device inside the container. If
+	// empty, Path is used.
+	ContainerPath string `json:"containerPath,omitempty"`
+	// Permissions are the cgroups device permissions, e.g. "rwm". If
+	// empty, "rwm" is used.
+	Permissions string `json:"permissions,omitempty"`
+}
+
+// ContainerTemplate is a named, reusable bundle of container creation
+// settings. It exists so that recurring, complex configurations (extra
+// devices, bind mounts, capabilities, labels, ...) can be assembled once
+// and applied to many containers, instead of being rebuilt from
+// individual SpecOpts/NewContainerOpts on every NewContainer call.
+//
+// A ContainerTemplate only ever adds to a container's configuration; it
+// never resets or replaces a spec wholesale the way WithSpec does, so it
+// composes safely with other options regardless of where in the option
+// list it is applied.
+type ContainerTemplate struct {
+	// Labels are merged into the container's labels.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations are merged into the container's OCI annotations.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Runtime, if set, is used as the container's runtime name.
+	Runtime string `json:"runtime,omitempty"`
+	// Env are additional environment variables appended to the
+	// container's process environment.
+	Env []string `json:"env,omitempty"`
+	// Mounts are additional mounts added to the container's spec.
+	Mounts []specs.Mount `json:"mounts,omitempty"`
+	// Devices are additional host devices added to the container.
+	Devices []TemplateDevice `json:"devices,omitempty"`
+	// CapabilitiesAdd are additional Linux capabilities added to the
+	// container.
+	CapabilitiesAdd []string `json:"capabilitiesAdd,omitempty"`
+	// CapabilitiesDrop are Linux capabilities removed from the
+	// container's default capability set.
+	CapabilitiesDrop []string `json:"capabilitiesDrop,omitempty"`
+}
+
+// SpecOpts returns the oci.SpecOpts contributed by t, in a stable order.
+// Callers building their own opts list (e.g. alongside other oci.SpecOpts
+// derived from CLI flags) should append these rather than using
+// WithContainerTemplate, so all spec changes are merged through a single
+// WithNewSpec/WithSpec call.
+func (t ContainerTemplate) SpecOpts() []oci.SpecOpts {
+	var opts []oci.SpecOpts
+	if len(t.Env) > 0 {
+		opts = append(opts, oci.WithEnv(t.Env))
+	}
+	if len(t.Mounts) > 0 {
+		opts = append(opts, oci.WithMounts(t.Mounts))
+	}
+	for _, d := range t.Devices {
+		permissions := d.Permissions
+		if permissions == "" {
+			permissions = "rwm"
+		}
+		opts = append(opts, oci.WithDevices(d.Path, d.ContainerPath, permissions))
+	}
+	if len(t.CapabilitiesAdd) > 0 {
+		opts = append(opts, oci.WithAddedCapabilities(t.CapabilitiesAdd))
+	}
+	if len(t.CapabilitiesDrop) > 0 {
+		opts = append(opts, oci.WithDroppedCapabilities(t.CapabilitiesDrop))
+	}
+	if len(t.Annotations) > 0 {
+		opts = append(opts, oci.WithAnnotations(t.Annotations))
+	}
+	return opts
+}
+
+// ContainerOpts returns the NewContainerOpts contributed by t that are not
+// part of the OCI spec.
+func (t ContainerTemplate) ContainerOpts() []NewContainerOpts {
+	var opts []NewContainerOpts
+	if len(t.Labels) > 0 {
+		opts = append(opts, WithContainerLabels(t.Labels))
+	}
+	if t.Runtime != "" {
+		opts = append(opts, WithRuntime(t.Runtime, nil))
+	}
+	return opts
+}
+
+// WithContainerTemplate returns a NewContainerOpts that applies every
+// setting in t to the container being created. It is a convenience for
+// callers that are not otherwise assembling their own oci.SpecOpts list;
+// it generates a new spec from t.SpecOpts() via WithNewSpec, so it should
+// not be combined with another option that also replaces c.Spec wholesale
+// (such as WithSpec or a second WithContainerTemplate/WithTemplate).
+func WithContainerTemplate(t ContainerTemplate) NewContainerOpts {
+	return func(ctx context.Context, client *Client, c *containers.Container) error {
+		for _, opt := range t.ContainerOpts() {
+			if err := opt(ctx, client, c); err != nil {
+				return err
+			}
+		}
+		if specOpts := t.SpecOpts(); len(specOpts) > 0 {
+			return WithNewSpec(specOpts...)(ctx, client, c)
+		}
+		return nil
+	}
+}
+
+// RegisterContainerTemplate registers a named ContainerTemplate on the
+// client for later use with WithTemplate. It is intended for embedders
+// that create many containers from the same small set of base
+// configurations over the lifetime of a single Client, so the settings
+// that make up each configuration only need to be assembled once.
+//
+// Registration is local to this Client and this process: it is not
+// persisted to the containerd metadata store and is not visible to other
+// clients or after the process restarts. Callers that need a template to
+// survive process restarts should load it from a file with
+// LoadContainerTemplate instead.
+func (c *Client) RegisterContainerTemplate(name string, template ContainerTemplate) error {
+	c.templatesMu.Lock()
+	defer c.templatesMu.Unlock()
+	if _, ok := c.templates[name]; ok {
+		return fmt.Errorf("container template %q: %w", name, errdefs.ErrAlreadyExists)
+	}
+	if c.templates == nil {
+		c.templates = make(map[string]ContainerTemplate)
+	}
+	c.templates[name] = template
+	return nil
+}
+
+// ContainerTemplate returns the template previously registered under name
+// with RegisterContainerTemplate.
+func (c *Client) ContainerTemplate(name string) (ContainerTemplate, error) {
+	c.templatesMu.Lock()
+	defer c.templatesMu.Unlock()
+	t, ok := c.templates[name]
+	if !ok {
+		return ContainerTemplate{}, fmt.Errorf("container template %q: %w", name, errdefs.ErrNotFound)
+	}
+	return t, nil
+}
+
+// UnregisterContainerTemplate removes the template previously registered
+// under name. It is a no-op if no such template is registered.
+func (c *Client) UnregisterContainerTemplate(name string) {
+	c.templatesMu.Lock()
+	defer c.templatesMu.Unlock()
+	delete(c.templates, name)
+}
+
+// WithTemplate returns a NewContainerOpts that applies the template
+// registered under name via RegisterContainerTemplate.
+func WithTemplate(name string) NewContainerOpts {
+	return func(ctx context.Context, client *Client, c *containers.Container) error {
+		t, err := client.ContainerTemplate(name)
+		if err != nil {
+			return err
+		}
+		return WithContainerTemplate(t)(ctx, client, c)
+	}
+}
+
+// LoadContainerTemplate reads and decodes a ContainerTemplate from the
+// JSON file at path, the same way WithSpecFromFile loads a spec: as a
+// file-based preset that does not require a registered Client.
+func LoadContainerTemplate(path string) (ContainerTemplate, error) {
+	p, err := os.ReadFile(path)
+	if err != nil {
+		return ContainerTemplate{}, fmt.Errorf("cannot load container template: %w", err)
+	}
+	var t ContainerTemplate
+	if err := json.Unmarshal(p, &t); err != nil {
+		return ContainerTemplate{}, fmt.Errorf("decoding container template failed: %w", err)
+	}
+	return t, nil
+}
@@ -66,6 +66,9 @@ type Image interface {
 	Platform() platforms.MatchComparer
 	// Spec returns the OCI image spec for a given image.
 	Spec(ctx context.Context) (ocispec.Image, error)
+	// IsArtifact returns the image's OCI artifact type and true, or "" and
+	// false if it is an ordinary container image. See images.IsArtifact.
+	IsArtifact(ctx context.Context) (string, bool, error)
 }
 
 type usageOptions struct {
@@ -225,6 +228,10 @@ func (i *image) IsUnpacked(ctx context.Context, snapshotterName string) (bool, e
 	return true, nil
 }
 
+func (i *image) IsArtifact(ctx context.Context) (string, bool, error) {
+	return images.IsArtifact(ctx, i.ContentStore(), i.i.Target)
+}
+
 func (i *image) Spec(ctx context.Context) (ocispec.Image, error) {
 	var ociImage ocispec.Image
 
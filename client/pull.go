@@ -20,7 +20,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	digest "github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"golang.org/x/sync/semaphore"
 
@@ -29,6 +31,7 @@ import (
 	"github.com/containerd/containerd/v2/core/remotes/docker"
 	"github.com/containerd/containerd/v2/core/transfer"
 	"github.com/containerd/containerd/v2/core/unpack"
+	"github.com/containerd/containerd/v2/pkg/labels"
 	"github.com/containerd/containerd/v2/pkg/tracing"
 	"github.com/containerd/errdefs"
 	"github.com/containerd/platforms"
@@ -36,8 +39,27 @@ import (
 
 const (
 	pullSpanPrefix = "pull"
+
+	// pullProgressPollInterval is how often the content store is polled for
+	// ingest status while a ProgressCallback is set on a Pull.
+	pullProgressPollInterval = 100 * time.Millisecond
 )
 
+// PullProgress reports the status of a single blob being fetched as part of
+// a Pull. It is delivered through the callback set with WithPullProgress.
+type PullProgress struct {
+	// Ref identifies the blob within this pull, as assigned by the content
+	// store's ingest tracking (see content.Status.Ref).
+	Ref string
+	// Digest is the digest the blob is expected to have once the fetch
+	// completes.
+	Digest digest.Digest
+	// Offset is the number of bytes written for Ref so far.
+	Offset int64
+	// Total is the expected size of the blob, or 0 if unknown.
+	Total int64
+}
+
 // Pull downloads the provided content into containerd's content store
 // and returns a platform specific image object
 func (c *Client) Pull(ctx context.Context, ref string, opts ...RemoteOpt) (_ Image, retErr error) {
@@ -52,6 +74,16 @@ func (c *Client) Pull(ctx context.Context, ref string, opts ...RemoteOpt) (_ Ima
 		}
 	}
 
+	// Only one Pull of a given ref runs at a time within this process. This
+	// avoids racing fetches and unpacks of the same image when, for example,
+	// multiple goroutines try to run the same container image concurrently.
+	if c.pullDedup != nil {
+		if err := c.pullDedup.Lock(ctx, ref); err != nil {
+			return nil, err
+		}
+		defer c.pullDedup.Unlock(ref)
+	}
+
 	if resolver, ok := pullCtx.Resolver.(remotes.ResolverWithOptions); ok {
 		resolver.SetOptions(
 			transfer.WithConcurrentLayerFetchBuffer(pullCtx.ConcurrentLayerFetchBuffer),
@@ -141,6 +173,11 @@ func (c *Client) Pull(ctx context.Context, ref string, opts ...RemoteOpt) (_ Ima
 		}
 	}
 
+	if pullCtx.ProgressCallback != nil {
+		stopProgress := c.reportPullProgress(ctx, pullCtx.ProgressCallback)
+		defer stopProgress()
+	}
+
 	img, err := c.fetch(ctx, pullCtx, ref, 1)
 	if err != nil {
 		return nil, err
@@ -179,6 +216,49 @@ func (c *Client) Pull(ctx context.Context, ref string, opts ...RemoteOpt) (_ Ima
 	return i, nil
 }
 
+// reportPullProgress polls the content store's ingest statuses at a fixed
+// interval and reports them to cb until the returned stop function is
+// called. stop blocks until the final report has been sent.
+func (c *Client) reportPullProgress(ctx context.Context, cb func(PullProgress)) (stop func()) {
+	stopCh := make(chan struct{})
+	doneCh := make(chan struct{})
+
+	report := func() {
+		statuses, err := c.ContentStore().ListStatuses(ctx)
+		if err != nil {
+			return
+		}
+		for _, st := range statuses {
+			cb(PullProgress{
+				Ref:    st.Ref,
+				Digest: st.Expected,
+				Offset: st.Offset,
+				Total:  st.Total,
+			})
+		}
+	}
+
+	go func() {
+		defer close(doneCh)
+		ticker := time.NewTicker(pullProgressPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				report()
+			case <-stopCh:
+				report()
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(stopCh)
+		<-doneCh
+	}
+}
+
 func (c *Client) fetch(ctx context.Context, rCtx *RemoteContext, ref string, limit int) (images.Image, error) {
 	ctx, span := tracing.StartSpan(ctx, tracing.Name(pullSpanPrefix, "fetch"))
 	defer span.End()
@@ -188,6 +268,10 @@ func (c *Client) fetch(ctx context.Context, rCtx *RemoteContext, ref string, lim
 		return images.Image{}, fmt.Errorf("failed to resolve reference %q: %w", ref, err)
 	}
 
+	if err := rCtx.VerifierPolicy.Verify(ctx, name, desc); err != nil {
+		return images.Image{}, err
+	}
+
 	fetcher, err := rCtx.Resolver.Fetcher(ctx, name)
 	if err != nil {
 		return images.Image{}, fmt.Errorf("failed to get fetcher for %q: %w", name, err)
@@ -233,7 +317,11 @@ func (c *Client) fetch(ctx context.Context, rCtx *RemoteContext, ref string, lim
 		},
 	)
 
-	appendDistSrcLabelHandler, err := docker.AppendDistributionSourceLabel(store, ref)
+	var appendDistSrcLabelOpts []docker.DistributionSourceLabelOpt
+	if fh, ok := fetcher.(docker.FetcherHosts); ok {
+		appendDistSrcLabelOpts = append(appendDistSrcLabelOpts, docker.WithSourceHosts(fh.Hosts()))
+	}
+	appendDistSrcLabelHandler, err := docker.AppendDistributionSourceLabel(store, ref, appendDistSrcLabelOpts...)
 	if err != nil {
 		return images.Image{}, err
 	}
@@ -265,10 +353,28 @@ func (c *Client) fetch(ctx context.Context, rCtx *RemoteContext, ref string, lim
 		}
 	}
 
+	// An OCI artifact manifest (ML model, WASM module, Helm chart, etc.) is
+	// stored like any other blob above, but its config and layers may use
+	// media types the unpacker doesn't recognize, so it's never unpacked -
+	// unpack.Unpacker already skips any manifest whose children don't match
+	// a known config/layer media type. Record the artifact type on the image
+	// so it can be told apart from a container image without re-reading the
+	// manifest every time.
+	imgLabels := rCtx.Labels
+	if artifactType, ok, err := images.IsArtifact(ctx, store, desc); err != nil {
+		return images.Image{}, fmt.Errorf("failed to determine artifact type for %q: %w", name, err)
+	} else if ok {
+		imgLabels = make(map[string]string, len(rCtx.Labels)+1)
+		for k, v := range rCtx.Labels {
+			imgLabels[k] = v
+		}
+		imgLabels[labels.LabelImageArtifactType] = artifactType
+	}
+
 	return images.Image{
 		Name:   name,
 		Target: desc,
-		Labels: rCtx.Labels,
+		Labels: imgLabels,
 	}, nil
 }
 
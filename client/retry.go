@@ -0,0 +1,102 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// idempotentMethodSuffixes are the gRPC method name suffixes (the part
+// after the last "/") this package considers safe to retry automatically:
+// read-only calls where replaying the request after a transient failure
+// cannot change what the daemon does, unlike, say, Create or Delete.
+var idempotentMethodSuffixes = []string{
+	"Get", "List", "Stat", "Info", "Lookup", "ListSyncedFds",
+}
+
+// retryPolicy configures retryUnaryClientInterceptor. The zero value
+// disables retries.
+type retryPolicy struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+// defaultRetryPolicy retries a transiently-unavailable daemon (the common
+// case is a containerd restart) a handful of times over roughly a couple
+// of seconds, which is long enough to ride out a restart without making
+// every embedder implement its own retry loop for the idempotent calls it
+// is always safe to retry.
+var defaultRetryPolicy = retryPolicy{
+	maxAttempts: 4,
+	baseDelay:   100 * time.Millisecond,
+	maxDelay:    2 * time.Second,
+}
+
+func isIdempotentMethod(fullMethod string) bool {
+	i := strings.LastIndexByte(fullMethod, '/')
+	name := fullMethod[i+1:]
+	for _, suffix := range idempotentMethodSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryUnaryClientInterceptor retries idempotent calls (see
+// isIdempotentMethod) that fail with codes.Unavailable, the status gRPC
+// reports for a daemon that is down or mid-restart. Non-idempotent calls
+// and any other error are returned unchanged on the first failure; callers
+// that need different behavior can still reach the underlying error, since
+// this only ever returns the last attempt's error.
+func retryUnaryClientInterceptor(policy retryPolicy) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if policy.maxAttempts == 0 || !isIdempotentMethod(method) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		var err error
+		for attempt := 0; attempt < policy.maxAttempts; attempt++ {
+			if attempt > 0 {
+				delay := policy.baseDelay * time.Duration(1<<uint(attempt-1))
+				if delay > policy.maxDelay {
+					delay = policy.maxDelay
+				}
+				delay = time.Duration(float64(delay) * (0.5 + rand.Float64()))
+				select {
+				case <-time.After(delay):
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+
+			err = invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || status.Code(err) != codes.Unavailable {
+				return err
+			}
+		}
+		return err
+	}
+}
@@ -189,6 +189,21 @@ type Task interface {
 	Pause(context.Context) error
 	// Resume the execution of the task
 	Resume(context.Context) error
+	// PauseWithTimeout pauses the task like Pause, but additionally waits for
+	// it to finish transitioning to the Paused state, reporting
+	// ErrFreezeTimeout if that hasn't happened within timeout. This can
+	// happen when one or more of the task's processes are stuck in
+	// uninterruptible sleep (D state), which the cgroup freezer cannot
+	// suspend; the pause request itself is not undone, and the task may
+	// still finish pausing on its own afterwards.
+	PauseWithTimeout(ctx context.Context, timeout time.Duration) error
+	// Freeze pauses the task for duration and then automatically resumes
+	// it, which is useful for taking a consistent snapshot or backup of a
+	// running container without stopping it. Pausing is subject to the
+	// same timeout and partial-failure semantics as PauseWithTimeout;
+	// Freeze always attempts to resume the task before returning,
+	// regardless of whether pausing it fully succeeded.
+	Freeze(ctx context.Context, timeout, duration time.Duration) error
 	// Exec creates a new process inside the task
 	Exec(context.Context, string, *specs.Process, cio.Creator) (Process, error)
 	// Pids returns a list of system specific process ids inside the task
@@ -311,6 +326,62 @@ func (t *task) Resume(ctx context.Context) error {
 	return errgrpc.ToNative(err)
 }
 
+// ErrFreezeTimeout is returned by PauseWithTimeout and Freeze when the task
+// does not finish transitioning to the Paused state before the deadline.
+var ErrFreezeTimeout = errors.New("timed out waiting for task to pause")
+
+func (t *task) PauseWithTimeout(ctx context.Context, timeout time.Duration) error {
+	ctx, span := tracing.StartSpan(ctx, "task.PauseWithTimeout",
+		tracing.WithAttribute("task.id", t.ID()),
+	)
+	defer span.End()
+	if err := t.Pause(ctx); err != nil {
+		return err
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		status, err := t.Status(ctx)
+		if err != nil {
+			return err
+		}
+		if status.Status == Paused {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("task %s: %w", t.id, ErrFreezeTimeout)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func (t *task) Freeze(ctx context.Context, timeout, duration time.Duration) (retErr error) {
+	ctx, span := tracing.StartSpan(ctx, "task.Freeze",
+		tracing.WithAttribute("task.id", t.ID()),
+	)
+	defer span.End()
+
+	pauseErr := t.PauseWithTimeout(ctx, timeout)
+	defer func() {
+		if err := t.Resume(ctx); err != nil && retErr == nil {
+			retErr = fmt.Errorf("failed to resume task %s after freeze: %w", t.id, err)
+		}
+	}()
+	if pauseErr != nil {
+		return pauseErr
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(duration):
+	}
+	return nil
+}
+
 func (t *task) Status(ctx context.Context) (Status, error) {
 	r, err := t.client.TaskService().Get(ctx, &tasks.GetRequest{
 		ContainerID: t.id,
@@ -0,0 +1,78 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// containerd-coredump-helper is meant to be installed as a container's
+// core_pattern pipe handler (see pkg/oci.WithCoreDumpHandler): the kernel
+// invokes it with the crashing process' core dump on stdin and information
+// about it as positional arguments, and it files the dump away under
+// -dir/-id with the configured size and retention limits applied.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/containerd/containerd/v2/pkg/coredump"
+)
+
+func main() {
+	dir := flag.String("dir", "", "directory core dumps are written to")
+	id := flag.String("id", "", "container ID the core dump belongs to")
+	maxSize := flag.Int64("limit", 0, "maximum core dump size in bytes, 0 for unlimited")
+	maxFiles := flag.Int("retain", 0, "maximum number of core dumps retained per container, 0 for unlimited")
+	flag.Parse()
+
+	if *dir == "" || *id == "" {
+		fmt.Fprintln(os.Stderr, "containerd-coredump-helper: -dir and -id are required")
+		os.Exit(1)
+	}
+
+	// Positional arguments come from the container's core_pattern, e.g.
+	// "|containerd-coredump-helper -dir=... -id=... %p %u %g %s %t %e".
+	args := flag.Args()
+	meta := coredump.Metadata{
+		ContainerID: *id,
+		Time:        time.Now(),
+	}
+	if len(args) > 0 {
+		meta.Pid, _ = strconv.Atoi(args[0])
+	}
+	if len(args) > 1 {
+		meta.Uid, _ = strconv.Atoi(args[1])
+	}
+	if len(args) > 2 {
+		meta.Gid, _ = strconv.Atoi(args[2])
+	}
+	if len(args) > 3 {
+		meta.Signal, _ = strconv.Atoi(args[3])
+	}
+	if len(args) > 5 {
+		meta.Comm = args[5]
+	}
+
+	opts := coredump.Options{
+		Dir:          *dir,
+		MaxSizeBytes: *maxSize,
+		MaxFiles:     *maxFiles,
+	}
+	if err := coredump.Capture(os.Stdin, opts, meta); err != nil {
+		fmt.Fprintf(os.Stderr, "containerd-coredump-helper: %s\n", err)
+		os.Exit(1)
+	}
+}
@@ -28,9 +28,11 @@ import (
 	"os/exec"
 	"path/filepath"
 	goruntime "runtime"
+	"strconv"
 	"syscall"
 	"time"
 
+	criu "github.com/checkpoint-restore/go-criu/v7/utils"
 	"github.com/containerd/cgroups/v3"
 	"github.com/containerd/cgroups/v3/cgroup1"
 	cgroupsv2 "github.com/containerd/cgroups/v3/cgroup2"
@@ -51,6 +53,14 @@ import (
 	"golang.org/x/sys/unix"
 )
 
+// checkpointRestoreAnnotation reports, in the "features" response's
+// implementation-specific Annotations map (see features.Features), whether
+// this host can checkpoint/restore containers with CRIU. It uses the same
+// CRIU version and check as the CRI checkpoint path (see
+// internal/cri/server/container_checkpoint_linux.go), so a "true" here
+// matches what a checkpoint attempt through either path would see.
+const checkpointRestoreAnnotation = "io.containerd.checkpoint.criu"
+
 // NewShimManager returns an implementation of the shim manager
 // using runc
 func NewShimManager(name string) shim.Manager {
@@ -363,6 +373,10 @@ func (m manager) Info(ctx context.Context, optionsR io.Reader) (*types.RuntimeIn
 		log.G(ctx).WithError(err).Debug("Failed to get the runtime features. The runc binary does not implement `runc features` command?")
 	}
 	if features != nil {
+		if features.Annotations == nil {
+			features.Annotations = make(map[string]string)
+		}
+		features.Annotations[checkpointRestoreAnnotation] = strconv.FormatBool(criu.CheckForCriu(criu.PodCriuVersion) == nil)
 		info.Features, err = typeurl.MarshalAnyToProto(features)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal %T: %w", features, err)
@@ -39,7 +39,7 @@ func (s *deletedState) Resume(ctx context.Context) error {
 	return errors.New("cannot resume a deleted process")
 }
 
-func (s *deletedState) Update(context context.Context, r *google_protobuf.Any) error {
+func (s *deletedState) Update(context context.Context, r *google_protobuf.Any, annotations map[string]string) error {
 	return errors.New("cannot update a deleted process")
 }
 
@@ -76,6 +76,10 @@ type Init struct {
 	NoPivotRoot  bool
 	NoNewKeyring bool
 	CriuWorkPath string
+
+	// psiTriggerFiles holds the open *.pressure files backing any PSI
+	// triggers registered via Update; see updatePSITriggers.
+	psiTriggerFiles map[string]*os.File
 }
 
 // NewRunc returns a new runc instance for a process
@@ -179,6 +183,31 @@ func (p *Init) Create(ctx context.Context, r *CreateConfig) (retError error) {
 	return nil
 }
 
+// Attach reconnects Init to an OCI runtime container that is already
+// running, without going through the normal create/start flow. This lets a
+// shim that was respawned after a crash re-adopt a container process that
+// survived it instead of treating the task as lost.
+//
+// The respawned shim is not the parent of pid, so it cannot reap its exit
+// status the normal way (via the SIGCHLD-driven reaper); instead the exit is
+// detected by polling for the process to disappear, and since the real exit
+// status can no longer be retrieved at that point, it is reported as killed.
+func (p *Init) Attach(pid int) {
+	p.pid = pid
+	go p.pollExit(pid)
+}
+
+func (p *Init) pollExit(pid int) {
+	const pollInterval = 1 * time.Second
+	for {
+		time.Sleep(pollInterval)
+		if err := unix.Kill(pid, 0); err == unix.ESRCH {
+			p.SetExited(137)
+			return
+		}
+	}
+}
+
 func (p *Init) openStdin(path string) error {
 	sc, err := fifo.OpenFifo(context.Background(), path, unix.O_WRONLY|unix.O_NONBLOCK, 0)
 	if err != nil {
@@ -190,11 +219,14 @@ func (p *Init) openStdin(path string) error {
 }
 
 func (p *Init) createCheckpointedState(r *CreateConfig, pidFile *pidFile) error {
+	lazyPages, pageServer := lazyPagesConfig(p.Bundle)
 	opts := &runc.RestoreOpts{
 		CheckpointOpts: runc.CheckpointOpts{
-			ImagePath:  r.Checkpoint,
-			WorkDir:    p.CriuWorkPath,
-			ParentPath: r.ParentCheckpoint,
+			ImagePath:      r.Checkpoint,
+			WorkDir:        p.CriuWorkPath,
+			ParentPath:     r.ParentCheckpoint,
+			LazyPages:      lazyPages,
+			CriuPageServer: pageServer,
 		},
 		PidFile:     pidFile.Path(),
 		NoPivot:     p.NoPivotRoot,
@@ -299,6 +331,7 @@ func (p *Init) Delete(ctx context.Context) error {
 
 func (p *Init) delete(ctx context.Context) error {
 	waitTimeout(ctx, &p.wg, 2*time.Second)
+	p.closePSITriggers()
 	err := p.runtime.Delete(ctx, p.id, nil)
 	// ignore errors if a runtime has already deleted the process
 	// but we still hold metadata and pipes
@@ -424,6 +457,38 @@ func (p *Init) exec(ctx context.Context, path string, r *ExecConfig) (Process, e
 	return e, nil
 }
 
+const (
+	// criuLazyPagesAnnotation enables CRIU's userfaultfd-based lazy-pages
+	// migration: the container starts running on restore as soon as its
+	// page server connection is up, with remaining pages faulted in over
+	// the network on demand instead of waiting for the full dump/restore.
+	criuLazyPagesAnnotation = "io.containerd.runc.v2.criu-lazy-pages"
+	// criuPageServerAnnotation gives the address:port of the criu page
+	// server to migrate pages from. It is only consulted on restore; a
+	// lazy-pages dump listens for the destination to connect to it.
+	criuPageServerAnnotation = "io.containerd.runc.v2.criu-page-server"
+)
+
+// lazyPagesConfig reads the CRIU lazy-pages migration settings from the
+// bundle's OCI spec annotations, since the task API's CheckpointOptions and
+// CreateConfig have no fields for them.
+func lazyPagesConfig(bundle string) (lazyPages bool, pageServer string) {
+	f, err := os.Open(filepath.Join(bundle, "config.json"))
+	if err != nil {
+		return false, ""
+	}
+	defer f.Close()
+
+	var s specs.Spec
+	if err := json.NewDecoder(f).Decode(&s); err != nil {
+		return false, ""
+	}
+
+	lazyPages = s.Annotations[criuLazyPagesAnnotation] == "true"
+	pageServer = s.Annotations[criuPageServerAnnotation]
+	return lazyPages, pageServer
+}
+
 // Checkpoint the init process
 func (p *Init) Checkpoint(ctx context.Context, r *CheckpointConfig) error {
 	p.mu.Lock()
@@ -443,6 +508,7 @@ func (p *Init) checkpoint(ctx context.Context, r *CheckpointConfig) error {
 		work = filepath.Join(p.WorkDir, "criu-work")
 		defer os.RemoveAll(work)
 	}
+	lazyPages, pageServer := lazyPagesConfig(p.Bundle)
 	if err := p.runtime.Checkpoint(ctx, p.id, &runc.CheckpointOpts{
 		WorkDir:                  work,
 		ImagePath:                r.Path,
@@ -451,6 +517,8 @@ func (p *Init) checkpoint(ctx context.Context, r *CheckpointConfig) error {
 		AllowTerminal:            r.AllowTerminal,
 		FileLocks:                r.FileLocks,
 		EmptyNamespaces:          r.EmptyNamespaces,
+		LazyPages:                lazyPages,
+		CriuPageServer:           pageServer,
 	}, actions...); err != nil {
 		dumpLog := filepath.Join(p.Bundle, "criu-dump.log")
 		if cerr := copyFile(dumpLog, filepath.Join(work, "dump.log")); cerr != nil {
@@ -462,19 +530,22 @@ func (p *Init) checkpoint(ctx context.Context, r *CheckpointConfig) error {
 }
 
 // Update the processes resource configuration
-func (p *Init) Update(ctx context.Context, r *google_protobuf.Any) error {
+func (p *Init) Update(ctx context.Context, r *google_protobuf.Any, annotations map[string]string) error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	return p.initState.Update(ctx, r)
+	return p.initState.Update(ctx, r, annotations)
 }
 
-func (p *Init) update(ctx context.Context, r *google_protobuf.Any) error {
+func (p *Init) update(ctx context.Context, r *google_protobuf.Any, annotations map[string]string) error {
 	var resources specs.LinuxResources
 	if err := json.Unmarshal(r.Value, &resources); err != nil {
 		return err
 	}
-	return p.runtime.Update(ctx, p.id, &resources)
+	if err := p.runtime.Update(ctx, p.id, &resources); err != nil {
+		return err
+	}
+	return p.updatePSITriggers(ctx, annotations)
 }
 
 // Stdio of the process
@@ -33,7 +33,7 @@ type initState interface {
 	Delete(context.Context) error
 	Pause(context.Context) error
 	Resume(context.Context) error
-	Update(context.Context, *google_protobuf.Any) error
+	Update(context.Context, *google_protobuf.Any, map[string]string) error
 	Checkpoint(context.Context, *CheckpointConfig) error
 	Exec(context.Context, string, *ExecConfig) (Process, error)
 	Kill(context.Context, uint32, bool) error
@@ -67,8 +67,8 @@ func (s *createdState) Resume(ctx context.Context) error {
 	return errors.New("cannot resume task in created state")
 }
 
-func (s *createdState) Update(ctx context.Context, r *google_protobuf.Any) error {
-	return s.p.update(ctx, r)
+func (s *createdState) Update(ctx context.Context, r *google_protobuf.Any, annotations map[string]string) error {
+	return s.p.update(ctx, r, annotations)
 }
 
 func (s *createdState) Checkpoint(ctx context.Context, r *CheckpointConfig) error {
@@ -136,8 +136,8 @@ func (s *createdCheckpointState) Resume(ctx context.Context) error {
 	return errors.New("cannot resume task in created state")
 }
 
-func (s *createdCheckpointState) Update(ctx context.Context, r *google_protobuf.Any) error {
-	return s.p.update(ctx, r)
+func (s *createdCheckpointState) Update(ctx context.Context, r *google_protobuf.Any, annotations map[string]string) error {
+	return s.p.update(ctx, r, annotations)
 }
 
 func (s *createdCheckpointState) Checkpoint(ctx context.Context, r *CheckpointConfig) error {
@@ -253,8 +253,8 @@ func (s *runningState) Resume(ctx context.Context) error {
 	return errors.New("cannot resume a running process")
 }
 
-func (s *runningState) Update(ctx context.Context, r *google_protobuf.Any) error {
-	return s.p.update(ctx, r)
+func (s *runningState) Update(ctx context.Context, r *google_protobuf.Any, annotations map[string]string) error {
+	return s.p.update(ctx, r, annotations)
 }
 
 func (s *runningState) Checkpoint(ctx context.Context, r *CheckpointConfig) error {
@@ -317,8 +317,8 @@ func (s *pausedState) Resume(ctx context.Context) error {
 	return s.transition("running")
 }
 
-func (s *pausedState) Update(ctx context.Context, r *google_protobuf.Any) error {
-	return s.p.update(ctx, r)
+func (s *pausedState) Update(ctx context.Context, r *google_protobuf.Any, annotations map[string]string) error {
+	return s.p.update(ctx, r, annotations)
 }
 
 func (s *pausedState) Checkpoint(ctx context.Context, r *CheckpointConfig) error {
@@ -379,7 +379,7 @@ func (s *stoppedState) Resume(ctx context.Context) error {
 	return errors.New("cannot resume a stopped container")
 }
 
-func (s *stoppedState) Update(ctx context.Context, r *google_protobuf.Any) error {
+func (s *stoppedState) Update(ctx context.Context, r *google_protobuf.Any, annotations map[string]string) error {
 	return errors.New("cannot update a stopped container")
 }
 
@@ -0,0 +1,102 @@
+//go:build linux
+
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package process
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/containerd/cgroups/v3"
+	cgroupsv2 "github.com/containerd/cgroups/v3/cgroup2"
+)
+
+// PSI pressure trigger annotations. The value is the raw trigger line as
+// documented in Documentation/accounting/psi.rst, e.g. "some 150000 1000000"
+// (stall type, threshold in us, tracking window in us). They are only
+// honored on cgroup v2, which is where the per-controller *.pressure files
+// accepting trigger registrations live.
+const (
+	psiMemoryTriggerAnnotation = "io.containerd.runc.v2.psi.memory"
+	psiCPUTriggerAnnotation    = "io.containerd.runc.v2.psi.cpu"
+	psiIOTriggerAnnotation     = "io.containerd.runc.v2.psi.io"
+)
+
+// psiTriggerFiles keeps the *.pressure files we've registered triggers on
+// open for the life of the process: the kernel removes a trigger as soon as
+// the file descriptor that wrote it is closed.
+func (p *Init) updatePSITriggers(ctx context.Context, annotations map[string]string) error {
+	if cgroups.Mode() != cgroups.Unified {
+		return nil
+	}
+
+	triggers := map[string]string{
+		"memory": annotations[psiMemoryTriggerAnnotation],
+		"cpu":    annotations[psiCPUTriggerAnnotation],
+		"io":     annotations[psiIOTriggerAnnotation],
+	}
+
+	group, err := cgroupsv2.PidGroupPath(p.Pid())
+	if err != nil {
+		return fmt.Errorf("failed to resolve cgroup for psi triggers: %w", err)
+	}
+	cgroupPath := filepath.Join("/sys/fs/cgroup", group)
+
+	for controller, trigger := range triggers {
+		if trigger == "" {
+			continue
+		}
+		if err := p.setPSITrigger(cgroupPath, controller, trigger); err != nil {
+			return fmt.Errorf("failed to set %s psi trigger: %w", controller, err)
+		}
+	}
+	return nil
+}
+
+func (p *Init) setPSITrigger(cgroupPath, controller, trigger string) error {
+	if p.psiTriggerFiles == nil {
+		p.psiTriggerFiles = make(map[string]*os.File)
+	}
+	if f, ok := p.psiTriggerFiles[controller]; ok {
+		f.Close()
+		delete(p.psiTriggerFiles, controller)
+	}
+
+	f, err := os.OpenFile(filepath.Join(cgroupPath, controller+".pressure"), os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(trigger); err != nil {
+		f.Close()
+		return err
+	}
+	// Kept open so the trigger stays registered; closed when the process is
+	// reaped (see delete) or replaced by a later trigger for the same
+	// controller.
+	p.psiTriggerFiles[controller] = f
+	return nil
+}
+
+func (p *Init) closePSITriggers() {
+	for _, f := range p.psiTriggerFiles {
+		f.Close()
+	}
+	p.psiTriggerFiles = nil
+}
@@ -134,7 +134,14 @@ func NewContainer(ctx context.Context, platform stdio.Platform, r *task.CreateTa
 	if err != nil {
 		return nil, errgrpc.ToGRPC(err)
 	}
-	if err := p.Create(ctx, config); err != nil {
+	// If the OCI runtime already reports this container as created or
+	// running, this shim was very likely respawned after the previous
+	// shim process for this bundle died: reattach to the surviving
+	// container instead of trying (and failing) to create it again.
+	if state, serr := p.Runtime().State(ctx, config.ID); serr == nil && (state.Status == "created" || state.Status == "running") {
+		log.G(ctx).WithField("id", config.ID).Info("reattaching to container left running by a previous shim")
+		p.Attach(state.Pid)
+	} else if err := p.Create(ctx, config); err != nil {
 		return nil, errgrpc.ToGRPC(err)
 	}
 	container := &Container{
@@ -468,7 +475,7 @@ func (c *Container) Update(ctx context.Context, r *task.UpdateTaskRequest) error
 	if err != nil {
 		return err
 	}
-	return p.(*process.Init).Update(ctx, r.Resources)
+	return p.(*process.Init).Update(ctx, r.Resources, r.Annotations)
 }
 
 // HasPid returns true if the container owns a specific pid
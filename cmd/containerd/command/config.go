@@ -18,8 +18,10 @@ package command
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/containerd/containerd/v2/cmd/containerd/server"
 	srvconfig "github.com/containerd/containerd/v2/cmd/containerd/server/config"
@@ -102,9 +104,49 @@ var configCommand = &cli.Command{
 			// TODO(vinayakankugoyal): This should not output fields that were not set in the current configuration.
 			Action: dumpConfig,
 		},
+		{
+			Name:  "provenance",
+			Usage: "Show the source (default, main config file, or an import) of every effective config field",
+			Action: func(cliContext *cli.Context) error {
+				return dumpProvenance(cliContext, cliContext.String("field"))
+			},
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "field",
+					Usage: "Only show the provenance of field paths with this prefix (e.g. plugins[io.containerd.snapshotter.v1.overlayfs])",
+				},
+			},
+		},
 	},
 }
 
+// dumpProvenance prints, for every field of the effective config (built-in
+// defaults merged with the main config file and whatever it imports), the
+// file responsible for its current value, so "why is this snapshotter my
+// default" can be answered by running a command instead of diffing config
+// fragments by hand. Plugin configs are only as deep as what's already
+// in config.Plugins (unmigrated, undecoded), not the typed config each
+// plugin decodes it into: both dumpConfig and this command agree on that.
+func dumpProvenance(cliContext *cli.Context, fieldPrefix string) error {
+	config := defaultConfig()
+	ctx := cliContext.Context
+	prov, err := srvconfig.LoadConfigWithProvenance(ctx, cliContext.String("config"), config)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+		prov = srvconfig.DefaultProvenance(config)
+	}
+
+	for _, field := range prov.Sorted() {
+		if fieldPrefix != "" && !strings.HasPrefix(field, fieldPrefix) {
+			continue
+		}
+		fmt.Fprintf(os.Stdout, "%s\t%s\n", field, prov[field])
+	}
+	return nil
+}
+
 func dumpConfig(cliContext *cli.Context) error {
 	config := defaultConfig()
 	ctx := cliContext.Context
@@ -117,6 +117,7 @@ can be used and modified as necessary as a custom configuration.`
 		configCommand,
 		publishCommand,
 		ociHook,
+		migrateCheckCommand,
 	}
 	app.Action = func(cliContext *cli.Context) error {
 		var (
@@ -168,7 +169,7 @@ can be used and modified as necessary as a custom configuration.`
 			return nil
 		}
 
-		done := handleSignals(ctx, signals, serverC, cancel)
+		done := handleSignals(ctx, signals, serverC, cancel, configPath)
 		// start the signal handler as soon as we can to make sure that
 		// we don't miss any signals during boot
 		signal.Notify(signals, handledSignals...)
@@ -24,6 +24,7 @@ import (
 	"path/filepath"
 
 	"github.com/containerd/containerd/v2/cmd/containerd/server"
+	srvconfig "github.com/containerd/containerd/v2/cmd/containerd/server/config"
 	"github.com/containerd/log"
 	"golang.org/x/sys/unix"
 )
@@ -33,9 +34,10 @@ var handledSignals = []os.Signal{
 	unix.SIGINT,
 	unix.SIGUSR1,
 	unix.SIGPIPE,
+	unix.SIGHUP,
 }
 
-func handleSignals(ctx context.Context, signals chan os.Signal, serverC chan *server.Server, cancel func()) chan struct{} {
+func handleSignals(ctx context.Context, signals chan os.Signal, serverC chan *server.Server, cancel func(), configPath string) chan struct{} {
 	done := make(chan struct{}, 1)
 	go func() {
 		var server *server.Server
@@ -55,6 +57,12 @@ func handleSignals(ctx context.Context, signals chan os.Signal, serverC chan *se
 				switch s {
 				case unix.SIGUSR1:
 					dumpStacks(true)
+				case unix.SIGHUP:
+					if server == nil {
+						log.G(ctx).Warn("ignoring SIGHUP: server is not yet running")
+						continue
+					}
+					reloadConfig(ctx, configPath, server)
 				default:
 					if err := notifyStopping(ctx); err != nil {
 						log.G(ctx).WithError(err).Error("notify stopping failed")
@@ -76,3 +84,22 @@ func handleSignals(ctx context.Context, signals chan os.Signal, serverC chan *se
 func isLocalAddress(path string) bool {
 	return filepath.IsAbs(path)
 }
+
+// reloadConfig re-reads the daemon's configuration file from configPath and
+// applies it to srv, in response to SIGHUP. Only plugins that opt into the
+// reload package's Validator/Reloader interfaces are affected; everything
+// else keeps running with its original configuration until the next
+// restart. A configuration that fails validation, or that a plugin
+// rejects while applying it, is logged and otherwise ignored: the daemon
+// keeps running with its previous configuration rather than exiting.
+func reloadConfig(ctx context.Context, configPath string, srv *server.Server) {
+	log.G(ctx).WithField("path", configPath).Info("reloading configuration")
+	config := defaultConfig()
+	if err := srvconfig.LoadConfig(ctx, configPath, config); err != nil {
+		log.G(ctx).WithError(err).Error("failed to load configuration for reload")
+		return
+	}
+	if err := srv.ReloadConfig(ctx, config); err != nil {
+		log.G(ctx).WithError(err).Error("failed to reload configuration")
+	}
+}
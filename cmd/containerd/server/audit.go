@@ -0,0 +1,132 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	srvconfig "github.com/containerd/containerd/v2/cmd/containerd/server/config"
+	"github.com/containerd/containerd/v2/pkg/audit"
+	"github.com/containerd/containerd/v2/pkg/namespaces"
+	"github.com/containerd/log"
+)
+
+// readOnlyMethodPrefixes names the gRPC method-name verbs (the part after
+// the last "/" in the full method, e.g. "Get" in
+// "/containerd.services.containers.v1.Containers/Get") this package
+// treats as non-mutating and therefore not audit logged. A method name
+// matches a prefix only at a camelCase word boundary - either the name is
+// exactly the prefix, or the prefix is immediately followed by an
+// uppercase letter (e.g. "ListPids" matches "List", but "Checkpoint" does
+// not match "Check") - so a verb can't accidentally swallow an unrelated,
+// longer method name that merely happens to start with the same letters.
+// This is still a naming convention, not something the proto definitions
+// assert formally, so a handler that doesn't follow it (a "List" that
+// writes, a "Set" that doesn't) would be mis-classified; it's a
+// reasonable default, not a guarantee.
+var readOnlyMethodPrefixes = []string{
+	"Get", "List", "Info", "Stat", "Status", "Read", "Version",
+	"Check", "Watch", "Subscribe",
+}
+
+func isMutatingMethod(fullMethod string) bool {
+	name := fullMethod
+	if idx := strings.LastIndex(fullMethod, "/"); idx >= 0 {
+		name = fullMethod[idx+1:]
+	}
+	for _, prefix := range readOnlyMethodPrefixes {
+		if name == prefix {
+			return false
+		}
+		if rest, ok := strings.CutPrefix(name, prefix); ok && rest[0] >= 'A' && rest[0] <= 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// setupAuditLog opens the audit log file named in cfg, creating its
+// parent directory if necessary.
+func setupAuditLog(cfg srvconfig.AuditConfig) (*os.File, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("audit.path must be set when audit logging is enabled")
+	}
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0711); err != nil {
+		return nil, fmt.Errorf("creating audit log directory: %w", err)
+	}
+	f, err := os.OpenFile(cfg.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("opening audit log %s: %w", cfg.Path, err)
+	}
+	return f, nil
+}
+
+// newAuditUnaryInterceptor returns an interceptor that logs one audit.Entry
+// per mutating gRPC call (see isMutatingMethod) to logger, recording the
+// method, namespace, peer address, a redacted summary of the request, and
+// the outcome.
+//
+// The peer address is the best caller identity available without a
+// custom transport: containerd's local listener doesn't currently expose
+// SO_PEERCRED (the connecting process's uid/gid/pid), so on a unix socket
+// this is usually just the abstract/socket path rather than a specific
+// caller identity. Wiring real peer credentials through would mean
+// replacing the plain net.Listener with one that captures ucred at
+// accept time and carries it as grpc credentials.AuthInfo - a bigger,
+// transport-level change than this pass; logging what's reachable today
+// (method, namespace, request shape, outcome) is still useful for an
+// audit trail even without it.
+func newAuditUnaryInterceptor(logger *audit.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !isMutatingMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		resp, err := handler(ctx, req)
+
+		entry := audit.Entry{
+			Time:   time.Now().UTC(),
+			Method: info.FullMethod,
+			Code:   status.Code(err).String(),
+			Args:   logger.Summarize(req),
+		}
+		if ns, ok := namespaces.Namespace(ctx); ok {
+			entry.Namespace = ns
+		}
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			entry.Caller = p.Addr.String()
+		}
+		if err != nil {
+			entry.Error = err.Error()
+		}
+
+		if logErr := logger.Log(entry); logErr != nil {
+			log.G(ctx).WithError(logErr).Error("failed writing audit log entry")
+		}
+
+		return resp, err
+	}
+}
@@ -0,0 +1,119 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIsMutatingMethod enumerates every RPC defined across
+// api/services/**/*.proto (as of this writing) against isMutatingMethod,
+// so a change to readOnlyMethodPrefixes can't silently stop audit-logging
+// a mutating call, or start skipping one, without a test failing here.
+//
+// wantMutating is hand classified from each RPC's actual behavior, not
+// derived from the prefix table itself - the point is to catch the table
+// disagreeing with reality, as it did for Tasks.Checkpoint matching the
+// "Check" prefix meant for read verbs.
+func TestIsMutatingMethod(t *testing.T) {
+	type rpc struct {
+		method       string
+		wantMutating bool
+	}
+
+	var rpcs []rpc
+	service := func(pkg, svc string, methods ...rpc) {
+		for _, m := range methods {
+			m.method = fmt.Sprintf("/%s.%s/%s", pkg, svc, m.method)
+			rpcs = append(rpcs, m)
+		}
+	}
+	ro := func(name string) rpc { return rpc{method: name, wantMutating: false} }
+	rw := func(name string) rpc { return rpc{method: name, wantMutating: true} }
+
+	service("containerd.services.containers.v1", "Containers",
+		ro("Get"), ro("List"), ro("ListStream"), rw("Create"), rw("Update"), rw("Delete"),
+	)
+	service("containerd.services.content.v1", "Content",
+		ro("Info"), rw("Update"), ro("List"), rw("Delete"), ro("Read"), ro("Status"),
+		ro("ListStatuses"), rw("Write"), rw("Abort"),
+	)
+	service("containerd.services.diff.v1", "Diff",
+		rw("Apply"), rw("Diff"),
+	)
+	service("containerd.services.events.v1", "Events",
+		rw("Publish"), rw("Forward"), ro("Subscribe"),
+	)
+	service("containerd.services.events.ttrpc.v1", "Events",
+		rw("Forward"),
+	)
+	service("containerd.services.images.v1", "Images",
+		ro("Get"), ro("List"), rw("Create"), rw("Update"), rw("Delete"),
+	)
+	service("containerd.services.introspection.v1", "Introspection",
+		rw("Plugins"), rw("Server"), rw("PluginInfo"),
+	)
+	service("containerd.services.leases.v1", "Leases",
+		rw("Create"), rw("Delete"), ro("List"), rw("AddResource"), rw("DeleteResource"), ro("ListResources"),
+	)
+	service("containerd.services.namespaces.v1", "Namespaces",
+		ro("Get"), ro("List"), rw("Create"), rw("Update"), rw("Delete"),
+	)
+	service("containerd.services.sandbox.v1", "Store",
+		rw("Create"), rw("Update"), rw("Delete"), ro("List"), ro("Get"),
+	)
+	service("containerd.services.sandbox.v1", "Controller",
+		rw("Create"), rw("Start"), rw("Platform"), rw("Stop"), rw("Wait"), ro("Status"),
+		rw("Shutdown"), rw("Metrics"), rw("Update"),
+	)
+	service("containerd.services.snapshots.v1", "Snapshots",
+		rw("Prepare"), rw("View"), rw("Mounts"), rw("Commit"), rw("Remove"), ro("Stat"),
+		rw("Update"), ro("List"), rw("Usage"), rw("Cleanup"),
+	)
+	service("containerd.services.streaming.v1", "Streaming",
+		rw("Stream"),
+	)
+	service("containerd.services.tasks.v1", "Tasks",
+		rw("Create"), rw("Start"), rw("Delete"), rw("DeleteProcess"), ro("Get"), ro("List"),
+		rw("Kill"), rw("Exec"), rw("ResizePty"), rw("CloseIO"), rw("Pause"), rw("Resume"),
+		ro("ListPids"), rw("Checkpoint"), rw("Update"), rw("Metrics"), rw("Wait"),
+	)
+	service("containerd.services.transfer.v1", "Transfer",
+		rw("Transfer"),
+	)
+	service("containerd.services.version.v1", "Version",
+		ro("Version"),
+	)
+
+	for _, r := range rpcs {
+		r := r
+		t.Run(r.method, func(t *testing.T) {
+			assert.Equal(t, r.wantMutating, isMutatingMethod(r.method))
+		})
+	}
+}
+
+// TestIsMutatingMethodCheckpointNotConfusedWithCheck guards the specific
+// regression this table is here to prevent: Checkpoint dumps and persists
+// process/container state, but its name starts with the same letters as
+// the "Check" read-verb prefix.
+func TestIsMutatingMethodCheckpointNotConfusedWithCheck(t *testing.T) {
+	assert.True(t, isMutatingMethod("/containerd.services.tasks.v1.Tasks/Checkpoint"))
+}
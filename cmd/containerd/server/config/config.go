@@ -91,6 +91,10 @@ type Config struct {
 	Imports []string `toml:"imports"`
 	// StreamProcessors configuration
 	StreamProcessors map[string]StreamProcessor `toml:"stream_processors"`
+	// Audit configuration for logging mutating API calls
+	Audit AuditConfig `toml:"audit"`
+	// RateLimit configuration for limiting the rate of GRPC API calls
+	RateLimit RateLimitConfig `toml:"rate_limit"`
 }
 
 // StreamProcessor provides configuration for diff content processors
@@ -238,6 +242,63 @@ type MetricsConfig struct {
 	GRPCHistogram bool   `toml:"grpc_histogram"`
 }
 
+// AuditConfig provides configuration for the optional audit log, a
+// structured JSON record of mutating API calls kept separate from
+// containerd's regular debug/info logging so it can be shipped and
+// retained under its own policy.
+type AuditConfig struct {
+	// Enabled turns the audit log on. Off by default: writing a second,
+	// separate log of every mutating call is a cost most installs don't
+	// want to pay.
+	Enabled bool `toml:"enabled"`
+	// Path is the file the audit log is appended to. Required when
+	// Enabled is true.
+	Path string `toml:"path"`
+	// RedactFields lists top-level request field names (snake_case, as
+	// they appear in the proto) whose value is replaced with
+	// "[REDACTED]" in the logged argument summary instead of being
+	// recorded, e.g. for a field that can carry registry credentials.
+	// Message-typed and bytes fields are never included in the summary
+	// regardless of this list; it only affects scalar fields that would
+	// otherwise be logged.
+	RedactFields []string `toml:"redact_fields"`
+}
+
+// RateLimitConfig provides configuration for the optional GRPC API rate
+// limiter, which protects the daemon from a single caller hammering it
+// with requests (e.g. a buggy client polling ListContainers or Stats in
+// a tight loop).
+type RateLimitConfig struct {
+	// Enabled turns the rate limiter on. Off by default.
+	Enabled bool `toml:"enabled"`
+	// QPS is the default sustained requests-per-second limit applied per
+	// caller (see PerCaller) to any method not listed in PerMethodQPS.
+	QPS float64 `toml:"qps"`
+	// Burst is the default burst size applied per caller to any method
+	// not listed in PerMethodBurst.
+	Burst int `toml:"burst"`
+	// PerMethodQPS overrides QPS for specific GRPC methods, keyed by the
+	// method's short name (the part after the last "/", e.g. "List" for
+	// "/containerd.services.containers.v1.Containers/List").
+	PerMethodQPS map[string]float64 `toml:"per_method_qps"`
+	// PerMethodBurst overrides Burst for specific GRPC methods, keyed the
+	// same way as PerMethodQPS.
+	PerMethodBurst map[string]int `toml:"per_method_burst"`
+	// PerCaller limits are tracked separately for each caller (identified
+	// by unix socket peer address, the same best-available identity used
+	// by the audit log - see newAuditUnaryInterceptor) rather than shared
+	// across all callers. When false, each method has a single limiter
+	// shared by every caller.
+	PerCaller bool `toml:"per_caller"`
+	// MaxTrackedCallers caps how many distinct callers are tracked at
+	// once when PerCaller is true, evicting the least recently used
+	// caller once the cap is reached; this bounds the memory an
+	// unauthenticated, ever-changing set of callers can consume. Only
+	// meaningful when PerCaller is true. Defaults to a built-in limit if
+	// left at zero.
+	MaxTrackedCallers int `toml:"max_tracked_callers"`
+}
+
 // CgroupConfig provides cgroup configuration
 type CgroupConfig struct {
 	Path string `toml:"path"`
@@ -250,6 +311,24 @@ type ProxyPlugin struct {
 	Platform     string            `toml:"platform"`
 	Exports      map[string]string `toml:"exports"`
 	Capabilities []string          `toml:"capabilities"`
+
+	// TLS configures mutual TLS for Address. Set this to connect to an
+	// off-node proxy plugin over a TCP gRPC endpoint (e.g.
+	// "host:port") instead of the default local unix socket.
+	TLS *ProxyPluginTLSConfig `toml:"tls"`
+}
+
+// ProxyPluginTLSConfig configures mutual TLS for an off-node proxy
+// plugin's gRPC endpoint.
+type ProxyPluginTLSConfig struct {
+	// CACert is the path to the CA certificate used to verify the proxy
+	// plugin's server certificate.
+	CACert string `toml:"ca_cert"`
+	// Cert is the path to containerd's client certificate, presented to
+	// the proxy plugin for mutual TLS.
+	Cert string `toml:"cert"`
+	// Key is the path to containerd's client private key matching Cert.
+	Key string `toml:"key"`
 }
 
 // Decode unmarshals a plugin specific configuration by plugin id
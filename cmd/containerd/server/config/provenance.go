@@ -0,0 +1,292 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// ProvenanceDefault is the source recorded for a field that was never set by
+// any config file or import, i.e. it still holds the value it was given
+// before LoadConfigWithProvenance was called (typically the built-in
+// default config).
+const ProvenanceDefault = "default"
+
+// Provenance maps a dotted config field path (following each field's "toml"
+// tag, with map entries rendered as "field[key]") to the file that last set
+// it, or to ProvenanceDefault if no loaded file ever changed it.
+//
+// Only fields that LoadConfigWithProvenance actually observed changing are
+// present with a non-default source; a whole slice (e.g. disabled_plugins)
+// is attributed to a single file as one unit rather than per element, since
+// mergeConfig's append/de-dup behavior for slices makes per-element
+// attribution ambiguous.
+type Provenance map[string]string
+
+// LoadConfigWithProvenance behaves exactly like LoadConfig, but additionally
+// returns a Provenance recording, for every field the resulting Config
+// carries a non-default value for, which file (main config or an import) is
+// responsible for it. It exists to answer questions like "why is this
+// snapshotter my default" without manually diffing the main config against
+// every file it imports.
+func LoadConfigWithProvenance(ctx context.Context, path string, out *Config) (Provenance, error) {
+	if out == nil {
+		return nil, fmt.Errorf("argument out must not be nil")
+	}
+
+	prov := Provenance{}
+
+	var (
+		loaded  = map[string]bool{}
+		pending = []string{path}
+	)
+
+	for len(pending) > 0 {
+		p, rest := pending[0], pending[1:]
+		pending = rest
+
+		if _, ok := loaded[p]; ok {
+			continue
+		}
+
+		config, err := loadConfigFile(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+
+		switch config.Version {
+		case 0, 1:
+			if err := config.MigrateConfigTo(ctx, out.Version); err != nil {
+				return nil, err
+			}
+		default:
+			// NOP
+		}
+
+		// out's map fields are shared, mutable references: a shallow copy
+		// of *out taken here would still observe mergeConfig's writes, so
+		// round-trip through TOML to get an independent snapshot to diff
+		// the post-merge state against.
+		before, err := deepCopyConfig(out)
+		if err != nil {
+			return nil, err
+		}
+		if err := mergeConfig(out, config); err != nil {
+			return nil, err
+		}
+		diffProvenance("", reflect.ValueOf(*before), reflect.ValueOf(*out), p, prov)
+
+		imports, err := resolveImports(p, config.Imports)
+		if err != nil {
+			return nil, err
+		}
+
+		loaded[p] = true
+		pending = append(pending, imports...)
+	}
+
+	fillDefaultProvenance("", reflect.ValueOf(*out), prov)
+
+	if err := out.ValidateVersion(); err != nil {
+		return nil, fmt.Errorf("failed to load TOML from %s: %w", path, err)
+	}
+	return prov, nil
+}
+
+// DefaultProvenance returns a Provenance attributing every field of c to
+// ProvenanceDefault. It's for callers that already know no config file was
+// merged into c, e.g. because LoadConfigWithProvenance returned a
+// not-exist error for the main config path - the same case in which
+// LoadConfig's own callers fall back to treating c as all-defaults.
+func DefaultProvenance(c *Config) Provenance {
+	prov := Provenance{}
+	fillDefaultProvenance("", reflect.ValueOf(*c), prov)
+	return prov
+}
+
+// Sorted returns prov's paths in lexical order, for stable output.
+func (prov Provenance) Sorted() []string {
+	paths := make([]string, 0, len(prov))
+	for p := range prov {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func fieldPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// tomlName returns the field's toml tag name, falling back to the Go field
+// name for untagged fields (matching how the toml package itself behaves).
+func tomlName(f reflect.StructField) string {
+	tag := f.Tag.Get("toml")
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" || name == "-" {
+		return f.Name
+	}
+	return name
+}
+
+// diffProvenance records, for every field that differs between before and
+// after, that source is responsible for the new value in after.
+func diffProvenance(prefix string, before, after reflect.Value, source string, out Provenance) {
+	if !after.IsValid() {
+		return
+	}
+
+	switch after.Kind() {
+	case reflect.Ptr:
+		if after.IsNil() {
+			return
+		}
+		if !validOfKind(before, reflect.Ptr) || before.IsNil() {
+			out[prefix] = source
+			return
+		}
+		diffProvenance(prefix, before.Elem(), after.Elem(), source, out)
+
+	case reflect.Struct:
+		t := after.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" {
+				continue // unexported
+			}
+			var beforeField reflect.Value
+			if validOfKind(before, reflect.Struct) {
+				beforeField = before.Field(i)
+			}
+			diffProvenance(fieldPath(prefix, tomlName(f)), beforeField, after.Field(i), source, out)
+		}
+
+	case reflect.Map:
+		iter := after.MapRange()
+		for iter.Next() {
+			k := iter.Key()
+			path := fmt.Sprintf("%s[%v]", prefix, k.Interface())
+			var beforeVal reflect.Value
+			if validOfKind(before, reflect.Map) {
+				beforeVal = before.MapIndex(k)
+			}
+			diffProvenance(path, beforeVal, iter.Value(), source, out)
+		}
+
+	case reflect.Interface:
+		if after.IsNil() {
+			return
+		}
+		var beforeElem reflect.Value
+		if validOfKind(before, reflect.Interface) && !before.IsNil() {
+			beforeElem = before.Elem()
+		}
+		diffProvenance(prefix, beforeElem, after.Elem(), source, out)
+
+	default:
+		// Slices, scalars and everything else are attributed to source as
+		// a single unit if they changed at all: mergeConfig's append/de-dup
+		// semantics for slices make per-element attribution ambiguous, and
+		// there's no coarser-grained equivalent worth building for scalars.
+		if !before.IsValid() || !reflect.DeepEqual(before.Interface(), after.Interface()) {
+			if !isZeroValue(after) {
+				out[prefix] = source
+			}
+		}
+	}
+}
+
+// fillDefaultProvenance records ProvenanceDefault for every field that
+// diffProvenance never attributed to a loaded file; such a field was never
+// touched by the merge loop, so it still holds whatever value out had
+// before LoadConfigWithProvenance started merging files into it.
+func fillDefaultProvenance(prefix string, v reflect.Value, out Provenance) {
+	if !v.IsValid() {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		fillDefaultProvenance(prefix, v.Elem(), out)
+
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+			fillDefaultProvenance(fieldPath(prefix, tomlName(f)), v.Field(i), out)
+		}
+
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			path := fmt.Sprintf("%s[%v]", prefix, iter.Key().Interface())
+			fillDefaultProvenance(path, iter.Value(), out)
+		}
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		fillDefaultProvenance(prefix, v.Elem(), out)
+
+	default:
+		if _, ok := out[prefix]; ok {
+			return
+		}
+		if isZeroValue(v) {
+			return
+		}
+		out[prefix] = ProvenanceDefault
+	}
+}
+
+// deepCopyConfig returns an independent copy of c, including its map and
+// slice fields, by round-tripping it through TOML.
+func deepCopyConfig(c *Config) (*Config, error) {
+	b, err := toml.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	cp := &Config{}
+	if err := toml.Unmarshal(b, cp); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+func validOfKind(v reflect.Value, kind reflect.Kind) bool {
+	return v.IsValid() && v.Kind() == kind
+}
+
+func isZeroValue(v reflect.Value) bool {
+	return !v.IsValid() || v.IsZero()
+}
@@ -0,0 +1,95 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTempConfig(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	p := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	return p
+}
+
+func TestLoadConfigWithProvenance(t *testing.T) {
+	tempDir := t.TempDir()
+
+	imported := writeTempConfig(t, tempDir, "snapshotter.toml", `
+version = 2
+
+[plugins."io.containerd.snapshotter.v1.overlayfs"]
+root_path = "/var/lib/imported-overlay"
+`)
+
+	main := writeTempConfig(t, tempDir, "main.toml", `
+version = 2
+root = "/var/lib/containerd-custom"
+
+imports = ["`+filepath.Base(imported)+`"]
+
+[plugins."io.containerd.snapshotter.v1.overlayfs"]
+upperdir_label = true
+`)
+
+	ctx := context.Background()
+	out := &Config{Version: 2, Root: "/var/lib/containerd", State: "/run/containerd"}
+
+	prov, err := LoadConfigWithProvenance(ctx, main, out)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/var/lib/containerd-custom", out.Root)
+	assert.Equal(t, main, prov["root"])
+
+	// State was never set by any file, so it's still the default it had
+	// before LoadConfigWithProvenance started merging.
+	assert.Equal(t, ProvenanceDefault, prov["state"])
+
+	pluginKey := `plugins[io.containerd.snapshotter.v1.overlayfs]`
+	plugins, ok := out.Plugins["io.containerd.snapshotter.v1.overlayfs"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "/var/lib/imported-overlay", plugins["root_path"])
+	assert.Equal(t, true, plugins["upperdir_label"])
+
+	assert.Equal(t, imported, prov[pluginKey+"[root_path]"])
+	assert.Equal(t, main, prov[pluginKey+"[upperdir_label]"])
+}
+
+func TestLoadConfigWithProvenanceNoFile(t *testing.T) {
+	tempDir := t.TempDir()
+	out := &Config{Version: 2, Root: "/var/lib/containerd"}
+
+	_, err := LoadConfigWithProvenance(context.Background(), filepath.Join(tempDir, "missing.toml"), out)
+	assert.Error(t, err)
+}
+
+func TestDiffProvenanceSlice(t *testing.T) {
+	prov := Provenance{}
+	before := Config{DisabledPlugins: []string{"a"}}
+	after := Config{DisabledPlugins: []string{"a", "b"}}
+
+	diffProvenance("", reflect.ValueOf(before), reflect.ValueOf(after), "file.toml", prov)
+	assert.Equal(t, "file.toml", prov["disabled_plugins"])
+}
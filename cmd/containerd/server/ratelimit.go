@@ -0,0 +1,56 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"google.golang.org/grpc"
+
+	srvconfig "github.com/containerd/containerd/v2/cmd/containerd/server/config"
+	"github.com/containerd/containerd/v2/pkg/ratelimit"
+)
+
+// newRateLimitUnaryInterceptor builds a ratelimit.Limiter from cfg and
+// returns its GRPC interceptor, recording an allowed/throttled count per
+// method via ratelimit.RecordMetrics.
+func newRateLimitUnaryInterceptor(cfg srvconfig.RateLimitConfig) grpc.UnaryServerInterceptor {
+	rlCfg := ratelimit.Config{
+		Default:           ratelimit.Limits{QPS: cfg.QPS, Burst: cfg.Burst},
+		PerCaller:         cfg.PerCaller,
+		MaxTrackedCallers: cfg.MaxTrackedCallers,
+	}
+	if len(cfg.PerMethodQPS) > 0 || len(cfg.PerMethodBurst) > 0 {
+		rlCfg.PerMethod = make(map[string]ratelimit.Limits, len(cfg.PerMethodQPS))
+		for method, qps := range cfg.PerMethodQPS {
+			limits := rlCfg.PerMethod[method]
+			limits.QPS = qps
+			if limits.Burst == 0 {
+				limits.Burst = cfg.Burst
+			}
+			rlCfg.PerMethod[method] = limits
+		}
+		for method, burst := range cfg.PerMethodBurst {
+			limits := rlCfg.PerMethod[method]
+			if limits.QPS == 0 {
+				limits.QPS = cfg.QPS
+			}
+			limits.Burst = burst
+			rlCfg.PerMethod[method] = limits
+		}
+	}
+
+	return ratelimit.New(rlCfg).UnaryServerInterceptor(ratelimit.RecordMetrics)
+}
@@ -0,0 +1,102 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/containerd/log"
+
+	srvconfig "github.com/containerd/containerd/v2/cmd/containerd/server/config"
+	"github.com/containerd/containerd/v2/pkg/reload"
+)
+
+// ValidateConfig decodes newConfig's plugin sections against every loaded
+// plugin that implements reload.Validator and reports the first
+// rejection, without changing anything. It is meant to let an operator
+// (or an admin-facing caller) check a proposed configuration file before
+// asking the daemon to reload it.
+func (s *Server) ValidateConfig(newConfig *srvconfig.Config) error {
+	candidates, err := s.reloadCandidates(context.Background(), newConfig)
+	if err != nil {
+		return err
+	}
+	for _, c := range candidates {
+		v, ok := c.Instance.(reload.Validator)
+		if !ok {
+			continue
+		}
+		if err := v.ValidateConfig(c.Config); err != nil {
+			return fmt.Errorf("validate config for %s: %w", c.ID, err)
+		}
+	}
+	return nil
+}
+
+// ReloadConfig validates newConfig against every loaded plugin that
+// implements reload.Validator and, only if all of them accept it, applies
+// it to every loaded plugin that implements reload.Reloader. Plugins that
+// implement neither interface keep running with their original
+// configuration: reloading only ever affects the subset of plugins that
+// opted in, everything else still requires a restart to pick up a
+// changed configuration.
+func (s *Server) ReloadConfig(ctx context.Context, newConfig *srvconfig.Config) error {
+	candidates, err := s.reloadCandidates(ctx, newConfig)
+	if err != nil {
+		return err
+	}
+	if err := reload.Stage(ctx, candidates); err != nil {
+		return err
+	}
+	for _, c := range candidates {
+		if _, ok := c.Instance.(reload.Reloader); ok {
+			log.G(ctx).WithField("id", c.ID).Info("reloaded plugin configuration")
+		}
+	}
+	return nil
+}
+
+// reloadCandidates decodes newConfig's plugin section for every loaded
+// plugin that declared a Config type in its registration, regardless of
+// whether that plugin implements reload.Validator or reload.Reloader;
+// reload.Stage is responsible for skipping the ones that don't.
+func (s *Server) reloadCandidates(ctx context.Context, newConfig *srvconfig.Config) ([]reload.Candidate, error) {
+	var candidates []reload.Candidate
+	for _, p := range s.plugins {
+		if p.Registration.Config == nil {
+			continue
+		}
+		instance, err := p.Instance()
+		if err != nil {
+			continue
+		}
+		id := p.Registration.URI()
+		cfg := reflect.New(reflect.TypeOf(p.Registration.Config).Elem()).Interface()
+		decoded, err := newConfig.Decode(ctx, id, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("decode config for %s: %w", id, err)
+		}
+		candidates = append(candidates, reload.Candidate{
+			ID:       id,
+			Instance: instance,
+			Config:   decoded,
+		})
+	}
+	return candidates, nil
+}
@@ -58,6 +58,7 @@ import (
 	sbproxy "github.com/containerd/containerd/v2/core/sandbox/proxy"
 	ssproxy "github.com/containerd/containerd/v2/core/snapshots/proxy"
 	"github.com/containerd/containerd/v2/defaults"
+	"github.com/containerd/containerd/v2/pkg/audit"
 	"github.com/containerd/containerd/v2/pkg/dialer"
 	"github.com/containerd/containerd/v2/pkg/sys"
 	"github.com/containerd/containerd/v2/pkg/timeout"
@@ -148,11 +149,23 @@ func New(ctx context.Context, config *srvconfig.Config) (*Server, error) {
 		diff.RegisterProcessor(diff.BinaryHandler(id, p.Returns, p.Accepts, p.Path, p.Args, p.Env))
 	}
 
+	unaryInterceptors := []grpc.UnaryServerInterceptor{unaryNamespaceInterceptor}
+	if config.RateLimit.Enabled {
+		unaryInterceptors = append(unaryInterceptors, newRateLimitUnaryInterceptor(config.RateLimit))
+	}
+	var auditFile *os.File
+	if config.Audit.Enabled {
+		auditFile, err = setupAuditLog(config.Audit)
+		if err != nil {
+			return nil, err
+		}
+		unaryInterceptors = append(unaryInterceptors, newAuditUnaryInterceptor(audit.NewLogger(auditFile, config.Audit.RedactFields)))
+	}
 
 	serverOpts := []grpc.ServerOption{
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc.ChainStreamInterceptor(streamNamespaceInterceptor),
-		grpc.ChainUnaryInterceptor(unaryNamespaceInterceptor),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
 	}
 	if config.GRPC.MaxRecvMsgSize > 0 {
 		serverOpts = append(serverOpts, grpc.MaxRecvMsgSize(config.GRPC.MaxRecvMsgSize))
@@ -212,10 +225,11 @@ func New(ctx context.Context, config *srvconfig.Config) (*Server, error) {
 		ttrpcServices []ttrpcService
 
 		s = &Server{
-			grpcServer:              grpcServer,
-			tcpServer:               tcpServer,
-			ttrpcServer:             ttrpcServer,
-			config:                  config,
+			grpcServer:  grpcServer,
+			tcpServer:   tcpServer,
+			ttrpcServer: ttrpcServer,
+			config:      config,
+			auditFile:   auditFile,
 		}
 		initialized = plugin.NewPluginSet()
 		required    = make(map[string]struct{})
@@ -362,12 +376,13 @@ func recordConfigDeprecations(ctx context.Context, config *srvconfig.Config, set
 
 // Server is the containerd main daemon
 type Server struct {
-	grpcServer              *grpc.Server
-	ttrpcServer             *ttrpc.Server
-	tcpServer               *grpc.Server
-	config                  *srvconfig.Config
-	plugins                 []*plugin.Plugin
-	ready                   sync.WaitGroup
+	grpcServer  *grpc.Server
+	ttrpcServer *ttrpc.Server
+	tcpServer   *grpc.Server
+	config      *srvconfig.Config
+	plugins     []*plugin.Plugin
+	ready       sync.WaitGroup
+	auditFile   *os.File
 }
 
 // ServeGRPC provides the containerd grpc APIs on the provided listener
@@ -417,6 +432,11 @@ func (s *Server) ServeDebug(l net.Listener) error {
 // Stop the containerd server canceling any open connections
 func (s *Server) Stop() {
 	s.grpcServer.Stop()
+	if s.auditFile != nil {
+		if err := s.auditFile.Close(); err != nil {
+			log.L.WithError(err).Error("failed to close audit log")
+		}
+	}
 	for i := len(s.plugins) - 1; i >= 0; i-- {
 		p := s.plugins[i]
 		instance, err := p.Instance()
@@ -508,7 +528,7 @@ func LoadPlugins(ctx context.Context, config *srvconfig.Config) ([]plugin.Regist
 				ic.Meta.Exports = exports
 				ic.Meta.Platforms = append(ic.Meta.Platforms, p)
 				ic.Meta.Capabilities = pp.Capabilities
-				conn, err := clients.getClient(address)
+				conn, err := clients.getClient(address, pp.TLS)
 				if err != nil {
 					return nil, err
 				}
@@ -528,7 +548,14 @@ type proxyClients struct {
 	clients map[string]*grpc.ClientConn
 }
 
-func (pc *proxyClients) getClient(address string) (*grpc.ClientConn, error) {
+// getClient returns a (cached) connection to a proxy plugin at address.
+// With tlsConfig nil, address is treated as a local unix socket, as
+// before. With tlsConfig set, address is instead dialed directly as a
+// gRPC target (e.g. "host:port"), over mutual TLS, so the proxy plugin
+// can run on a different host; the connection also enables the standard
+// gRPC health checking protocol, so the client stops routing calls to an
+// off-node plugin if it reports itself unhealthy.
+func (pc *proxyClients) getClient(address string, tlsConfig *srvconfig.ProxyPluginTLSConfig) (*grpc.ClientConn, error) {
 	pc.m.Lock()
 	defer pc.m.Unlock()
 	if pc.clients == nil {
@@ -543,16 +570,32 @@ func (pc *proxyClients) getClient(address string) (*grpc.ClientConn, error) {
 		Backoff: backoffConfig,
 	}
 	gopts := []grpc.DialOption{
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithConnectParams(connParams),
-		grpc.WithContextDialer(dialer.ContextDialer),
 
 		// TODO(stevvooe): We may need to allow configuration of this on the client.
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(defaults.DefaultMaxRecvMsgSize)),
 		grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(defaults.DefaultMaxSendMsgSize)),
 	}
 
-	conn, err := grpc.NewClient(dialer.DialAddress(address), gopts...)
+	target := dialer.DialAddress(address)
+	if tlsConfig == nil {
+		gopts = append(gopts,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithContextDialer(dialer.ContextDialer),
+		)
+	} else {
+		creds, err := newProxyPluginTLSCredentials(tlsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set up TLS for proxy plugin %q: %w", address, err)
+		}
+		target = address
+		gopts = append(gopts,
+			grpc.WithTransportCredentials(creds),
+			grpc.WithDefaultServiceConfig(`{"healthCheckConfig": {"serviceName": ""}}`),
+		)
+	}
+
+	conn, err := grpc.NewClient(target, gopts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to dial %q: %w", address, err)
 	}
@@ -562,6 +605,33 @@ func (pc *proxyClients) getClient(address string) (*grpc.ClientConn, error) {
 	return conn, nil
 }
 
+// newProxyPluginTLSCredentials builds client-side mTLS transport
+// credentials for connecting to an off-node proxy plugin.
+func newProxyPluginTLSCredentials(cfg *srvconfig.ProxyPluginTLSConfig) (credentials.TransportCredentials, error) {
+	if cfg.Cert == "" || cfg.Key == "" {
+		return nil, errors.New("tls.cert and tls.key must both be set")
+	}
+	cert, err := tls.LoadX509KeyPair(cfg.Cert, cfg.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}}
+
+	if cfg.CACert != "" {
+		caCert, err := os.ReadFile(cfg.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load CA file: %w", err)
+		}
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("failed to parse CA certificate from %q", cfg.CACert)
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
 func trapClosedConnErr(err error) error {
 	if err == nil || errors.Is(err, net.ErrClosed) {
 		return nil
@@ -24,10 +24,12 @@ import (
 	"github.com/urfave/cli/v2"
 	"google.golang.org/grpc/grpclog"
 
+	"github.com/containerd/containerd/v2/cmd/ctr/commands/admin"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/containers"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/content"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/deprecations"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/events"
+	gcCmd "github.com/containerd/containerd/v2/cmd/ctr/commands/gc"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/images"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/info"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/install"
@@ -36,9 +38,11 @@ import (
 	ociCmd "github.com/containerd/containerd/v2/cmd/ctr/commands/oci"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/plugins"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/pprof"
+	registryCmd "github.com/containerd/containerd/v2/cmd/ctr/commands/registry"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/run"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/sandboxes"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/snapshots"
+	"github.com/containerd/containerd/v2/cmd/ctr/commands/storage"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/tasks"
 	versionCmd "github.com/containerd/containerd/v2/cmd/ctr/commands/version"
 	"github.com/containerd/containerd/v2/defaults"
@@ -117,17 +121,21 @@ containerd CLI
 		},
 	}
 	app.Commands = append([]*cli.Command{
+		admin.Command,
 		plugins.Command,
 		versionCmd.Command,
 		containers.Command,
 		content.Command,
 		events.Command,
+		gcCmd.Command,
 		images.Command,
 		leases.Command,
 		namespacesCmd.Command,
 		pprof.Command,
+		registryCmd.Command,
 		run.Command,
 		snapshots.Command,
+		storage.Command,
 		tasks.Command,
 		install.Command,
 		ociCmd.Command,
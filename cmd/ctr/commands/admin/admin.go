@@ -0,0 +1,53 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package admin provides host administration commands for a running
+// containerd daemon.
+package admin
+
+import (
+	"github.com/containerd/errdefs"
+	"github.com/urfave/cli/v2"
+)
+
+// Command is the cli command for host administration of a containerd daemon.
+var Command = &cli.Command{
+	Name:  "admin",
+	Usage: "Host administration commands",
+	Subcommands: cli.Commands{
+		backupCommand,
+	},
+}
+
+var backupCommand = &cli.Command{
+	Name:      "backup",
+	Usage:     "Take a hot backup of containerd's metadata store",
+	ArgsUsage: "<output file>",
+	Description: `Streams a consistent snapshot of the metadata store (meta.db) to <output file>, suitable for restoring before an upgrade.
+
+This is not implemented yet: ctr only ever talks to containerd over its
+gRPC socket, and that API has no RPC to stream a live daemon's meta.db
+back to a client - core/metadata.DB.Backup does the actual hot backup
+(bbolt's own tx.WriteTo), but nothing calls it from outside the daemon
+process today. Adding the RPC this command would need means growing the
+containerd API, which this environment can't regenerate protobuf code
+for. Until that RPC exists, taking a backup means running
+core/metadata.DB.Backup from inside the daemon (a debug/admin plugin, or
+a one-off build), or stopping containerd and copying meta.db off disk.`,
+	Action: func(cliContext *cli.Context) error {
+		return errdefs.ErrNotImplemented
+	},
+}
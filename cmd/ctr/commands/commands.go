@@ -118,6 +118,10 @@ var (
 			Aliases: []string{"c"},
 			Usage:   "Path to the runtime-specific spec config file",
 		},
+		&cli.StringFlag{
+			Name:  "template",
+			Usage: "Path to a container template file (see containerd.ContainerTemplate) applying reusable settings such as mounts, devices and capabilities",
+		},
 		&cli.StringFlag{
 			Name:  "cwd",
 			Usage: "Specify the working directory of the process",
@@ -22,6 +22,7 @@ import (
 
 	containerd "github.com/containerd/containerd/v2/client"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands"
+	"github.com/containerd/containerd/v2/cmd/ctr/commands/images"
 	"github.com/containerd/errdefs"
 	"github.com/urfave/cli/v2"
 )
@@ -30,7 +31,7 @@ var checkpointCommand = &cli.Command{
 	Name:      "checkpoint",
 	Usage:     "Checkpoint a container",
 	ArgsUsage: "CONTAINER REF",
-	Flags: []cli.Flag{
+	Flags: append(commands.RegistryFlags,
 		&cli.BoolFlag{
 			Name:  "rw",
 			Usage: "Include the rw layer in the checkpoint",
@@ -43,7 +44,15 @@ var checkpointCommand = &cli.Command{
 			Name:  "task",
 			Usage: "Checkpoint container task",
 		},
-	},
+		&cli.BoolFlag{
+			Name:  "push",
+			Usage: "Push the checkpoint to REF via the transfer service once created, for migrating it to another node",
+		},
+		&cli.StringSliceFlag{
+			Name:  "platform",
+			Usage: "Push checkpoint content from a specific platform",
+		},
+	),
 	Action: func(cliContext *cli.Context) error {
 		id := cliContext.Args().First()
 		if id == "" {
@@ -97,6 +106,12 @@ var checkpointCommand = &cli.Command{
 			return err
 		}
 
+		if cliContext.Bool("push") {
+			if err := images.PushViaTransfer(ctx, client, cliContext, ref, ref); err != nil {
+				return fmt.Errorf("failed to push checkpoint %s: %w", ref, err)
+			}
+		}
+
 		return nil
 	},
 }
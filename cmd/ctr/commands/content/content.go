@@ -56,6 +56,8 @@ var (
 			pushObjectCommand,
 			setLabelsCommand,
 			pruneCommand,
+			verifyCommand,
+			fsckCommand,
 		},
 	}
 
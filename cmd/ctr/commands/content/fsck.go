@@ -0,0 +1,81 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package content
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/containerd/v2/cmd/ctr/commands"
+	"github.com/containerd/containerd/v2/core/images"
+	"github.com/containerd/errdefs"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/urfave/cli/v2"
+)
+
+var fsckCommand = &cli.Command{
+	Name:  "fsck",
+	Usage: "Cross-check image metadata against the content store",
+	Description: `Walks every image in the metadata store and, for each blob
+it references (manifest, config, and layers), checks that the blob is
+actually present in the content store. It reports any reference that
+points at a blob which is missing, which can happen after disk
+corruption or an interrupted removal.
+
+Unlike "content prune", fsck does not delete anything - it only reports
+what is broken so the affected images can be re-pulled or removed.
+`,
+	Action: func(cliContext *cli.Context) error {
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		cs := client.ContentStore()
+		is := client.ImageService()
+
+		imgs, err := is.List(ctx)
+		if err != nil {
+			return err
+		}
+
+		var missing int
+		for _, img := range imgs {
+			handler := images.HandlerFunc(func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+				if _, err := cs.Info(ctx, desc.Digest); err != nil {
+					if errdefs.IsNotFound(err) {
+						missing++
+						fmt.Printf("missing: image %q references %s (%s), which is not in the content store\n", img.Name, desc.Digest, desc.MediaType)
+						return nil, nil
+					}
+					return nil, err
+				}
+				return images.Children(ctx, cs, desc)
+			})
+			if err := images.Walk(ctx, handler, img.Target); err != nil {
+				return fmt.Errorf("failed to walk image %q: %w", img.Name, err)
+			}
+		}
+
+		fmt.Printf("checked %d image(s), %d missing reference(s)\n", len(imgs), missing)
+		if missing > 0 {
+			return fmt.Errorf("found %d broken reference(s)", missing)
+		}
+		return nil
+	},
+}
@@ -0,0 +1,155 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package content
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/cmd/ctr/commands"
+	"github.com/containerd/containerd/v2/core/content"
+	"github.com/containerd/containerd/v2/core/images"
+	"github.com/containerd/log"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/urfave/cli/v2"
+)
+
+var verifyCommand = &cli.Command{
+	Name:      "verify",
+	Usage:     "Verify content in the content store against its recorded digest",
+	ArgsUsage: "[flags]",
+	Description: `Re-reads every blob in the content store and re-hashes it,
+comparing the result against the digest under which it is stored. This
+catches blobs that were corrupted on disk (e.g. by a crash or a bad
+sector) without requiring the whole content store to be wiped and
+refetched.
+
+By default all blobs in the content store are checked. Pass --image to
+only check the blobs reachable from a single image, which is faster
+when only one image is suspected of being affected.
+`,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "image",
+			Usage: "Only verify blobs reachable from this image",
+		},
+		&cli.BoolFlag{
+			Name:  "remove",
+			Usage: "Delete blobs that fail verification",
+		},
+	},
+	Action: func(cliContext *cli.Context) error {
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		cs := client.ContentStore()
+
+		var digests []digest.Digest
+		if imageRef := cliContext.String("image"); imageRef != "" {
+			digests, err = reachableDigests(ctx, client, imageRef)
+		} else {
+			digests, err = allDigests(ctx, cs)
+		}
+		if err != nil {
+			return err
+		}
+
+		var corrupt int
+		for _, dgst := range digests {
+			ok, err := verifyBlob(ctx, cs, dgst)
+			if err != nil {
+				log.G(ctx).WithError(err).WithField("digest", dgst).Warn("failed to verify blob")
+				continue
+			}
+			if ok {
+				continue
+			}
+
+			corrupt++
+			fmt.Printf("corrupt: %s\n", dgst)
+			if cliContext.Bool("remove") {
+				if err := cs.Delete(ctx, dgst); err != nil {
+					log.G(ctx).WithError(err).WithField("digest", dgst).Warn("failed to remove corrupt blob")
+				} else {
+					fmt.Printf("removed: %s\n", dgst)
+				}
+			}
+		}
+
+		fmt.Printf("checked %d blobs, %d corrupt\n", len(digests), corrupt)
+		if corrupt > 0 && !cliContext.Bool("remove") {
+			return fmt.Errorf("found %d corrupt blob(s)", corrupt)
+		}
+		return nil
+	},
+}
+
+// allDigests returns the digest of every blob currently in the content store.
+func allDigests(ctx context.Context, cs content.Store) ([]digest.Digest, error) {
+	var digests []digest.Digest
+	if err := cs.Walk(ctx, func(info content.Info) error {
+		digests = append(digests, info.Digest)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return digests, nil
+}
+
+// reachableDigests resolves ref to an image and returns the digests of every
+// blob reachable from its manifest (the manifest itself, its config, and its
+// layers), following indexes down to the platform-specific manifests.
+func reachableDigests(ctx context.Context, client *containerd.Client, ref string) ([]digest.Digest, error) {
+	img, err := client.ImageService().Get(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve image %s: %w", ref, err)
+	}
+
+	cs := client.ContentStore()
+
+	var digests []digest.Digest
+	handler := images.HandlerFunc(func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		digests = append(digests, desc.Digest)
+		return images.Children(ctx, cs, desc)
+	})
+	if err := images.Walk(ctx, handler, img.Target); err != nil {
+		return nil, err
+	}
+	return digests, nil
+}
+
+// verifyBlob re-hashes the blob stored under dgst and reports whether it
+// still matches.
+func verifyBlob(ctx context.Context, cs content.Store, dgst digest.Digest) (bool, error) {
+	ra, err := cs.ReaderAt(ctx, ocispec.Descriptor{Digest: dgst})
+	if err != nil {
+		return false, err
+	}
+	defer ra.Close()
+
+	verifier := dgst.Verifier()
+	if _, err := io.Copy(verifier, io.NewSectionReader(ra, 0, ra.Size())); err != nil {
+		return false, err
+	}
+	return verifier.Verified(), nil
+}
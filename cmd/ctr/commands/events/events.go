@@ -19,6 +19,7 @@ package events
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/containerd/containerd/v2/cmd/ctr/commands"
 	"github.com/containerd/containerd/v2/core/events"
@@ -35,14 +36,50 @@ var Command = &cli.Command{
 	Name:    "events",
 	Aliases: []string{"event"},
 	Usage:   "Display containerd events",
+	Description: `Display containerd events as they are published.
+
+Filters may be given as trailing arguments using containerd's filter syntax
+(for example "topic==/tasks/delete"), or with the --topic/--namespace flags
+below as shorthand for the same thing; the two forms can be combined.
+
+containerd does not persist a log of past events anywhere a client can read
+it back from, so there is no "--since" here: Subscribe only ever delivers
+events published from the moment a client subscribes onward. Events that
+happened before this command was started are gone.
+`,
+	Flags: []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:  "topic",
+			Usage: "Only show events for the given topic (may be repeated); shorthand for a topic== filter",
+		},
+		&cli.StringSliceFlag{
+			Name:  "namespace",
+			Usage: "Only show events for the given namespace (may be repeated); shorthand for a namespace== filter",
+		},
+		&cli.BoolFlag{
+			Name:  "json",
+			Usage: "Print each event as a single-line JSON object instead of space-separated fields",
+		},
+	},
 	Action: func(cliContext *cli.Context) error {
 		client, ctx, cancel, err := commands.NewClient(cliContext)
 		if err != nil {
 			return err
 		}
 		defer cancel()
+
+		filters := cliContext.Args().Slice()
+		for _, topic := range cliContext.StringSlice("topic") {
+			filters = append(filters, fmt.Sprintf("topic==%s", topic))
+		}
+		for _, ns := range cliContext.StringSlice("namespace") {
+			filters = append(filters, fmt.Sprintf("namespace==%s", ns))
+		}
+
+		asJSON := cliContext.Bool("json")
+
 		eventsClient := client.EventService()
-		eventsCh, errCh := eventsClient.Subscribe(ctx, cliContext.Args().Slice()...)
+		eventsCh, errCh := eventsClient.Subscribe(ctx, filters...)
 		for {
 			var e *events.Envelope
 			select {
@@ -64,6 +101,27 @@ var Command = &cli.Command{
 						continue
 					}
 				}
+				if asJSON {
+					line, err := json.Marshal(struct {
+						Timestamp string          `json:"timestamp"`
+						Namespace string          `json:"namespace"`
+						Topic     string          `json:"topic"`
+						Event     json.RawMessage `json:"event,omitempty"`
+					}{
+						Timestamp: e.Timestamp.Format(time.RFC3339Nano),
+						Namespace: e.Namespace,
+						Topic:     e.Topic,
+						Event:     out,
+					})
+					if err != nil {
+						log.G(ctx).WithError(err).Warn("cannot marshal event envelope into JSON")
+						continue
+					}
+					if _, err := fmt.Println(string(line)); err != nil {
+						return err
+					}
+					continue
+				}
 				if _, err := fmt.Println(
 					e.Timestamp,
 					e.Namespace,
@@ -0,0 +1,115 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package images
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/containerd/containerd/v2/cmd/ctr/commands"
+	"github.com/containerd/containerd/v2/core/transfer"
+	"github.com/containerd/containerd/v2/core/transfer/image"
+	"github.com/containerd/containerd/v2/core/transfer/registry"
+	"github.com/containerd/platforms"
+	"github.com/urfave/cli/v2"
+)
+
+var copyCommand = &cli.Command{
+	Name:      "copy",
+	Usage:     "Copy an image from one registry to another",
+	ArgsUsage: "[flags] <src-ref> <dst-ref>",
+	Description: `Copy fetches an image from one registry and pushes it to another in a
+single command, via the same transfer service used by "pull" and "push".
+It saves having to run "images pull" followed by "images push" against a
+locally tagged name, but content is still staged through containerd's
+content store in between, the same as those two commands already do -
+this does not stream bytes directly between registries.
+`,
+	Flags: append(append(append(commands.RegistryFlags, commands.LabelFlag), progressFormatFlags...),
+		&cli.StringSliceFlag{
+			Name:  "platform",
+			Usage: "Copy content from a specific platform",
+			Value: cli.NewStringSlice(),
+		},
+		&cli.BoolFlag{
+			Name:  "all-platforms",
+			Usage: "Copy content and metadata from all platforms",
+		},
+	),
+	Action: func(cliContext *cli.Context) error {
+		srcRef := cliContext.Args().Get(0)
+		dstRef := cliContext.Args().Get(1)
+		if srcRef == "" || dstRef == "" {
+			return errors.New("please provide a source and a destination image reference")
+		}
+
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		ch, err := commands.NewStaticCredentials(ctx, cliContext, srcRef)
+		if err != nil {
+			return err
+		}
+
+		p, err := platforms.ParseAll(cliContext.StringSlice("platform"))
+		if err != nil {
+			return err
+		}
+		allPlatforms := cliContext.Bool("all-platforms")
+		if len(p) > 0 && allPlatforms {
+			return errors.New("cannot specify both --platform and --all-platforms")
+		}
+		if len(p) == 0 && !allPlatforms {
+			p = append(p, platforms.DefaultSpec())
+		}
+
+		sopts := []image.StoreOpt{image.WithPlatforms(p...), image.WithAllMetadata}
+		if labels := cliContext.StringSlice("label"); len(labels) > 0 {
+			sopts = append(sopts, image.WithImageLabels(commands.LabelArgs(labels)))
+		}
+
+		opts := []registry.Opt{registry.WithCredentials(ch), registry.WithHostDir(cliContext.String("hosts-dir"))}
+		if cliContext.Bool("plain-http") {
+			opts = append(opts, registry.WithDefaultScheme("http"))
+		}
+		srcReg, err := registry.NewOCIRegistry(ctx, srcRef, opts...)
+		if err != nil {
+			return err
+		}
+
+		// Stage the source image under the destination reference so the push
+		// below has a local image to resolve.
+		is := image.NewStore(dstRef, sopts...)
+		if err := func() error {
+			pf, done := ProgressHandler(ctx, cliContext, os.Stdout)
+			defer done()
+			return client.Transfer(ctx, srcReg, is, transfer.WithProgress(pf))
+		}(); err != nil {
+			return fmt.Errorf("failed to fetch %s: %w", srcRef, err)
+		}
+
+		if err := PushViaTransfer(ctx, client, cliContext, dstRef, dstRef); err != nil {
+			return fmt.Errorf("failed to push %s: %w", dstRef, err)
+		}
+
+		return nil
+	},
+}
@@ -94,7 +94,7 @@ When '--all-platforms' is given all images in a manifest list must be available.
 		defer w.Close()
 
 		if !cliContext.Bool("local") {
-			pf, done := ProgressHandler(ctx, os.Stdout)
+			pf, done := ProgressHandler(ctx, cliContext, os.Stdout)
 			defer done()
 
 			exportOpts := []tarchive.ExportOpt{}
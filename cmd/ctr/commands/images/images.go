@@ -17,6 +17,7 @@
 package images
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -24,12 +25,15 @@ import (
 	"strings"
 	"text/tabwriter"
 
+	containerd "github.com/containerd/containerd/v2/client"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands"
 	"github.com/containerd/containerd/v2/core/images"
+	"github.com/containerd/containerd/v2/defaults"
 	"github.com/containerd/containerd/v2/pkg/progress"
 	"github.com/containerd/errdefs"
 	"github.com/containerd/log"
 	"github.com/containerd/platforms"
+	"github.com/opencontainers/image-spec/identity"
 	"github.com/urfave/cli/v2"
 )
 
@@ -40,6 +44,7 @@ var Command = &cli.Command{
 	Usage:   "Manage images",
 	Subcommands: cli.Commands{
 		checkCommand,
+		copyCommand,
 		exportCommand,
 		importCommand,
 		inspectCommand,
@@ -322,12 +327,16 @@ var removeCommand = &cli.Command{
 	Usage:       "Remove one or more images by reference",
 	ArgsUsage:   "[flags] <ref> [<ref>, ...]",
 	Description: "remove one or more images by reference",
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		&cli.BoolFlag{
 			Name:  "sync",
 			Usage: "Synchronously remove image and all associated resources",
 		},
-	},
+		&cli.BoolFlag{
+			Name:  "with-snapshots",
+			Usage: "Also remove the image's unpacked snapshot chain from --snapshotter, if no other image still references it",
+		},
+	}, commands.SnapshotterFlags...),
 	Action: func(cliContext *cli.Context) error {
 		client, ctx, cancel, err := commands.NewClient(cliContext)
 		if err != nil {
@@ -338,7 +347,26 @@ var removeCommand = &cli.Command{
 			exitErr    error
 			imageStore = client.ImageService()
 		)
-		for i, target := range cliContext.Args().Slice() {
+
+		withSnapshots := cliContext.Bool("with-snapshots")
+		snapshotter := cliContext.String("snapshotter")
+		if snapshotter == "" {
+			snapshotter = defaults.DefaultSnapshotter
+		}
+
+		targets := cliContext.Args().Slice()
+		for _, target := range targets {
+			reportDependents(ctx, client, target)
+		}
+
+		var reclaim func(target string) error
+		if withSnapshots {
+			reclaim = func(target string) error {
+				return removeSnapshotChain(ctx, client, snapshotter, target, targets)
+			}
+		}
+
+		for i, target := range targets {
 			var opts []images.DeleteOpt
 			if cliContext.Bool("sync") && i == cliContext.NArg()-1 {
 				opts = append(opts, images.SynchronousDelete())
@@ -353,8 +381,13 @@ var removeCommand = &cli.Command{
 				}
 				// image ref not found in metadata store; log not found condition
 				log.G(ctx).Warnf("%v: image not found", target)
-			} else {
-				fmt.Println(target)
+				continue
+			}
+			fmt.Println(target)
+			if reclaim != nil {
+				if err := reclaim(target); err != nil {
+					log.G(ctx).WithError(err).Warnf("failed to remove snapshot chain for %v", target)
+				}
 			}
 		}
 
@@ -362,6 +395,130 @@ var removeCommand = &cli.Command{
 	},
 }
 
+// reportDependents prints a best-effort report of containers still
+// referencing target by image name, so an operator removing an image knows
+// what else may break. It does not block or alter the deletion itself:
+// containerd's own image delete already tolerates dangling container/image
+// references, resolving them (or not) at the next garbage collection.
+func reportDependents(ctx context.Context, client *containerd.Client, target string) {
+	containerList, err := client.ContainerService().List(ctx)
+	if err != nil {
+		log.G(ctx).WithError(err).Warn("failed to list containers for dependency report")
+		return
+	}
+	var dependents []string
+	for _, c := range containerList {
+		if c.Image == target {
+			dependents = append(dependents, c.ID)
+		}
+	}
+	if len(dependents) > 0 {
+		sort.Strings(dependents)
+		fmt.Printf("%s: still referenced by container(s): %s\n", target, strings.Join(dependents, ", "))
+	}
+}
+
+// removeSnapshotChain removes the snapshot chain that target was unpacked
+// into under snapshotter, walking from the leaf snapshot up to the root and
+// stopping at (not removing) any snapshot whose chain ID is still used by
+// one of the images in keep other than target itself. This mirrors the walk
+// "ctr images usage" does to display a chain, but removes instead of
+// reporting, and is deliberately conservative: a snapshot is only removed if
+// nothing else known to the image store still depends on it.
+func removeSnapshotChain(ctx context.Context, client *containerd.Client, snapshotter, target string, keep []string) error {
+	img, err := client.ImageService().Get(ctx, target)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	i := containerd.NewImage(client, img)
+	if ok, err := i.IsUnpacked(ctx, snapshotter); err != nil {
+		return err
+	} else if !ok {
+		return nil
+	}
+
+	diffIDs, err := i.RootFS(ctx)
+	if err != nil {
+		return err
+	}
+
+	shared, err := sharedChainIDs(ctx, client, snapshotter, target, keep)
+	if err != nil {
+		return err
+	}
+
+	snSrv := client.SnapshotService(snapshotter)
+	snID := identity.ChainID(diffIDs).String()
+	for snID != "" {
+		if _, ok := shared[snID]; ok {
+			fmt.Printf("%s: snapshot %s still used by another image, not removed\n", target, snID)
+			break
+		}
+
+		info, err := snSrv.Stat(ctx, snID)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				break
+			}
+			return err
+		}
+
+		if err := snSrv.Remove(ctx, snID); err != nil {
+			if !errdefs.IsNotFound(err) {
+				return err
+			}
+		} else {
+			fmt.Printf("%s: removed snapshot %s\n", target, snID)
+		}
+
+		snID = info.Parent
+	}
+	return nil
+}
+
+// sharedChainIDs returns the set of snapshot chain IDs (including every
+// ancestor of each image's full chain, not just the leaf) used by any image
+// other than target, so that removeSnapshotChain never deletes a layer
+// another image is still relying on.
+func sharedChainIDs(ctx context.Context, client *containerd.Client, snapshotter, target string, exclude []string) (map[string]struct{}, error) {
+	skip := make(map[string]struct{}, len(exclude))
+	for _, ref := range exclude {
+		skip[ref] = struct{}{}
+	}
+
+	imgs, err := client.ImageService().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shared := make(map[string]struct{})
+	for _, img := range imgs {
+		if img.Name == target {
+			continue
+		}
+		if _, ok := skip[img.Name]; ok {
+			continue
+		}
+
+		i := containerd.NewImage(client, img)
+		if ok, err := i.IsUnpacked(ctx, snapshotter); err != nil || !ok {
+			continue
+		}
+		diffIDs, err := i.RootFS(ctx)
+		if err != nil {
+			continue
+		}
+		for k := 1; k <= len(diffIDs); k++ {
+			shared[identity.ChainID(diffIDs[:k]).String()] = struct{}{}
+		}
+	}
+	return shared, nil
+}
+
 var pruneCommand = &cli.Command{
 	Name:  "prune",
 	Usage: "Remove unused images",
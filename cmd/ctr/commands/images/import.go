@@ -187,7 +187,7 @@ If foobar.tar contains an OCI ref named "latest" and anonymous ref "sha256:deadb
 			}
 			iis := tarchive.NewImageImportStream(r, "", iopts...)
 
-			pf, done := ProgressHandler(ctx, os.Stdout)
+			pf, done := ProgressHandler(ctx, cliContext, os.Stdout)
 			defer done()
 
 			err := client.Transfer(ctx, iis, is, transfer.WithProgress(pf))
@@ -18,11 +18,13 @@ package images
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	containerd "github.com/containerd/containerd/v2/client"
@@ -41,6 +43,20 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
+// progressFormatFlags control how transfer-service progress (pull, push,
+// copy) is rendered. They have no effect on the legacy --local paths, which
+// predate the transfer service's progress stream and render their own way.
+var progressFormatFlags = []cli.Flag{
+	&cli.BoolFlag{
+		Name:  "quiet",
+		Usage: "Suppress progress output",
+	},
+	&cli.StringFlag{
+		Name:  "format",
+		Usage: "Progress output format (json for machine-readable, default is a multi-bar per-layer display)",
+	},
+}
+
 var pullCommand = &cli.Command{
 	Name:      "pull",
 	Usage:     "Pull an image from a remote",
@@ -54,7 +70,7 @@ command. As part of this process, we do the following:
 2. Prepare the snapshot filesystem with the pulled resources.
 3. Register metadata for the image.
 `,
-	Flags: append(append(commands.RegistryFlags, append(commands.SnapshotterFlags, commands.LabelFlag)...),
+	Flags: append(append(append(commands.RegistryFlags, append(commands.SnapshotterFlags, commands.LabelFlag)...), progressFormatFlags...),
 		&cli.StringSliceFlag{
 			Name:  "platform",
 			Usage: "Pull content from a specific platform",
@@ -73,6 +89,14 @@ command. As part of this process, we do the following:
 			Name:  "skip-metadata",
 			Usage: "Skips metadata for unused platforms (Image may be unable to be pushed without metadata)",
 		},
+		&cli.BoolFlag{
+			Name:  "skip-foreign-layers",
+			Usage: "Skip fetching non-distributable (foreign) layers, such as Windows base layers",
+		},
+		&cli.BoolFlag{
+			Name:  "skip-attestations",
+			Usage: "Skip fetching BuildKit attestation manifests (SBOM/provenance) referenced from an index, even with --all-metadata",
+		},
 		&cli.BoolFlag{
 			Name:  "print-chainid",
 			Usage: "Print the resulting image's chain ID",
@@ -147,6 +171,12 @@ command. As part of this process, we do the following:
 			} else if !cliContext.Bool("skip-metadata") {
 				sopts = append(sopts, image.WithAllMetadata)
 			}
+			if cliContext.Bool("skip-foreign-layers") {
+				sopts = append(sopts, image.WithSkipNonDistributableBlobs)
+			}
+			if cliContext.Bool("skip-attestations") {
+				sopts = append(sopts, image.WithSkipAttestationManifests)
+			}
 			labels := cliContext.StringSlice("label")
 			if len(labels) > 0 {
 				sopts = append(sopts, image.WithImageLabels(commands.LabelArgs(labels)))
@@ -172,7 +202,7 @@ command. As part of this process, we do the following:
 			}
 			is := image.NewStore(ref, sopts...)
 
-			pf, done := ProgressHandler(ctx, os.Stdout)
+			pf, done := ProgressHandler(ctx, cliContext, os.Stdout)
 			defer done()
 
 			return client.Transfer(ctx, reg, is, transfer.WithProgress(pf))
@@ -255,9 +285,41 @@ func (n *progressNode) mainDesc() *ocispec.Descriptor {
 	return nil
 }
 
-// ProgressHandler continuously updates the output with job progress
+// ProgressHandler returns a transfer.ProgressFunc for pull/push/copy, along
+// with a func to call once the transfer is done. Its rendering is governed
+// by the --quiet and --format flags (see progressFormatFlags): by default it
+// prints a continuously updated multi-bar per-layer display; --quiet
+// suppresses output entirely; --format json emits one JSON object per
+// progress event instead, for scripts to consume.
+func ProgressHandler(ctx context.Context, cliContext *cli.Context, out io.Writer) (transfer.ProgressFunc, func()) {
+	if cliContext.Bool("quiet") {
+		return func(transfer.Progress) {}, func() {}
+	}
+	if cliContext.String("format") == "json" {
+		return jsonProgressHandler(out)
+	}
+	return hierarchyProgressHandler(ctx, out)
+}
+
+// jsonProgressHandler writes each progress event as a single JSON line to
+// out, making it straightforward for scripts to follow along without
+// parsing the human-oriented multi-bar display.
+func jsonProgressHandler(out io.Writer) (transfer.ProgressFunc, func()) {
+	var mu sync.Mutex
+	enc := json.NewEncoder(out)
+	pf := func(p transfer.Progress) {
+		mu.Lock()
+		defer mu.Unlock()
+		// Best effort: there's no good way to surface an encoding error from
+		// inside a progress callback, and out is normally stdout.
+		_ = enc.Encode(p)
+	}
+	return pf, func() {}
+}
+
+// hierarchyProgressHandler continuously updates the output with job progress
 // by checking status in the content store.
-func ProgressHandler(ctx context.Context, out io.Writer) (transfer.ProgressFunc, func()) {
+func hierarchyProgressHandler(ctx context.Context, out io.Writer) (transfer.ProgressFunc, func()) {
 	ctx, cancel := context.WithCancel(ctx)
 	var (
 		fw       = progress.NewWriter(out)
@@ -44,6 +44,43 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+// PushViaTransfer pushes the image named local (or ref, if local is empty)
+// to ref using the transfer service, the same path the "push" command takes
+// by default. It is exported so other ctr commands that produce an image in
+// the local image store (e.g. "containers checkpoint --push") can push it to
+// a registry without re-implementing the transfer-service plumbing.
+func PushViaTransfer(ctx context.Context, client *containerd.Client, cliContext *cli.Context, ref, local string) error {
+	ch, err := commands.NewStaticCredentials(ctx, cliContext, ref)
+	if err != nil {
+		return err
+	}
+
+	if local == "" {
+		local = ref
+	}
+	opts := []registry.Opt{registry.WithCredentials(ch), registry.WithHostDir(cliContext.String("hosts-dir"))}
+	if cliContext.Bool("plain-http") {
+		opts = append(opts, registry.WithDefaultScheme("http"))
+	}
+	reg, err := registry.NewOCIRegistry(ctx, ref, opts...)
+	if err != nil {
+		return err
+	}
+	var p []ocispec.Platform
+	if pss := cliContext.StringSlice("platform"); len(pss) > 0 {
+		p, err = platforms.ParseAll(pss)
+		if err != nil {
+			return fmt.Errorf("invalid platform %v: %w", pss, err)
+		}
+	}
+	is := image.NewStore(local, image.WithPlatforms(p...))
+
+	pf, done := ProgressHandler(ctx, cliContext, os.Stdout)
+	defer done()
+
+	return client.Transfer(ctx, is, reg, transfer.WithProgress(pf))
+}
+
 var pushCommand = &cli.Command{
 	Name:      "push",
 	Usage:     "Push an image to a remote",
@@ -57,7 +94,7 @@ var pushCommand = &cli.Command{
 	creating the associated configuration, and creating the manifest
 	which references those resources.
 `,
-	Flags: append(commands.RegistryFlags, &cli.StringFlag{
+	Flags: append(append(commands.RegistryFlags, progressFormatFlags...), &cli.StringFlag{
 		Name:  "manifest",
 		Usage: "Digest of manifest",
 	}, &cli.StringFlag{
@@ -106,35 +143,13 @@ var pushCommand = &cli.Command{
 				}
 			}
 
-			ch, err := commands.NewStaticCredentials(ctx, cliContext, ref)
-			if err != nil {
-				return err
-			}
+			return PushViaTransfer(ctx, client, cliContext, ref, local)
+		}
 
-			if local == "" {
-				local = ref
-			}
-			opts := []registry.Opt{registry.WithCredentials(ch), registry.WithHostDir(cliContext.String("hosts-dir"))}
-			if cliContext.Bool("plain-http") {
-				opts = append(opts, registry.WithDefaultScheme("http"))
-			}
-			reg, err := registry.NewOCIRegistry(ctx, ref, opts...)
-			if err != nil {
-				return err
-			}
-			var p []ocispec.Platform
-			if pss := cliContext.StringSlice("platform"); len(pss) > 0 {
-				p, err = platforms.ParseAll(pss)
-				if err != nil {
-					return fmt.Errorf("invalid platform %v: %w", pss, err)
-				}
+		for _, s := range []string{"quiet", "format"} {
+			if cliContext.IsSet(s) {
+				return fmt.Errorf("\"--%s\" is not supported with \"--local\"", s)
 			}
-			is := image.NewStore(local, image.WithPlatforms(p...))
-
-			pf, done := ProgressHandler(ctx, os.Stdout)
-			defer done()
-
-			return client.Transfer(ctx, is, reg, transfer.WithProgress(pf))
 		}
 
 		if manifest := cliContext.String("manifest"); manifest != "" {
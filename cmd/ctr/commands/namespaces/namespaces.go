@@ -17,14 +17,22 @@
 package namespaces
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 
+	containerd "github.com/containerd/containerd/v2/client"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands"
+	gcCmd "github.com/containerd/containerd/v2/cmd/ctr/commands/gc"
+	"github.com/containerd/containerd/v2/cmd/ctr/commands/storage"
+	clabels "github.com/containerd/containerd/v2/pkg/labels"
+	nsutil "github.com/containerd/containerd/v2/pkg/namespaces"
+	"github.com/containerd/containerd/v2/pkg/progress"
 	"github.com/containerd/errdefs"
 	"github.com/containerd/log"
 	"github.com/urfave/cli/v2"
@@ -40,6 +48,7 @@ var Command = &cli.Command{
 		listCommand,
 		removeCommand,
 		setLabelsCommand,
+		usageCommand,
 	},
 }
 
@@ -182,3 +191,132 @@ var removeCommand = &cli.Command{
 		return exitErr
 	},
 }
+
+var usageCommand = &cli.Command{
+	Name:        "usage",
+	Usage:       "Report content bytes, snapshotter bytes, and container count per namespace",
+	ArgsUsage:   "[flags]",
+	Description: `Reports, per namespace, total content store size, size used by each registered snapshotter, and container count, alongside any containerd.io/namespace.max-containers limit set on it, so a multi-tenant daemon operator can see which namespaces are hoarding resources or approaching their configured limits.`,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "format",
+			Usage: "output format to use (Examples: 'default', 'json')",
+		},
+	},
+	Action: func(cliContext *cli.Context) error {
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		snapshotterNames, err := storage.SnapshotterNames(ctx, client)
+		if err != nil {
+			return err
+		}
+
+		nsService := client.NamespaceService()
+		nsList, err := nsService.List(ctx)
+		if err != nil {
+			return err
+		}
+		sort.Strings(nsList)
+
+		var report []NamespaceUsage
+		for _, ns := range nsList {
+			nsUsage, err := namespaceUsage(nsutil.WithNamespace(ctx, ns), client, ns, snapshotterNames)
+			if err != nil {
+				return fmt.Errorf("failed to report usage for namespace %q: %w", ns, err)
+			}
+			report = append(report, nsUsage)
+		}
+
+		if cliContext.String("format") == "json" {
+			commands.PrintAsJSON(report)
+			return nil
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 1, 8, 1, ' ', 0)
+		fmt.Fprintln(tw, "NAMESPACE\tCONTENT\tSNAPSHOTTERS\tCONTAINERS\tMAX CONTAINERS\t")
+		for _, nsUsage := range report {
+			maxContainers := "-"
+			if nsUsage.MaxContainers > 0 {
+				maxContainers = strconv.Itoa(nsUsage.MaxContainers)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t\n",
+				nsUsage.Namespace,
+				progress.Bytes(nsUsage.ContentBytes),
+				formatSnapshotterBytes(nsUsage.SnapshotterBytes),
+				nsUsage.Containers,
+				maxContainers)
+		}
+		return tw.Flush()
+	},
+}
+
+// NamespaceUsage is the per-namespace resource accounting reported by
+// "ctr namespaces usage".
+type NamespaceUsage struct {
+	Namespace        string           `json:"namespace"`
+	ContentBytes     int64            `json:"contentBytes"`
+	SnapshotterBytes map[string]int64 `json:"snapshotterBytes"`
+	Containers       int              `json:"containers"`
+	// MaxContainers is the namespace's configured clabels.LabelMaxContainers
+	// limit, or 0 if it has none.
+	MaxContainers int `json:"maxContainers,omitempty"`
+}
+
+func namespaceUsage(ctx context.Context, client *containerd.Client, ns string, snapshotterNames []string) (NamespaceUsage, error) {
+	contentBytes, err := gcCmd.ContentStoreSize(ctx, client.ContentStore())
+	if err != nil {
+		return NamespaceUsage{}, fmt.Errorf("content store: %w", err)
+	}
+
+	snapshotterBytes := make(map[string]int64, len(snapshotterNames))
+	for _, name := range snapshotterNames {
+		size, err := storage.SnapshotterUsage(ctx, client, name)
+		if err != nil {
+			log.G(ctx).WithError(err).Warnf("failed to get usage for snapshotter %q in namespace %q", name, ns)
+			continue
+		}
+		snapshotterBytes[name] = size
+	}
+
+	containerList, err := client.ContainerService().List(ctx)
+	if err != nil {
+		return NamespaceUsage{}, fmt.Errorf("containers: %w", err)
+	}
+
+	var maxContainers int
+	labels, err := client.NamespaceService().Labels(ctx, ns)
+	if err != nil {
+		return NamespaceUsage{}, fmt.Errorf("labels: %w", err)
+	}
+	if v, ok := labels[clabels.LabelMaxContainers]; ok {
+		maxContainers, _ = strconv.Atoi(v)
+	}
+
+	return NamespaceUsage{
+		Namespace:        ns,
+		ContentBytes:     contentBytes,
+		SnapshotterBytes: snapshotterBytes,
+		Containers:       len(containerList),
+		MaxContainers:    maxContainers,
+	}, nil
+}
+
+func formatSnapshotterBytes(totals map[string]int64) string {
+	names := make([]string, 0, len(totals))
+	for name := range totals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	s := ""
+	for i, name := range names {
+		if i > 0 {
+			s += ", "
+		}
+		s += fmt.Sprintf("%s=%s", name, progress.Bytes(totals[name]))
+	}
+	return s
+}
@@ -0,0 +1,108 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+
+	"github.com/containerd/console"
+	"github.com/urfave/cli/v2"
+)
+
+var loginCommand = &cli.Command{
+	Name:      "login",
+	Usage:     "Log in to a registry, storing credentials for use by image pull and push",
+	ArgsUsage: "<host>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "username",
+			Aliases: []string{"u"},
+			Usage:   "Registry username",
+		},
+		&cli.StringFlag{
+			Name:    "password",
+			Aliases: []string{"p"},
+			Usage:   "Registry password, prompted for if not supplied",
+		},
+		&cli.StringFlag{
+			Name:  "credential-helper",
+			Usage: "Name of a docker-credential-<name> helper binary on $PATH to store the credentials with, instead of containerd's local credential file",
+		},
+	},
+	Action: func(cliContext *cli.Context) error {
+		host := cliContext.Args().First()
+		if host == "" {
+			return errors.New("please specify a registry host")
+		}
+
+		username := cliContext.String("username")
+		if username == "" {
+			fmt.Print("Username: ")
+			line, err := readLine()
+			if err != nil {
+				return fmt.Errorf("failed to read username: %w", err)
+			}
+			username = line
+		}
+
+		password := cliContext.String("password")
+		if password == "" {
+			fmt.Print("Password: ")
+			line, err := readPassword()
+			if err != nil {
+				return fmt.Errorf("failed to read password: %w", err)
+			}
+			password = line
+			fmt.Print("\n")
+		}
+
+		if err := Login(host, username, password, cliContext.String("credential-helper")); err != nil {
+			return err
+		}
+
+		fmt.Println("Login Succeeded")
+		return nil
+	},
+}
+
+func readLine() (string, error) {
+	c := console.Current()
+	defer c.Reset()
+
+	line, _, err := bufio.NewReader(c).ReadLine()
+	if err != nil {
+		return "", err
+	}
+	return string(line), nil
+}
+
+func readPassword() (string, error) {
+	c := console.Current()
+	defer c.Reset()
+
+	if err := c.DisableEcho(); err != nil {
+		return "", fmt.Errorf("failed to disable echo: %w", err)
+	}
+
+	line, _, err := bufio.NewReader(c).ReadLine()
+	if err != nil {
+		return "", err
+	}
+	return string(line), nil
+}
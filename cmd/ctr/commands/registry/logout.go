@@ -0,0 +1,41 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+var logoutCommand = &cli.Command{
+	Name:      "logout",
+	Usage:     "Remove stored credentials for a registry",
+	ArgsUsage: "<host>",
+	Action: func(cliContext *cli.Context) error {
+		host := cliContext.Args().First()
+		if host == "" {
+			return errors.New("please specify a registry host")
+		}
+		if err := Logout(host); err != nil {
+			return err
+		}
+		fmt.Printf("Removed login credentials for %s\n", host)
+		return nil
+	},
+}
@@ -0,0 +1,118 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/containerd/containerd/v2/core/remotes/docker"
+	hostconfig "github.com/containerd/containerd/v2/core/remotes/docker/config"
+)
+
+var statusCommand = &cli.Command{
+	Name:      "status",
+	Usage:     "Resolve a reference against its configured registry hosts and report per-host request stats",
+	ArgsUsage: "<ref>",
+	Description: `Resolve performs a single resolve request against every host configured
+for ref's registry (mirrors included) and prints, per host, how many
+requests were sent, how many failed, and the outcome of the most recent
+one. It is meant for on-node debugging of registry connectivity, e.g.
+confirming a mirror is reachable and answering before a pull is retried
+against it.
+
+This reports only what the single resolve in this invocation observed:
+there is no persistent daemon-side registry client in containerd to
+accumulate stats across pulls, so repeated runs do not build on each
+other.`,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "plain-http",
+			Usage: "Connect to the registry using plain HTTP",
+		},
+		&cli.BoolFlag{
+			Name:  "skip-verify",
+			Usage: "Skip SSL certificate validation",
+		},
+		&cli.StringFlag{
+			Name:  "hosts-dir",
+			Usage: "Custom hosts.toml directory, overriding /etc/containerd/certs.d",
+		},
+	},
+	Action: func(cliContext *cli.Context) error {
+		ref := cliContext.Args().First()
+		if ref == "" {
+			return errors.New("please specify an image reference")
+		}
+
+		hostOptions := hostconfig.HostOptions{
+			Credentials: func(host string) (string, string, error) {
+				return Get(host)
+			},
+		}
+		if cliContext.Bool("plain-http") {
+			hostOptions.DefaultScheme = "http"
+		}
+		if cliContext.Bool("skip-verify") {
+			hostOptions.DefaultTLS = &tls.Config{InsecureSkipVerify: true}
+		}
+		if hostsDir := cliContext.String("hosts-dir"); hostsDir != "" {
+			hostOptions.HostDir = hostconfig.HostDirFromRoot(hostsDir)
+		}
+
+		tracker := docker.NewStatsTracker()
+		resolver := docker.NewResolver(docker.ResolverOptions{
+			Hosts:        hostconfig.ConfigureHosts(cliContext.Context, hostOptions),
+			StatsTracker: tracker,
+		})
+
+		name, desc, err := resolver.Resolve(cliContext.Context, ref)
+		resolveErr := err
+		if err == nil {
+			fmt.Printf("Resolved %s to %s\n\n", name, desc.Digest)
+		}
+
+		stats := tracker.Stats()
+		if len(stats) == 0 {
+			if resolveErr != nil {
+				return resolveErr
+			}
+			fmt.Println("no requests were recorded")
+			return nil
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 1, 8, 2, ' ', 0)
+		fmt.Fprintln(tw, "HOST\tREQUESTS\tERRORS\tLAST STATUS\tLAST LATENCY\tLAST ERROR")
+		for _, s := range stats {
+			lastError := s.LastError
+			if lastError == "" {
+				lastError = "-"
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%s\t%s\n", s.Host, s.Requests, s.Errors, s.LastStatus, s.LastLatency, lastError)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+
+		return resolveErr
+	},
+}
@@ -0,0 +1,220 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package registry stores and retrieves registry credentials for `ctr`.
+//
+// Credentials are either delegated to a docker-credential-helper binary
+// (https://github.com/docker/docker-credential-helpers) found on $PATH, or,
+// when no helper is configured, kept in a local JSON file modeled on
+// docker's config.json: the secret is base64 encoded, not encrypted, so the
+// credential-helper path should be preferred wherever a platform keychain is
+// available.
+package registry
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/containerd/containerd/v2/pkg/atomicfile"
+)
+
+const credHelperPrefix = "docker-credential-"
+
+// config is the on-disk layout of the local credential store, kept
+// deliberately close to docker's config.json so the file is recognizable to
+// anyone familiar with that format.
+type config struct {
+	CredsStore string               `json:"credsStore,omitempty"`
+	Auths      map[string]authEntry `json:"auths,omitempty"`
+}
+
+type authEntry struct {
+	Auth string `json:"auth"`
+}
+
+func configPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine user config directory: %w", err)
+	}
+	return filepath.Join(dir, "containerd", "ctr", "auth.json"), nil
+}
+
+func readConfig() (*config, error) {
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &config{}, nil
+		}
+		return nil, err
+	}
+	var c config
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+func writeConfig(c *config) error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(c, "", "\t")
+	if err != nil {
+		return err
+	}
+	f, err := atomicfile.New(path, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Cancel()
+		return err
+	}
+	return f.Close()
+}
+
+// Login stores credentials for host, using the named credential helper if
+// one is given, or the local config file otherwise. The helper name, if
+// any, is remembered in the local config file so Get and Logout know where
+// to look for this host in the future.
+func Login(host, username, secret, credHelper string) error {
+	if credHelper != "" {
+		if err := helperStore(credHelper, host, username, secret); err != nil {
+			return fmt.Errorf("failed to store credentials with %s%s: %w", credHelperPrefix, credHelper, err)
+		}
+	}
+
+	c, err := readConfig()
+	if err != nil {
+		return err
+	}
+	if credHelper != "" {
+		c.CredsStore = credHelper
+	} else {
+		if c.Auths == nil {
+			c.Auths = map[string]authEntry{}
+		}
+		c.Auths[host] = authEntry{
+			Auth: base64.StdEncoding.EncodeToString([]byte(username + ":" + secret)),
+		}
+	}
+	return writeConfig(c)
+}
+
+// Logout removes any stored credentials for host.
+func Logout(host string) error {
+	c, err := readConfig()
+	if err != nil {
+		return err
+	}
+	if c.CredsStore != "" {
+		if err := helperErase(c.CredsStore, host); err != nil {
+			return fmt.Errorf("failed to erase credentials with %s%s: %w", credHelperPrefix, c.CredsStore, err)
+		}
+	}
+	if c.Auths != nil {
+		delete(c.Auths, host)
+	}
+	return writeConfig(c)
+}
+
+// Get returns any previously stored credentials for host. It returns empty
+// strings, with no error, when nothing is stored for host.
+func Get(host string) (username, secret string, err error) {
+	c, err := readConfig()
+	if err != nil {
+		return "", "", err
+	}
+	if c.CredsStore != "" {
+		return helperGet(c.CredsStore, host)
+	}
+	entry, ok := c.Auths[host]
+	if !ok {
+		return "", "", nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decode stored credentials for %s: %w", host, err)
+	}
+	username, secret, ok = strings.Cut(string(decoded), ":")
+	if !ok {
+		return "", "", fmt.Errorf("malformed stored credentials for %s", host)
+	}
+	return username, secret, nil
+}
+
+// The remaining functions implement the docker-credential-helper protocol:
+// https://github.com/docker/docker-credential-helpers#development
+
+type helperCredentials struct {
+	ServerURL string
+	Username  string
+	Secret    string
+}
+
+func runHelper(helper, action string, stdin []byte) ([]byte, error) {
+	cmd := exec.Command(credHelperPrefix+helper, action)
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+func helperStore(helper, host, username, secret string) error {
+	in, err := json.Marshal(helperCredentials{ServerURL: host, Username: username, Secret: secret})
+	if err != nil {
+		return err
+	}
+	_, err = runHelper(helper, "store", in)
+	return err
+}
+
+func helperErase(helper, host string) error {
+	_, err := runHelper(helper, "erase", []byte(host))
+	return err
+}
+
+func helperGet(helper, host string) (username, secret string, err error) {
+	out, err := runHelper(helper, "get", []byte(host))
+	if err != nil {
+		// Helpers exit non-zero (with a "credentials not found" message) when
+		// there's nothing stored for host; treat that the same as no entry.
+		return "", "", nil
+	}
+	var creds helperCredentials
+	if err := json.Unmarshal(out, &creds); err != nil {
+		return "", "", fmt.Errorf("failed to parse %s%s output: %w", credHelperPrefix, helper, err)
+	}
+	return creds.Username, creds.Secret, nil
+}
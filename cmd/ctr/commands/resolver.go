@@ -28,6 +28,7 @@ import (
 	"strings"
 
 	"github.com/containerd/console"
+	ctrregistry "github.com/containerd/containerd/v2/cmd/ctr/commands/registry"
 	"github.com/containerd/containerd/v2/core/remotes"
 	"github.com/containerd/containerd/v2/core/remotes/docker"
 	"github.com/containerd/containerd/v2/core/remotes/docker/config"
@@ -39,7 +40,8 @@ import (
 // PushTracker returns a new InMemoryTracker which tracks the ref status
 var PushTracker = docker.NewInMemoryTracker()
 
-func passwordPrompt() (string, error) {
+// PasswordPrompt reads a password from the terminal without echoing it.
+func PasswordPrompt() (string, error) {
 	c := console.Current()
 	defer c.Reset()
 
@@ -70,7 +72,7 @@ func GetResolver(ctx context.Context, cliContext *cli.Context) (remotes.Resolver
 			fmt.Printf("Password: ")
 
 			var err error
-			secret, err = passwordPrompt()
+			secret, err = PasswordPrompt()
 			if err != nil {
 				return nil, err
 			}
@@ -83,9 +85,12 @@ func GetResolver(ctx context.Context, cliContext *cli.Context) (remotes.Resolver
 
 	hostOptions := config.HostOptions{}
 	hostOptions.Credentials = func(host string) (string, string, error) {
-		// If host doesn't match...
-		// Only one host
-		return username, secret, nil
+		if username != "" || secret != "" {
+			return username, secret, nil
+		}
+		// No credentials given on the command line: fall back to whatever
+		// was saved for this host by `ctr registry login`.
+		return ctrregistry.Get(host)
 	}
 	if cliContext.Bool("plain-http") {
 		hostOptions.DefaultScheme = "http"
@@ -170,7 +175,7 @@ func NewStaticCredentials(ctx context.Context, cliContext *cli.Context, ref stri
 			fmt.Printf("Password: ")
 
 			var err error
-			secret, err = passwordPrompt()
+			secret, err = PasswordPrompt()
 			if err != nil {
 				return nil, err
 			}
@@ -189,11 +194,23 @@ func NewStaticCredentials(ctx context.Context, cliContext *cli.Context, ref stri
 }
 
 func (sc *staticCredentials) GetCredentials(ctx context.Context, ref, host string) (registry.Credentials, error) {
-	if ref == sc.ref {
+	if ref != sc.ref {
+		return registry.Credentials{}, nil
+	}
+	if sc.username != "" || sc.secret != "" {
 		return registry.Credentials{
 			Username: sc.username,
 			Secret:   sc.secret,
 		}, nil
 	}
-	return registry.Credentials{}, nil
+	// No credentials given on the command line: fall back to whatever was
+	// saved for this host by `ctr registry login`.
+	username, secret, err := ctrregistry.Get(host)
+	if err != nil {
+		return registry.Credentials{}, err
+	}
+	return registry.Credentials{
+		Username: username,
+		Secret:   secret,
+	}, nil
 }
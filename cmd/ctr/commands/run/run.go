@@ -55,6 +55,25 @@ func withMounts(cliContext *cli.Context) oci.SpecOpts {
 	}
 }
 
+// templateOpts loads the --template file, if one was given, and returns
+// the container template along with the oci.SpecOpts it contributes.
+// Both the template and the returned SpecOpts are the zero value if no
+// template was requested. Callers apply the template's non-spec fields
+// (Labels, Runtime) themselves, since ctr's own flags for those fields
+// carry CLI-wide defaults that must take precedence only when the user
+// actually set them.
+func templateOpts(cliContext *cli.Context) (containerd.ContainerTemplate, []oci.SpecOpts, error) {
+	path := cliContext.String("template")
+	if path == "" {
+		return containerd.ContainerTemplate{}, nil, nil
+	}
+	t, err := containerd.LoadContainerTemplate(path)
+	if err != nil {
+		return containerd.ContainerTemplate{}, nil, err
+	}
+	return t, t.SpecOpts(), nil
+}
+
 // parseMountFlag parses a mount string in the form "type=foo,source=/path,destination=/target,options=rbind:rw"
 func parseMountFlag(m string) (specs.Mount, error) {
 	mount := specs.Mount{}
@@ -179,10 +198,16 @@ var Command = &cli.Command{
 			return err
 		}
 		if rm && !detach {
+			info, infoErr := container.Info(ctx)
 			defer func() {
 				if err := container.Delete(ctx, containerd.WithSnapshotCleanup); err != nil {
 					log.L.WithError(err).Error("failed to cleanup container")
+					return
 				}
+				if infoErr != nil || info.SnapshotKey == "" {
+					return
+				}
+				warnIfSnapshotLeaked(ctx, client, info.Snapshotter, info.SnapshotKey)
 			}()
 		}
 		var con console.Console
@@ -269,6 +294,23 @@ var Command = &cli.Command{
 	},
 }
 
+// warnIfSnapshotLeaked checks that a --rm container's snapshot actually
+// went away after WithSnapshotCleanup, and logs a warning naming it if not.
+// A snapshot (and the rootfs mount backing it) outliving its container is
+// the leak "ctr run --rm" is supposed to prevent; it most often means
+// something else (a still-running task, a child snapshot, a lease) kept a
+// reference to it, since Remove itself reports no error in that case.
+func warnIfSnapshotLeaked(ctx context.Context, client *containerd.Client, snapshotter, key string) {
+	if snapshotter == "" {
+		return
+	}
+	if _, err := client.SnapshotService(snapshotter).Stat(ctx, key); err == nil {
+		log.L.Warnf("snapshot %q (snapshotter %q) is still present after container cleanup; its rootfs mount may be leaked", key, snapshotter)
+	} else if !errdefs.IsNotFound(err) {
+		log.L.WithError(err).Warnf("failed to check snapshot %q (snapshotter %q) for leaks after container cleanup", key, snapshotter)
+	}
+}
+
 // buildLabels builds the labels from command line labels and the image labels
 func buildLabels(cmdLabels, imageLabels map[string]string) map[string]string {
 	labels := make(map[string]string)
@@ -44,7 +44,6 @@ import (
 	"github.com/intel/goresctrl/pkg/blockio"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/urfave/cli/v2"
-	"tags.cncf.io/container-device-interface/pkg/cdi"
 	"tags.cncf.io/container-device-interface/pkg/parser"
 )
 
@@ -103,6 +102,14 @@ func NewContainer(ctx context.Context, client *containerd.Client, cliContext *cl
 		spec  containerd.NewContainerOpts
 	)
 
+	template, templateSpecOpts, err := templateOpts(cliContext)
+	if err != nil {
+		return nil, err
+	}
+	if len(template.Labels) > 0 {
+		cOpts = append(cOpts, containerd.WithContainerLabels(template.Labels))
+	}
+
 	if sandbox := cliContext.String("sandbox"); sandbox != "" {
 		cOpts = append(cOpts, containerd.WithSandbox(sandbox))
 	}
@@ -404,8 +411,13 @@ func NewContainer(ctx context.Context, client *containerd.Client, cliContext *cl
 	if err != nil {
 		return nil, err
 	}
-	cOpts = append(cOpts, containerd.WithRuntime(cliContext.String("runtime"), runtimeOpts))
+	if runtimeName := cliContext.String("runtime"); cliContext.IsSet("runtime") || template.Runtime == "" {
+		cOpts = append(cOpts, containerd.WithRuntime(runtimeName, runtimeOpts))
+	} else {
+		cOpts = append(cOpts, containerd.WithRuntime(template.Runtime, runtimeOpts))
+	}
 
+	opts = append(opts, templateSpecOpts...)
 	opts = append(opts, oci.WithAnnotations(commands.LabelArgs(cliContext.StringSlice("label"))))
 	var s specs.Spec
 	spec = containerd.WithSpec(&s, opts...)
@@ -476,11 +488,9 @@ func getNetNSPath(_ context.Context, task containerd.Task) (string, error) {
 
 // withStaticCDIRegistry inits the CDI registry and disables auto-refresh.
 // This is used from the `run` command to avoid creating a registry with auto-refresh enabled.
-// It also provides a way to override the CDI spec file paths if required.
 func withStaticCDIRegistry() oci.SpecOpts {
 	return func(ctx context.Context, _ oci.Client, _ *containers.Container, s *oci.Spec) error {
-		_ = cdi.Configure(cdi.WithAutoRefresh(false))
-		if err := cdi.Refresh(); err != nil {
+		if err := cdispec.Configure(); err != nil {
 			// We don't consider registry refresh failure a fatal error.
 			// For instance, a dynamically generated invalid CDI Spec file for
 			// any particular vendor shouldn't prevent injection of devices of
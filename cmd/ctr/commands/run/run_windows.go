@@ -52,6 +52,14 @@ func NewContainer(ctx context.Context, client *containerd.Client, cliContext *cl
 		config = cliContext.IsSet("config")
 	)
 
+	template, templateSpecOpts, err := templateOpts(cliContext)
+	if err != nil {
+		return nil, err
+	}
+	if len(template.Labels) > 0 {
+		cOpts = append(cOpts, containerd.WithContainerLabels(template.Labels))
+	}
+
 	if sandbox := cliContext.String("sandbox"); sandbox != "" {
 		cOpts = append(cOpts, containerd.WithSandbox(sandbox))
 	}
@@ -174,6 +182,9 @@ func NewContainer(ctx context.Context, client *containerd.Client, cliContext *cl
 	}
 
 	runtime := cliContext.String("runtime")
+	if !cliContext.IsSet("runtime") && template.Runtime != "" {
+		runtime = template.Runtime
+	}
 	var runtimeOpts interface{}
 	if runtime == "io.containerd.runhcs.v1" {
 		runtimeOpts = &options.Options{
@@ -182,6 +193,7 @@ func NewContainer(ctx context.Context, client *containerd.Client, cliContext *cl
 	}
 	cOpts = append(cOpts, containerd.WithRuntime(runtime, runtimeOpts))
 
+	opts = append(opts, templateSpecOpts...)
 	var s specs.Spec
 	spec = containerd.WithSpec(&s, opts...)
 
@@ -30,6 +30,7 @@ import (
 
 	"github.com/containerd/log"
 	digest "github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/identity"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/urfave/cli/v2"
 
@@ -61,6 +62,7 @@ var Command = &cli.Command{
 		treeCommand,
 		unpackCommand,
 		usageCommand,
+		verifyCommand,
 		viewCommand,
 	},
 }
@@ -252,6 +254,90 @@ var usageCommand = &cli.Command{
 	},
 }
 
+var verifyCommand = &cli.Command{
+	Name:  "verify",
+	Usage: "Verify that committed snapshots match the diffIDs recorded by their image, optionally repairing drift",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "repair",
+			Usage: "Re-unpack any layer whose recomputed digest does not match its image diffID",
+		},
+	},
+	Action: func(cliContext *cli.Context) error {
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		ctx, done, err := client.WithLease(ctx)
+		if err != nil {
+			return err
+		}
+		defer done(ctx)
+
+		snapshotterName := cliContext.String("snapshotter")
+		snapshotter := client.SnapshotService(snapshotterName)
+		repair := cliContext.Bool("repair")
+
+		images, err := client.ListImages(ctx)
+		if err != nil {
+			return err
+		}
+
+		var drifted, missing int
+		for _, image := range images {
+			diffIDs, err := image.RootFS(ctx)
+			if err != nil {
+				log.G(ctx).WithError(err).WithField("image", image.Name()).Warn("failed to resolve rootfs, skipping")
+				continue
+			}
+			if len(diffIDs) == 0 {
+				continue
+			}
+
+			chainIDs := identity.ChainIDs(diffIDs)
+			for i, chainID := range chainIDs {
+				key := chainID.String()
+				info, err := snapshotter.Stat(ctx, key)
+				if err != nil {
+					fmt.Printf("MISSING\t%s\t(image %s, layer %d)\n", key, image.Name(), i)
+					missing++
+					continue
+				}
+				if info.Kind != snapshots.KindCommitted {
+					continue
+				}
+
+				got, err := rootfs.CreateDiff(ctx, key, snapshotter, client.DiffService(), diff.WithMediaType(ocispec.MediaTypeImageLayer))
+				if err != nil {
+					fmt.Printf("ERROR\t%s\t%v\n", key, err)
+					drifted++
+					continue
+				}
+
+				if got.Digest != diffIDs[i] {
+					fmt.Printf("DRIFT\t%s\twant=%s got=%s\n", key, diffIDs[i], got.Digest)
+					drifted++
+					if repair {
+						if err := image.Unpack(ctx, snapshotterName); err != nil {
+							fmt.Printf("REPAIR FAILED\t%s\t%v\n", key, err)
+						} else {
+							fmt.Printf("REPAIRED\t%s\n", key)
+						}
+					}
+				}
+			}
+		}
+
+		if drifted > 0 || missing > 0 {
+			return fmt.Errorf("verification found %d drifted and %d missing snapshots", drifted, missing)
+		}
+		fmt.Println("all snapshots verified")
+		return nil
+	},
+}
+
 var removeCommand = &cli.Command{
 	Name:      "delete",
 	Aliases:   []string{"del", "remove", "rm"},
@@ -0,0 +1,234 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package storage provides a cross-namespace disk usage report for ctr.
+//
+// containerd has no introspection API dedicated to storage accounting, so
+// this aggregates the same figures an operator could already get one
+// namespace and one snapshotter at a time ("content" blob sizes via the
+// content store, per-snapshot usage via each snapshotter's own Usage call,
+// and an estimate of what "gc run" would reclaim via the same reachability
+// walk "gc plan" uses) into a single cross-namespace report.
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/cmd/ctr/commands"
+	gcCmd "github.com/containerd/containerd/v2/cmd/ctr/commands/gc"
+	"github.com/containerd/containerd/v2/core/content"
+	"github.com/containerd/containerd/v2/core/snapshots"
+	"github.com/containerd/containerd/v2/pkg/namespaces"
+	"github.com/containerd/containerd/v2/pkg/progress"
+	"github.com/containerd/containerd/v2/plugins"
+	"github.com/containerd/log"
+	"github.com/urfave/cli/v2"
+)
+
+// Command is the cli command for reporting on containerd's storage usage.
+var Command = &cli.Command{
+	Name:  "storage",
+	Usage: "Report on containerd's disk usage",
+	Subcommands: cli.Commands{
+		usageCommand,
+	},
+}
+
+var usageCommand = &cli.Command{
+	Name:  "usage",
+	Usage: "Report content store and snapshotter usage across all namespaces",
+	Description: `Aggregates, for every namespace: total content store size, size used by
+each registered snapshotter, and an estimate of content bytes "gc run" would
+reclaim right now. The reclaimable estimate is the same client-side
+reachability approximation "ctr gc plan" uses (images and lease resources
+vs. the full content listing), so it carries the same caveats: it can
+under- or over-report relative to the garbage collector's own mark phase.
+`,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "format",
+			Usage: "output format to use (Examples: 'default', 'json')",
+		},
+	},
+	Action: func(cliContext *cli.Context) error {
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		snapshotterNames, err := SnapshotterNames(ctx, client)
+		if err != nil {
+			return err
+		}
+
+		namespaceList, err := client.NamespaceService().List(ctx)
+		if err != nil {
+			return err
+		}
+		sort.Strings(namespaceList)
+
+		report := Report{
+			SnapshotterTotals: make(map[string]int64, len(snapshotterNames)),
+		}
+		for _, ns := range namespaceList {
+			nsReport, err := reportForNamespace(namespaces.WithNamespace(ctx, ns), client, ns, snapshotterNames)
+			if err != nil {
+				return fmt.Errorf("failed to report usage for namespace %q: %w", ns, err)
+			}
+			report.Namespaces = append(report.Namespaces, nsReport)
+			report.ContentTotal += nsReport.ContentBytes
+			report.ReclaimableTotal += nsReport.ReclaimableBytes
+			for name, size := range nsReport.SnapshotterBytes {
+				report.SnapshotterTotals[name] += size
+			}
+		}
+
+		if cliContext.String("format") == "json" {
+			commands.PrintAsJSON(report)
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 4, 8, 4, ' ', 0)
+		fmt.Fprintln(w, "NAMESPACE\tCONTENT\tRECLAIMABLE\tSNAPSHOTTERS\t")
+		for _, nsReport := range report.Namespaces {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n",
+				nsReport.Namespace,
+				progress.Bytes(nsReport.ContentBytes),
+				progress.Bytes(nsReport.ReclaimableBytes),
+				formatSnapshotterTotals(nsReport.SnapshotterBytes),
+			)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n",
+			"TOTAL",
+			progress.Bytes(report.ContentTotal),
+			progress.Bytes(report.ReclaimableTotal),
+			formatSnapshotterTotals(report.SnapshotterTotals),
+		)
+		return w.Flush()
+	},
+}
+
+// Report is the full cross-namespace storage usage report.
+type Report struct {
+	Namespaces        []NamespaceUsage `json:"namespaces"`
+	ContentTotal      int64            `json:"contentBytesTotal"`
+	ReclaimableTotal  int64            `json:"reclaimableBytesTotal"`
+	SnapshotterTotals map[string]int64 `json:"snapshotterBytesTotal"`
+}
+
+// NamespaceUsage is the storage usage report for a single namespace.
+type NamespaceUsage struct {
+	Namespace        string           `json:"namespace"`
+	ContentBytes     int64            `json:"contentBytes"`
+	ReclaimableBytes int64            `json:"reclaimableBytes"`
+	SnapshotterBytes map[string]int64 `json:"snapshotterBytes"`
+}
+
+func reportForNamespace(ctx context.Context, client *containerd.Client, ns string, snapshotterNames []string) (NamespaceUsage, error) {
+	cs := client.ContentStore()
+
+	contentBytes, err := gcCmd.ContentStoreSize(ctx, cs)
+	if err != nil {
+		return NamespaceUsage{}, fmt.Errorf("content store: %w", err)
+	}
+
+	reachable, err := gcCmd.ReachableDigests(ctx, client.ImageService(), client.LeasesService(), cs)
+	if err != nil {
+		return NamespaceUsage{}, fmt.Errorf("reachability walk: %w", err)
+	}
+	var reclaimable int64
+	if err := cs.Walk(ctx, func(info content.Info) error {
+		if _, ok := reachable[info.Digest]; !ok {
+			reclaimable += info.Size
+		}
+		return nil
+	}); err != nil {
+		return NamespaceUsage{}, fmt.Errorf("content store: %w", err)
+	}
+
+	snapshotterBytes := make(map[string]int64, len(snapshotterNames))
+	for _, name := range snapshotterNames {
+		size, err := SnapshotterUsage(ctx, client, name)
+		if err != nil {
+			log.G(ctx).WithError(err).Warnf("failed to get usage for snapshotter %q in namespace %q", name, ns)
+			continue
+		}
+		snapshotterBytes[name] = size
+	}
+
+	return NamespaceUsage{
+		Namespace:        ns,
+		ContentBytes:     contentBytes,
+		ReclaimableBytes: reclaimable,
+		SnapshotterBytes: snapshotterBytes,
+	}, nil
+}
+
+// SnapshotterUsage sums per-snapshot Usage (which already excludes parent
+// usage per its own doc comment) across every snapshot known to the named
+// snapshotter, the same calculation "ctr snapshots usage" does for a single
+// chain, just over all of them.
+func SnapshotterUsage(ctx context.Context, client *containerd.Client, name string) (int64, error) {
+	sn := client.SnapshotService(name)
+	var total int64
+	err := sn.Walk(ctx, func(ctx context.Context, info snapshots.Info) error {
+		usage, err := sn.Usage(ctx, info.Name)
+		if err != nil {
+			return err
+		}
+		total += usage.Size
+		return nil
+	})
+	return total, err
+}
+
+// SnapshotterNames discovers the registered snapshotter plugin IDs via the
+// introspection service, so the report covers every snapshotter the daemon
+// knows about rather than just the default one.
+func SnapshotterNames(ctx context.Context, client *containerd.Client) ([]string, error) {
+	resp, err := client.IntrospectionService().Plugins(ctx, fmt.Sprintf("type==%s", plugins.SnapshotPlugin))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(resp.Plugins))
+	for _, p := range resp.Plugins {
+		names = append(names, p.ID)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func formatSnapshotterTotals(totals map[string]int64) string {
+	names := make([]string, 0, len(totals))
+	for name := range totals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	s := ""
+	for i, name := range names {
+		if i > 0 {
+			s += ", "
+		}
+		s += fmt.Sprintf("%s=%s", name, progress.Bytes(totals[name]))
+	}
+	return s
+}
@@ -50,7 +50,10 @@ func FuzzExchange(f *testing.F) {
 			return
 		}
 		ctx := namespaces.WithNamespace(context.Background(), namespace)
-		exch := exchange.NewExchange()
+		exch, err := exchange.NewExchange()
+		if err != nil {
+			return
+		}
 		exch.Publish(ctx, input, event)
 		exch.Forward(ctx, env)
 	})
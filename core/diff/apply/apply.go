@@ -89,7 +89,8 @@ func (s *fsApplier) Apply(ctx context.Context, desc ocispec.Descriptor, mounts [
 
 	digester := digest.Canonical.Digester()
 	rc := &readCounter{
-		r: io.TeeReader(processor, digester.Hash()),
+		r:        io.TeeReader(processor, digester.Hash()),
+		progress: config.Progress,
 	}
 
 	if err := apply(ctx, mounts, rc, config.SyncFs); err != nil {
@@ -118,14 +119,18 @@ func (s *fsApplier) Apply(ctx context.Context, desc ocispec.Descriptor, mounts [
 }
 
 type readCounter struct {
-	r io.Reader
-	c int64
+	r        io.Reader
+	c        int64
+	progress func(copied int64)
 }
 
 func (rc *readCounter) Read(p []byte) (n int, err error) {
 	n, err = rc.r.Read(p)
 	if n > 0 {
 		rc.c += int64(n)
+		if rc.progress != nil {
+			rc.progress(rc.c)
+		}
 	}
 	return
 }
@@ -48,6 +48,19 @@ type Config struct {
 
 	// SourceDateEpoch specifies the SOURCE_DATE_EPOCH without touching the env vars.
 	SourceDateEpoch *time.Time
+
+	// CompressionLevel sets the target compression level for the
+	// default compressor selected through MediaType. Its meaning
+	// depends on the compression algorithm; for zstd it matches
+	// zstd.EncoderLevel. A zero value uses the compressor's default and
+	// has no effect when Compressor is set.
+	CompressionLevel int
+
+	// CompressionConcurrency sets the number of goroutines the default
+	// compressor uses to compress concurrently, where supported
+	// (currently zstd only). A zero value uses the compressor's default
+	// and has no effect when Compressor is set.
+	CompressionConcurrency int
 }
 
 // Opt is used to configure a diff operation
@@ -69,6 +82,12 @@ type ApplyConfig struct {
 	ProcessorPayloads map[string]typeurl.Any
 	// SyncFs is to synchronize the underlying filesystem containing files
 	SyncFs bool
+
+	// Progress, if set, is called periodically with the number of bytes of
+	// the diff stream applied so far. It is only honored by appliers that
+	// apply locally; proxy appliers that go over the (unary) Diff GRPC/TTRPC
+	// API have no channel to report progress back on and ignore it.
+	Progress func(copied int64)
 }
 
 // ApplyOpt is used to configure an Apply operation
@@ -127,6 +146,15 @@ func WithPayloads(payloads map[string]typeurl.Any) ApplyOpt {
 	}
 }
 
+// WithProgress sets a callback invoked periodically with the number of
+// bytes applied so far while the diff is being extracted.
+func WithProgress(f func(copied int64)) ApplyOpt {
+	return func(_ context.Context, _ ocispec.Descriptor, c *ApplyConfig) error {
+		c.Progress = f
+		return nil
+	}
+}
+
 // WithSyncFs sets sync flag to the config.
 func WithSyncFs(sync bool) ApplyOpt {
 	return func(_ context.Context, _ ocispec.Descriptor, c *ApplyConfig) error {
@@ -135,6 +163,26 @@ func WithSyncFs(sync bool) ApplyOpt {
 	}
 }
 
+// WithCompressionLevel sets the target compression level for the default
+// compressor selected through MediaType. It has no effect when used
+// together with WithCompressor.
+func WithCompressionLevel(level int) Opt {
+	return func(c *Config) error {
+		c.CompressionLevel = level
+		return nil
+	}
+}
+
+// WithCompressionConcurrency sets the number of goroutines the default
+// compressor uses to compress concurrently, where supported (currently
+// zstd only). It has no effect when used together with WithCompressor.
+func WithCompressionConcurrency(n int) Opt {
+	return func(c *Config) error {
+		c.CompressionConcurrency = n
+		return nil
+	}
+}
+
 // WithSourceDateEpoch specifies the timestamp used to provide control for reproducibility.
 // See also https://reproducible-builds.org/docs/source-date-epoch/ .
 //
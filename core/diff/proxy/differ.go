@@ -44,6 +44,9 @@ type diffRemote struct {
 	client diffapi.DiffClient
 }
 
+// Apply applies the diff over GRPC/TTRPC. The Diff/Apply RPCs are unary, so
+// config.Progress (see diff.WithProgress) has nothing to report against and
+// is silently ignored here; it is only meaningful for in-process appliers.
 func (r *diffRemote) Apply(ctx context.Context, desc ocispec.Descriptor, mounts []mount.Mount, opts ...diff.ApplyOpt) (ocispec.Descriptor, error) {
 	var config diff.ApplyConfig
 	for _, opt := range opts {
@@ -33,8 +33,21 @@ var (
 
 	// ErrNoProcessor is returned when no stream processor is available for a media-type
 	ErrNoProcessor = errors.New("no processor for media-type")
+
+	// decompressionPool, when non-nil, bounds how many layers the default
+	// compressedHandler will decompress concurrently. It is unset by
+	// default, matching the historical unbounded behavior.
+	decompressionPool *compression.DecompressionPool
 )
 
+// SetDecompressionPool configures the worker pool used by the default
+// compressed-layer stream processor to decompress layer content. Passing nil
+// restores the default unbounded behavior, where each call to Apply/unpack
+// decompresses its layer inline with no shared limit on parallelism.
+func SetDecompressionPool(pool *compression.DecompressionPool) {
+	decompressionPool = pool
+}
+
 func init() {
 	// register the default compression handler
 	RegisterProcessor(compressedHandler)
@@ -94,7 +107,15 @@ func compressedHandler(ctx context.Context, mediaType string) (StreamProcessorIn
 	}
 	if compressed != "" {
 		return func(ctx context.Context, stream StreamProcessor, payloads map[string]typeurl.Any) (StreamProcessor, error) {
-			ds, err := compression.DecompressStream(stream)
+			var (
+				ds  compression.DecompressReadCloser
+				err error
+			)
+			if decompressionPool != nil {
+				ds, err = decompressionPool.DecompressStream(stream)
+			} else {
+				ds, err = compression.DecompressStream(stream)
+			}
 			if err != nil {
 				return nil, err
 			}
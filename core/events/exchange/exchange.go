@@ -19,6 +19,7 @@ package exchange
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -30,18 +31,73 @@ import (
 	"github.com/containerd/log"
 	"github.com/containerd/typeurl/v2"
 	goevents "github.com/docker/go-events"
+	"golang.org/x/time/rate"
 )
 
 // Exchange broadcasts events
 type Exchange struct {
-	broadcaster *goevents.Broadcaster
+	broadcaster     *goevents.Broadcaster
+	journal         *journal
+	subscriberLimit rate.Limit
+	subscriberBurst int
 }
 
-// NewExchange returns a new event Exchange
-func NewExchange() *Exchange {
-	return &Exchange{
+// Opt configures an Exchange.
+type Opt func(*Exchange) error
+
+// WithJournal opens (or creates) a bounded, on-disk event journal at path
+// and has the Exchange append every published or forwarded event to it,
+// enabling replay through Subscribe's "since" filter (see sinceFilterKey)
+// for subscribers that reconnect after missing events.
+func WithJournal(path string, cfg JournalConfig) Opt {
+	return func(e *Exchange) error {
+		j, err := openJournal(path, cfg)
+		if err != nil {
+			return err
+		}
+		e.journal = j
+		return nil
+	}
+}
+
+// WithSubscriberRateLimit bounds how many events per second any single
+// Subscribe call delivers, as a token-bucket allowing bursts up to burst
+// before it starts limiting. Events arriving faster than the limit allows
+// are dropped for that subscriber rather than queued, so one throttled
+// subscriber can't build unbounded backlog; every other subscriber has its
+// own queue and is unaffected.
+func WithSubscriberRateLimit(eventsPerSecond float64, burst int) Opt {
+	return func(e *Exchange) error {
+		e.subscriberLimit = rate.Limit(eventsPerSecond)
+		e.subscriberBurst = burst
+		return nil
+	}
+}
+
+// NewExchange returns a new event Exchange. It returns an error only if an
+// Opt does, e.g. WithJournal failing to open its on-disk file.
+func NewExchange(opts ...Opt) (*Exchange, error) {
+	e := &Exchange{
 		broadcaster: goevents.NewBroadcaster(),
 	}
+
+	for _, opt := range opts {
+		if err := opt(e); err != nil {
+			return nil, fmt.Errorf("configuring event exchange: %w", err)
+		}
+	}
+
+	return e, nil
+}
+
+// Close releases resources held by the Exchange, such as its journal, if
+// one was configured with WithJournal. It does not stop in-flight
+// subscriptions.
+func (e *Exchange) Close() error {
+	if e.journal != nil {
+		return e.journal.Close()
+	}
+	return nil
 }
 
 var _ events.Publisher = &Exchange{}
@@ -71,6 +127,8 @@ func (e *Exchange) Forward(ctx context.Context, envelope *events.Envelope) (err
 		}
 	}()
 
+	e.appendJournal(ctx, envelope)
+
 	return e.broadcaster.Write(envelope)
 }
 
@@ -115,9 +173,29 @@ func (e *Exchange) Publish(ctx context.Context, topic string, event events.Event
 		}
 	}()
 
+	e.appendJournal(ctx, &envelope)
+
 	return e.broadcaster.Write(&envelope)
 }
 
+// appendJournal persists envelope to the journal, if one is configured. A
+// journal write failure only means a reconnecting subscriber might miss
+// this one event on replay; it must not stop the event from reaching
+// subscribers that are live right now, so it is logged rather than
+// returned.
+func (e *Exchange) appendJournal(ctx context.Context, envelope *events.Envelope) {
+	if e.journal == nil {
+		return
+	}
+
+	if _, err := e.journal.append(envelope); err != nil {
+		log.G(ctx).WithError(err).WithFields(log.Fields{
+			"topic": envelope.Topic,
+			"ns":    envelope.Namespace,
+		}).Error("error appending event to journal")
+	}
+}
+
 // Subscribe to events on the exchange. Events are sent through the returned
 // channel ch. If an error is encountered, it will be sent on channel errs and
 // errs will be closed. To end the subscription, cancel the provided context.
@@ -125,6 +203,13 @@ func (e *Exchange) Publish(ctx context.Context, topic string, event events.Event
 // Zero or more filters may be provided as strings. Only events that match
 // *any* of the provided filters will be sent on the channel. The filters use
 // the standard containerd filters package syntax.
+//
+// One filter is reserved: sinceFilterKey ("since"), as in "since==100" or
+// "since==2024-01-01T00:00:00Z". If present, and an event journal was
+// configured with WithJournal, matching events recorded before this call
+// are replayed on ch before live events start, letting a subscriber that
+// lost its connection pick back up without missing anything that happened
+// in between. It is an error to use this filter without a journal.
 func (e *Exchange) Subscribe(ctx context.Context, fs ...string) (ch <-chan *events.Envelope, errs <-chan error) {
 	var (
 		evch                  = make(chan *events.Envelope)
@@ -144,8 +229,16 @@ func (e *Exchange) Subscribe(ctx context.Context, fs ...string) (ch <-chan *even
 	ch = evch
 	errs = errq
 
+	since, fs, err := extractSinceFilter(fs)
+	if err != nil {
+		errq <- err
+		closeAll()
+		return
+	}
+
+	var filter filters.Filter
 	if len(fs) > 0 {
-		filter, err := filters.ParseAll(fs...)
+		filter, err = filters.ParseAll(fs...)
 		if err != nil {
 			errq <- fmt.Errorf("failed parsing subscription filters: %w", err)
 			closeAll()
@@ -157,12 +250,48 @@ func (e *Exchange) Subscribe(ctx context.Context, fs ...string) (ch <-chan *even
 		}))
 	}
 
+	var replay []*events.Envelope
+	if since != nil {
+		if e.journal == nil {
+			errq <- fmt.Errorf("subscription requested replay since %v but no event journal is configured: %w", since, errdefs.ErrUnavailable)
+			closeAll()
+			return
+		}
+
+		replay, err = since.fetch(e.journal)
+		if err != nil {
+			errq <- fmt.Errorf("replaying events from journal: %w", err)
+			closeAll()
+			return
+		}
+	}
+
+	var limiter *rate.Limiter
+	if e.subscriberLimit > 0 {
+		limiter = rate.NewLimiter(e.subscriberLimit, e.subscriberBurst)
+	}
+
 	e.broadcaster.Add(dst)
 
 	go func() {
 		defer closeAll()
 
-		var err error
+		for _, env := range replay {
+			if filter != nil && !filter.Match(adapt(env)) {
+				continue
+			}
+			select {
+			case evch <- env:
+			case <-ctx.Done():
+				errq <- ctx.Err()
+				return
+			}
+		}
+
+		var (
+			err     error
+			dropped int
+		)
 	loop:
 		for {
 			select {
@@ -176,6 +305,11 @@ func (e *Exchange) Subscribe(ctx context.Context, fs ...string) (ch <-chan *even
 					break
 				}
 
+				if limiter != nil && !limiter.Allow() {
+					dropped++
+					continue
+				}
+
 				select {
 				case evch <- env:
 				case <-ctx.Done():
@@ -186,6 +320,10 @@ func (e *Exchange) Subscribe(ctx context.Context, fs ...string) (ch <-chan *even
 			}
 		}
 
+		if dropped > 0 {
+			log.G(ctx).WithField("dropped", dropped).Warn("subscriber exceeded event rate limit, some events were not delivered")
+		}
+
 		if err == nil {
 			if cerr := ctx.Err(); cerr != context.Canceled {
 				err = cerr
@@ -237,6 +375,74 @@ func validateEnvelope(envelope *events.Envelope) error {
 	return nil
 }
 
+// sinceFilterKey is the reserved filter fieldpath Subscribe recognizes for
+// journal replay, e.g. "since==100" (a sequence number) or
+// "since==2024-01-01T00:00:00Z" (an RFC3339 timestamp, exclusive). It is
+// handled here, not by the filters package, since it selects a set of
+// already-published envelopes to replay rather than matching fields on one.
+const sinceFilterKey = "since=="
+
+// sinceFilter is the parsed form of a "since==" subscription filter.
+type sinceFilter struct {
+	seq *uint64
+	t   *time.Time
+}
+
+func (s *sinceFilter) String() string {
+	if s.seq != nil {
+		return strconv.FormatUint(*s.seq, 10)
+	}
+	return s.t.Format(time.RFC3339Nano)
+}
+
+func (s *sinceFilter) fetch(j *journal) ([]*events.Envelope, error) {
+	if s.seq != nil {
+		return j.sinceSeq(*s.seq)
+	}
+	return j.sinceTime(*s.t)
+}
+
+func parseSinceFilter(value string) (*sinceFilter, error) {
+	if seq, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return &sinceFilter{seq: &seq}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid since value %q: must be a sequence number or an RFC3339 timestamp: %w", value, errdefs.ErrInvalidArgument)
+	}
+	return &sinceFilter{t: &t}, nil
+}
+
+// extractSinceFilter pulls any "since==" entries out of fs, returning the
+// parsed filter (nil if none were present) and the remaining filters
+// unchanged. It is an error to pass more than one.
+func extractSinceFilter(fs []string) (*sinceFilter, []string, error) {
+	var (
+		since *sinceFilter
+		rest  []string
+	)
+
+	for _, f := range fs {
+		if !strings.HasPrefix(f, sinceFilterKey) {
+			rest = append(rest, f)
+			continue
+		}
+
+		if since != nil {
+			return nil, nil, fmt.Errorf("multiple %q filters provided: %w", sinceFilterKey, errdefs.ErrInvalidArgument)
+		}
+
+		parsed, err := parseSinceFilter(strings.TrimPrefix(f, sinceFilterKey))
+		if err != nil {
+			return nil, nil, err
+		}
+		since = parsed
+	}
+
+	return since, rest, nil
+}
+
 func adapt(ev interface{}) filters.Adaptor {
 	if adaptor, ok := ev.(filters.Adaptor); ok {
 		return adaptor
@@ -38,7 +38,10 @@ func TestExchangeBasic(t *testing.T) {
 		&eventstypes.ContainerCreate{ID: "qwer"},
 		&eventstypes.ContainerCreate{ID: "zxcv"},
 	}
-	exchange := NewExchange()
+	exchange, err := NewExchange()
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	t.Log("subscribe")
 	var cancel1, cancel2 func()
@@ -114,8 +117,8 @@ func TestExchangeBasic(t *testing.T) {
 
 func TestExchangeFilters(t *testing.T) {
 	var (
-		ctx      = namespaces.WithNamespace(context.Background(), t.Name())
-		exchange = NewExchange()
+		ctx         = namespaces.WithNamespace(context.Background(), t.Name())
+		exchange, _ = NewExchange()
 
 		// config events, All events will be published
 		containerCreateEvents = []events.Event{
@@ -273,7 +276,10 @@ func TestExchangeFilters(t *testing.T) {
 func TestExchangeValidateTopic(t *testing.T) {
 	namespace := t.Name()
 	ctx := namespaces.WithNamespace(context.Background(), namespace)
-	exchange := NewExchange()
+	exchange, err := NewExchange()
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	for _, testcase := range []struct {
 		input string
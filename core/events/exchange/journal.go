@@ -0,0 +1,247 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package exchange
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/containerd/containerd/v2/core/events"
+	bolt "go.etcd.io/bbolt"
+)
+
+var bucketKeyEvents = []byte("events")
+
+// JournalConfig bounds the on-disk event journal an Exchange keeps when
+// opened with WithJournal, so that a subscriber that reconnects can replay
+// what it missed instead of silently losing events.
+type JournalConfig struct {
+	// MaxEvents is the maximum number of envelopes the journal retains. The
+	// oldest envelopes are pruned once a new one would push the journal
+	// over this count. Zero means no count-based pruning (rely on
+	// Retention instead, or on nothing).
+	MaxEvents int
+
+	// Retention is the maximum age of an envelope kept in the journal.
+	// Envelopes older than this are pruned on every append. Zero means no
+	// age-based pruning.
+	Retention time.Duration
+}
+
+// journal is a bounded, sequence-numbered, on-disk log of envelopes. It is
+// its own bolt database rather than sharing one with the metadata store,
+// since events are daemon-wide and not scoped to a namespace the way
+// metadata objects are.
+type journal struct {
+	db  *bolt.DB
+	cfg JournalConfig
+}
+
+func openJournal(path string, cfg JournalConfig) (*journal, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("opening event journal %s: %w", path, err)
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucketKeyEvents)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("initializing event journal %s: %w", path, err)
+	}
+
+	return &journal{db: db, cfg: cfg}, nil
+}
+
+func (j *journal) Close() error {
+	return j.db.Close()
+}
+
+// append stores env under the next sequence number, then prunes the
+// journal back down to its configured bounds, and returns the assigned
+// sequence number.
+func (j *journal) append(env *events.Envelope) (uint64, error) {
+	var seq uint64
+
+	err := j.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(bucketKeyEvents)
+
+		id, err := bkt.NextSequence()
+		if err != nil {
+			return err
+		}
+		seq = id
+
+		data, err := encodeEnvelope(env)
+		if err != nil {
+			return err
+		}
+
+		if err := bkt.Put(seqKey(seq), data); err != nil {
+			return err
+		}
+
+		return j.prune(bkt)
+	})
+
+	return seq, err
+}
+
+// prune drops entries past cfg.MaxEvents and older than cfg.Retention. It
+// must run inside the update transaction that already holds bkt.
+//
+// bkt.Stats().KeyN is not used to count entries here: bbolt computes it by
+// walking the committed on-disk pages, so it does not see keys put earlier
+// in the same still-open transaction, which would undercount right after
+// an append and let the journal grow past MaxEvents.
+func (j *journal) prune(bkt *bolt.Bucket) error {
+	if j.cfg.MaxEvents > 0 {
+		n := 0
+		if err := bkt.ForEach(func(_, _ []byte) error {
+			n++
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for ; n > j.cfg.MaxEvents; n-- {
+			k, _ := bkt.Cursor().First()
+			if k == nil {
+				break
+			}
+			if err := bkt.Delete(k); err != nil {
+				return err
+			}
+		}
+	}
+
+	if j.cfg.Retention > 0 {
+		cutoff := time.Now().Add(-j.cfg.Retention)
+		c := bkt.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			env, err := decodeEnvelope(v)
+			if err != nil {
+				return err
+			}
+			if env.Timestamp.After(cutoff) {
+				break
+			}
+			if err := bkt.Delete(k); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// sinceSeq returns envelopes appended after seq, oldest first.
+func (j *journal) sinceSeq(seq uint64) ([]*events.Envelope, error) {
+	var out []*events.Envelope
+
+	err := j.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketKeyEvents).Cursor()
+		for k, v := c.Seek(seqKey(seq + 1)); k != nil; k, v = c.Next() {
+			env, err := decodeEnvelope(v)
+			if err != nil {
+				return err
+			}
+			out = append(out, env)
+		}
+		return nil
+	})
+
+	return out, err
+}
+
+// sinceTime returns envelopes with a timestamp strictly after t, oldest
+// first.
+func (j *journal) sinceTime(t time.Time) ([]*events.Envelope, error) {
+	var out []*events.Envelope
+
+	err := j.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketKeyEvents).ForEach(func(_, v []byte) error {
+			env, err := decodeEnvelope(v)
+			if err != nil {
+				return err
+			}
+			if env.Timestamp.After(t) {
+				out = append(out, env)
+			}
+			return nil
+		})
+	})
+
+	return out, err
+}
+
+func seqKey(seq uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, seq)
+	return b
+}
+
+// journalEnvelope mirrors events.Envelope for encoding: typeurl.Any is an
+// interface, so it cannot be unmarshaled back into directly and is split
+// into its two concrete fields instead.
+type journalEnvelope struct {
+	Timestamp time.Time
+	Namespace string
+	Topic     string
+	TypeURL   string
+	Value     []byte
+}
+
+func encodeEnvelope(env *events.Envelope) ([]byte, error) {
+	return json.Marshal(journalEnvelope{
+		Timestamp: env.Timestamp,
+		Namespace: env.Namespace,
+		Topic:     env.Topic,
+		TypeURL:   env.Event.GetTypeUrl(),
+		Value:     env.Event.GetValue(),
+	})
+}
+
+func decodeEnvelope(data []byte) (*events.Envelope, error) {
+	var je journalEnvelope
+	if err := json.Unmarshal(data, &je); err != nil {
+		return nil, fmt.Errorf("decoding journaled event: %w", err)
+	}
+
+	return &events.Envelope{
+		Timestamp: je.Timestamp,
+		Namespace: je.Namespace,
+		Topic:     je.Topic,
+		Event: &anyEvent{
+			typeURL: je.TypeURL,
+			value:   je.Value,
+		},
+	}, nil
+}
+
+// anyEvent is a minimal typeurl.Any, used to reconstruct envelopes read
+// back from the journal without needing the original Go type registered.
+type anyEvent struct {
+	typeURL string
+	value   []byte
+}
+
+func (a *anyEvent) GetTypeUrl() string { return a.typeURL }
+func (a *anyEvent) GetValue() []byte   { return a.value }
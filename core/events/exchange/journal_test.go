@@ -0,0 +1,110 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package exchange
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/v2/core/events"
+)
+
+func TestJournalAppendAndSinceSeq(t *testing.T) {
+	j, err := openJournal(filepath.Join(t.TempDir(), "events.db"), JournalConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+
+	for _, topic := range []string{"/a", "/b", "/c"} {
+		env := &events.Envelope{Timestamp: time.Now(), Namespace: "ns", Topic: topic, Event: &anyEvent{typeURL: "test", value: []byte(topic)}}
+		if _, err := j.append(env); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	all, err := j.sinceSeq(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(all))
+	}
+
+	rest, err := j.sinceSeq(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rest) != 2 || rest[0].Topic != "/b" || rest[1].Topic != "/c" {
+		t.Fatalf("unexpected events after seq 1: %+v", rest)
+	}
+}
+
+func TestJournalMaxEventsPrunesOldest(t *testing.T) {
+	j, err := openJournal(filepath.Join(t.TempDir(), "events.db"), JournalConfig{MaxEvents: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+
+	for _, topic := range []string{"/a", "/b", "/c"} {
+		env := &events.Envelope{Timestamp: time.Now(), Namespace: "ns", Topic: topic, Event: &anyEvent{typeURL: "test", value: []byte(topic)}}
+		if _, err := j.append(env); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	remaining, err := j.sinceSeq(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 2 {
+		t.Fatalf("expected journal pruned to 2 events, got %d", len(remaining))
+	}
+	if remaining[0].Topic != "/b" || remaining[1].Topic != "/c" {
+		t.Fatalf("expected oldest event pruned, got %+v", remaining)
+	}
+}
+
+func TestJournalRetentionPrunesOldEvents(t *testing.T) {
+	j, err := openJournal(filepath.Join(t.TempDir(), "events.db"), JournalConfig{Retention: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+
+	old := &events.Envelope{Timestamp: time.Now().Add(-time.Hour), Namespace: "ns", Topic: "/old", Event: &anyEvent{typeURL: "test"}}
+	if _, err := j.append(old); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(2 * time.Millisecond)
+
+	fresh := &events.Envelope{Timestamp: time.Now(), Namespace: "ns", Topic: "/fresh", Event: &anyEvent{typeURL: "test"}}
+	if _, err := j.append(fresh); err != nil {
+		t.Fatal(err)
+	}
+
+	remaining, err := j.sinceSeq(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 1 || remaining[0].Topic != "/fresh" {
+		t.Fatalf("expected only the fresh event to remain, got %+v", remaining)
+	}
+}
@@ -20,4 +20,15 @@ const (
 	// AnnotationImageName is an annotation on a Descriptor in an index.json
 	// containing the `Name` value as used by an `Image` struct
 	AnnotationImageName = "io.containerd.image.name"
+
+	// AnnotationDockerReferenceType is the annotation key BuildKit (and other
+	// compatible producers) set on a manifest descriptor referenced from an
+	// index to describe its purpose, rather than being a runnable platform
+	// image. See AttestationManifestReferenceType for the attestation value.
+	AnnotationDockerReferenceType = "vnd.docker.reference.type"
+
+	// AttestationManifestReferenceType is the AnnotationDockerReferenceType
+	// value used on manifests that carry build attestations (e.g. in-toto
+	// provenance or SBOMs) rather than image content for a platform.
+	AttestationManifestReferenceType = "attestation-manifest"
 )
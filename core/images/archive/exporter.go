@@ -501,8 +501,18 @@ func manifestsRecord(ctx context.Context, store content.Provider, manifests map[
 		Layers   []string
 	}, len(manifests))
 
-	var i int
-	for _, m := range manifests {
+	// Iterate in digest order rather than ranging over the map directly so
+	// that manifest.json is byte-identical across runs for the same input.
+	digests := make([]digest.Digest, 0, len(manifests))
+	for d := range manifests {
+		digests = append(digests, d)
+	}
+	sort.Slice(digests, func(i, j int) bool {
+		return digests[i] < digests[j]
+	})
+
+	for i, d := range digests {
+		m := manifests[d]
 		p, err := content.ReadBlob(ctx, store, m.manifest)
 		if err != nil {
 			return tarRecord{}, err
@@ -530,8 +540,6 @@ func manifestsRecord(ctx context.Context, store content.Provider, manifests map[
 
 			mfsts[i].RepoTags = append(mfsts[i].RepoTags, nname)
 		}
-
-		i++
 	}
 
 	b, err := json.Marshal(mfsts)
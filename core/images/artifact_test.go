@@ -0,0 +1,116 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package images
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/containerd/containerd/v2/core/content"
+	"github.com/containerd/errdefs"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// memoryProvider is a minimal content.Provider backed by a digest-keyed
+// in-memory map, enough to resolve a manifest without a real content store.
+type memoryProvider map[digest.Digest][]byte
+
+func (p memoryProvider) ReaderAt(_ context.Context, desc ocispec.Descriptor) (content.ReaderAt, error) {
+	b, ok := p[desc.Digest]
+	if !ok {
+		return nil, fmt.Errorf("blob %s: %w", desc.Digest, errdefs.ErrNotFound)
+	}
+	return &memoryReaderAt{Reader: bytes.NewReader(b), size: int64(len(b))}, nil
+}
+
+type memoryReaderAt struct {
+	*bytes.Reader
+	size int64
+}
+
+func (m *memoryReaderAt) Close() error { return nil }
+func (m *memoryReaderAt) Size() int64  { return m.size }
+
+func (p memoryProvider) add(mediaType string, v interface{}) ocispec.Descriptor {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	dgst := digest.FromBytes(b)
+	p[dgst] = b
+	return ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    dgst,
+		Size:      int64(len(b)),
+	}
+}
+
+func TestIsArtifactOrdinaryImage(t *testing.T) {
+	p := memoryProvider{}
+	configDesc := p.add(ocispec.MediaTypeImageConfig, ocispec.Image{})
+	manifestDesc := p.add(ocispec.MediaTypeImageManifest, ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    configDesc,
+	})
+
+	artifactType, ok, err := IsArtifact(context.Background(), p, manifestDesc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Fatalf("expected an ordinary image not to be an artifact, got type %q", artifactType)
+	}
+}
+
+func TestIsArtifactWithArtifactType(t *testing.T) {
+	p := memoryProvider{}
+	configDesc := p.add(ocispec.MediaTypeEmptyJSON, struct{}{})
+	manifestDesc := p.add(ocispec.MediaTypeImageManifest, ocispec.Manifest{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: "application/vnd.example.model",
+		Config:       configDesc,
+	})
+
+	artifactType, ok, err := IsArtifact(context.Background(), p, manifestDesc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || artifactType != "application/vnd.example.model" {
+		t.Fatalf("expected artifact type %q, got %q (ok=%v)", "application/vnd.example.model", artifactType, ok)
+	}
+}
+
+func TestIsArtifactUnknownConfigType(t *testing.T) {
+	p := memoryProvider{}
+	configDesc := p.add("application/vnd.example.model.weights", struct{}{})
+	manifestDesc := p.add(ocispec.MediaTypeImageManifest, ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    configDesc,
+	})
+
+	artifactType, ok, err := IsArtifact(context.Background(), p, manifestDesc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || artifactType != "application/vnd.example.model.weights" {
+		t.Fatalf("expected artifact type %q, got %q (ok=%v)", "application/vnd.example.model.weights", artifactType, ok)
+	}
+}
@@ -0,0 +1,283 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package bundle exports and imports an OCI layout containing an image
+// together with its locally known referrers (signatures, SBOMs, and other
+// artifacts whose manifest Subject points at the image), so the set can be
+// carried as one unit into an air-gapped environment and checked for
+// completeness on the other side.
+//
+// There is no registry-side OCI Referrers API client in containerd, so
+// referrers here are only ever the ones already present in the local image
+// store (e.g. pulled and tagged by digest beforehand), not ones discovered
+// live from a registry.
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/containerd/containerd/v2/core/content"
+	"github.com/containerd/containerd/v2/core/images"
+	"github.com/containerd/containerd/v2/core/images/archive"
+	"github.com/containerd/errdefs"
+	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// VerificationManifest lists the subject image and referrers that were
+// present at export time, so an importer can confirm completeness: that
+// every descriptor it names actually arrived, not that it matches whatever
+// a registry would serve live.
+type VerificationManifest struct {
+	MediaType string `json:"mediaType"`
+
+	// Subject is the image the bundle was exported for.
+	Subject ocispec.Descriptor `json:"subject"`
+
+	// Referrers are the manifests found in the image store at export time
+	// whose Subject pointed at Subject's digest.
+	Referrers []ocispec.Descriptor `json:"referrers,omitempty"`
+}
+
+// Export writes an OCI layout tar to w containing image, its locally known
+// referrers, and a verification manifest listing both, so the bundle can be
+// checked for completeness by Import on the other end.
+func Export(ctx context.Context, store content.Store, is images.Store, image ocispec.Descriptor, name string, w io.Writer) error {
+	referrers, err := findReferrers(ctx, store, is, image.Digest)
+	if err != nil {
+		return fmt.Errorf("failed to find referrers for %s: %w", image.Digest, err)
+	}
+
+	artifactDesc, err := writeVerificationManifest(ctx, store, image, VerificationManifest{
+		MediaType: images.MediaTypeContainerd1VerificationManifest,
+		Subject:   image,
+		Referrers: referrers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write verification manifest: %w", err)
+	}
+
+	opts := make([]archive.ExportOpt, 0, len(referrers)+2)
+	opts = append(opts, archive.WithManifest(image, name), archive.WithManifest(artifactDesc))
+	for _, r := range referrers {
+		opts = append(opts, archive.WithManifest(r))
+	}
+
+	return archive.Export(ctx, store, w, opts...)
+}
+
+// Import ingests the OCI layout tar produced by Export into store and
+// returns its verification manifest, after confirming that every descriptor
+// it names (the subject image and all referrers) is now present in store.
+func Import(ctx context.Context, store content.Store, r io.Reader) (*VerificationManifest, error) {
+	idxDesc, err := archive.ImportIndex(ctx, store, r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import bundle: %w", err)
+	}
+
+	idxBytes, err := content.ReadBlob(ctx, store, idxDesc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read imported index: %w", err)
+	}
+	var idx ocispec.Index
+	if err := json.Unmarshal(idxBytes, &idx); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal imported index: %w", err)
+	}
+
+	var artifact *ocispec.Descriptor
+	for i, m := range idx.Manifests {
+		if m.ArtifactType == images.MediaTypeContainerd1VerificationManifest {
+			artifact = &idx.Manifests[i]
+			break
+		}
+	}
+	if artifact == nil {
+		return nil, fmt.Errorf("bundle is missing its verification manifest")
+	}
+
+	artifactBytes, err := content.ReadBlob(ctx, store, *artifact)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read verification artifact manifest: %w", err)
+	}
+	var am ocispec.Manifest
+	if err := json.Unmarshal(artifactBytes, &am); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal verification artifact manifest: %w", err)
+	}
+	if len(am.Layers) != 1 {
+		return nil, fmt.Errorf("verification artifact manifest has %d layers, expected 1", len(am.Layers))
+	}
+
+	vmBytes, err := content.ReadBlob(ctx, store, am.Layers[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read verification manifest: %w", err)
+	}
+	var vm VerificationManifest
+	if err := json.Unmarshal(vmBytes, &vm); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal verification manifest: %w", err)
+	}
+
+	wanted := append([]ocispec.Descriptor{vm.Subject}, vm.Referrers...)
+	for _, d := range wanted {
+		if _, err := store.Info(ctx, d.Digest); err != nil {
+			if errdefs.IsNotFound(err) {
+				return &vm, fmt.Errorf("bundle is incomplete: %s is missing from the imported content", d.Digest)
+			}
+			return &vm, err
+		}
+	}
+
+	return &vm, nil
+}
+
+// writeVerificationManifest writes vm as a data blob, then wraps it in an OCI
+// 1.1 artifact manifest (empty config, vm as the sole layer, ArtifactType set
+// to its media type) referring to image via Subject, and writes that manifest
+// too. The wrapping is what makes the verification manifest exportable: the
+// archive exporter only accepts image manifests and indexes as top-level
+// entries, not arbitrary blobs.
+func writeVerificationManifest(ctx context.Context, store content.Ingester, image ocispec.Descriptor, vm VerificationManifest) (ocispec.Descriptor, error) {
+	dataDesc, err := writeJSONBlob(ctx, store, images.MediaTypeContainerd1VerificationManifest, vm)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to write verification data: %w", err)
+	}
+
+	if err := content.WriteBlob(ctx, store, "verification-config-"+ocispec.DescriptorEmptyJSON.Digest.String(),
+		bytes.NewReader(ocispec.DescriptorEmptyJSON.Data), ocispec.DescriptorEmptyJSON); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to write verification manifest config: %w", err)
+	}
+
+	manifest := ocispec.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: images.MediaTypeContainerd1VerificationManifest,
+		Config:       ocispec.DescriptorEmptyJSON,
+		Layers:       []ocispec.Descriptor{dataDesc},
+		Subject:      &image,
+	}
+
+	desc, err := writeJSONBlob(ctx, store, ocispec.MediaTypeImageManifest, manifest)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	// Set on the descriptor too (not just the manifest content), since
+	// that's what ends up in the exported index.json and is what Import
+	// looks for to find this manifest again.
+	desc.ArtifactType = images.MediaTypeContainerd1VerificationManifest
+
+	return desc, nil
+}
+
+func writeJSONBlob(ctx context.Context, store content.Ingester, mediaType string, v interface{}) (ocispec.Descriptor, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	desc := ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromBytes(b),
+		Size:      int64(len(b)),
+	}
+	if err := content.WriteBlob(ctx, store, "bundle-"+desc.Digest.String(), bytes.NewReader(b), desc); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	return desc, nil
+}
+
+// findReferrers returns the images in is whose manifest or index Subject
+// field points at subject, deduplicated by digest. It never contacts a
+// registry: it only sees referrers that were already pulled and tagged into
+// the local image store.
+func findReferrers(ctx context.Context, provider content.Provider, is images.Store, subject digest.Digest) ([]ocispec.Descriptor, error) {
+	imgs, err := is.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var referrers []ocispec.Descriptor
+	seen := make(map[digest.Digest]struct{})
+	for _, img := range imgs {
+		if img.Target.Digest == subject {
+			continue
+		}
+		if _, ok := seen[img.Target.Digest]; ok {
+			continue
+		}
+
+		refers, err := refersTo(ctx, provider, img.Target, subject)
+		if err != nil {
+			return nil, err
+		}
+		if !refers {
+			continue
+		}
+
+		seen[img.Target.Digest] = struct{}{}
+		referrers = append(referrers, img.Target)
+	}
+
+	return referrers, nil
+}
+
+// refersTo reports whether desc's Subject field, if any, points at subject.
+// Content that is missing from provider or fails to parse is treated as not
+// referring to subject rather than as an error, since it has no bearing on
+// this particular image's referrer set.
+func refersTo(ctx context.Context, provider content.Provider, desc ocispec.Descriptor, subject digest.Digest) (bool, error) {
+	var getSubject func([]byte) (*ocispec.Descriptor, error)
+	switch desc.MediaType {
+	case ocispec.MediaTypeImageManifest, images.MediaTypeDockerSchema2Manifest:
+		getSubject = func(b []byte) (*ocispec.Descriptor, error) {
+			var m ocispec.Manifest
+			if err := json.Unmarshal(b, &m); err != nil {
+				return nil, err
+			}
+			return m.Subject, nil
+		}
+	case ocispec.MediaTypeImageIndex, images.MediaTypeDockerSchema2ManifestList:
+		getSubject = func(b []byte) (*ocispec.Descriptor, error) {
+			var idx ocispec.Index
+			if err := json.Unmarshal(b, &idx); err != nil {
+				return nil, err
+			}
+			return idx.Subject, nil
+		}
+	default:
+		return false, nil
+	}
+
+	b, err := content.ReadBlob(ctx, provider, desc)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	sub, err := getSubject(b)
+	if err != nil {
+		return false, nil
+	}
+
+	return sub != nil && sub.Digest == subject, nil
+}
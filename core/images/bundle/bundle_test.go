@@ -0,0 +1,143 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/containerd/containerd/v2/core/images"
+	"github.com/containerd/containerd/v2/core/images/imagetest"
+	"github.com/containerd/errdefs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// simpleImageStore is an in-memory images.Store for testing, mirroring the
+// one used in core/transfer/image's tests.
+type simpleImageStore struct {
+	l      sync.Mutex
+	images map[string]images.Image
+}
+
+func newSimpleImageStore() images.Store {
+	return &simpleImageStore{images: make(map[string]images.Image)}
+}
+
+func (is *simpleImageStore) Get(ctx context.Context, name string) (images.Image, error) {
+	is.l.Lock()
+	defer is.l.Unlock()
+	img, ok := is.images[name]
+	if !ok {
+		return images.Image{}, errdefs.ErrNotFound
+	}
+	return img, nil
+}
+
+func (is *simpleImageStore) List(ctx context.Context, filters ...string) ([]images.Image, error) {
+	is.l.Lock()
+	defer is.l.Unlock()
+	var imgs []images.Image
+	for _, img := range is.images {
+		imgs = append(imgs, img)
+	}
+	return imgs, nil
+}
+
+func (is *simpleImageStore) Create(ctx context.Context, image images.Image) (images.Image, error) {
+	is.l.Lock()
+	defer is.l.Unlock()
+	if _, ok := is.images[image.Name]; ok {
+		return images.Image{}, errdefs.ErrAlreadyExists
+	}
+	is.images[image.Name] = image
+	return image, nil
+}
+
+func (is *simpleImageStore) Update(ctx context.Context, image images.Image, fieldpaths ...string) (images.Image, error) {
+	is.l.Lock()
+	defer is.l.Unlock()
+	if _, ok := is.images[image.Name]; !ok {
+		return images.Image{}, errdefs.ErrNotFound
+	}
+	is.images[image.Name] = image
+	return image, nil
+}
+
+func (is *simpleImageStore) Delete(ctx context.Context, name string, opts ...images.DeleteOpt) error {
+	is.l.Lock()
+	defer is.l.Unlock()
+	if _, ok := is.images[name]; !ok {
+		return errdefs.ErrNotFound
+	}
+	delete(is.images, name)
+	return nil
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	cs := imagetest.NewContentStore(ctx, t)
+	is := newSimpleImageStore()
+
+	image := cs.Manifest(cs.RandomBlob(ocispec.MediaTypeImageConfig, 16), cs.RandomBlob(ocispec.MediaTypeImageLayerGzip, 128))
+
+	sigConfig := cs.RandomBlob(ocispec.MediaTypeImageConfig, 16)
+	sigManifest := cs.JSONObject(ocispec.MediaTypeImageManifest, ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    sigConfig.Descriptor,
+		Subject:   &image.Descriptor,
+	})
+	_, err := is.Create(ctx, images.Image{Name: "docker.io/library/signed@" + sigManifest.Descriptor.Digest.String(), Target: sigManifest.Descriptor})
+	require.NoError(t, err)
+
+	unrelated := cs.Manifest(cs.RandomBlob(ocispec.MediaTypeImageConfig, 16), cs.RandomBlob(ocispec.MediaTypeImageLayerGzip, 64))
+	_, err = is.Create(ctx, images.Image{Name: "docker.io/library/unrelated:latest", Target: unrelated.Descriptor})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, Export(ctx, cs.Store, is, image.Descriptor, "docker.io/library/image:latest", &buf))
+
+	vm, err := Import(ctx, cs.Store, &buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, image.Descriptor, vm.Subject)
+	require.Len(t, vm.Referrers, 1)
+	assert.Equal(t, sigManifest.Descriptor, vm.Referrers[0])
+}
+
+func TestImportRejectsIncompleteBundle(t *testing.T) {
+	ctx := context.Background()
+	src := imagetest.NewContentStore(ctx, t)
+	is := newSimpleImageStore()
+
+	image := src.Manifest(src.RandomBlob(ocispec.MediaTypeImageConfig, 16), src.RandomBlob(ocispec.MediaTypeImageLayerGzip, 128))
+
+	var buf bytes.Buffer
+	require.NoError(t, Export(ctx, src.Store, is, image.Descriptor, "docker.io/library/image:latest", &buf))
+
+	// Corrupt the bundle by truncating it partway through, so the imported
+	// index (if any) will reference blobs that never actually arrived.
+	truncated := bytes.NewReader(buf.Bytes()[:buf.Len()/2])
+
+	dst := imagetest.NewContentStore(ctx, t)
+	_, err := Import(ctx, dst.Store, truncated)
+	assert.Error(t, err)
+}
@@ -267,6 +267,29 @@ func Config(ctx context.Context, provider content.Provider, image ocispec.Descri
 	return manifest.Config, nil
 }
 
+// IsArtifact returns the OCI artifact type of image's root manifest and true,
+// or "" and false if image is an ordinary container image rather than an
+// artifact.
+//
+// Per the OCI 1.1 image-spec, an artifact is identified either by the
+// manifest's artifactType field, or, failing that, by its config descriptor
+// carrying a media type containerd doesn't recognize as an image or
+// checkpoint config; manifests produced by normal image build tooling always
+// have a known config type, so they are never mistaken for artifacts here.
+func IsArtifact(ctx context.Context, provider content.Provider, image ocispec.Descriptor) (string, bool, error) {
+	manifest, err := Manifest(ctx, provider, image, platforms.All)
+	if err != nil {
+		return "", false, err
+	}
+	if manifest.ArtifactType != "" {
+		return manifest.ArtifactType, true, nil
+	}
+	if !IsKnownConfig(manifest.Config.MediaType) {
+		return manifest.Config.MediaType, true, nil
+	}
+	return "", false, nil
+}
+
 // Platforms returns one or more platforms supported by the image.
 func Platforms(ctx context.Context, provider content.Provider, image ocispec.Descriptor) ([]ocispec.Platform, error) {
 	var platformSpecs []ocispec.Platform
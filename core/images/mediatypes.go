@@ -61,6 +61,13 @@ const (
 
 	// In-toto attestation
 	MediaTypeInToto = "application/vnd.in-toto+json"
+
+	// MediaTypeContainerd1VerificationManifest is the media type of the data
+	// blob the bundle package adds to an exported OCI layout, listing the
+	// bundle's subject image and referrers so an importer can confirm none
+	// of them were dropped in transit. It is also used as the ArtifactType
+	// of the OCI 1.1 artifact manifest that wraps that blob.
+	MediaTypeContainerd1VerificationManifest = "application/vnd.containerd.verification.manifest.v1+json"
 )
 
 // DiffCompression returns the compression as defined by the layer diff media
@@ -206,6 +213,13 @@ func IsAttestationType(mt string) bool {
 	}
 }
 
+// IsAttestationManifest returns true if the descriptor is a manifest
+// referenced from an index purely to carry build attestations (e.g. BuildKit
+// SBOM/provenance), as opposed to a manifest for a runnable platform image.
+func IsAttestationManifest(desc ocispec.Descriptor) bool {
+	return desc.Annotations[AnnotationDockerReferenceType] == AttestationManifestReferenceType
+}
+
 // ChildGCLabels returns the label for a given descriptor to reference it
 func ChildGCLabels(desc ocispec.Descriptor) []string {
 	mt := desc.MediaType
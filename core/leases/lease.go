@@ -35,6 +35,13 @@ type Manager interface {
 	AddResource(context.Context, Lease, Resource) error
 	DeleteResource(context.Context, Lease, Resource) error
 	ListResources(context.Context, Lease) ([]Resource, error)
+
+	// Update updates the labels on an existing lease, such as extending its
+	// "containerd.io/gc.expire" label to renew it. Only the fields named by
+	// fieldpaths are changed; with no fieldpaths, all labels are replaced.
+	// Not every Manager can reach a live lease to update it this way: see
+	// the implementation this Manager was obtained from for the details.
+	Update(context.Context, Lease, ...string) (Lease, error)
 }
 
 // Lease retains resources to prevent cleanup before
@@ -102,3 +109,25 @@ func WithExpiration(d time.Duration) Opt {
 		return nil
 	}
 }
+
+// LabelOwner is the well-known label recording who or what created a lease,
+// e.g. a component name or a client identity. It is set by WithOwner and
+// read back by callers (such as "ctr leases list") that want to attribute
+// a lease to something actionable.
+const LabelOwner = "containerd.io/lease.owner"
+
+// LabelPurpose is the well-known label recording why a lease was created,
+// e.g. "image-pull" or "checkpoint". It is set by WithPurpose.
+const LabelPurpose = "containerd.io/lease.purpose"
+
+// WithOwner sets the well-known owner label on a lease, identifying who or
+// what is holding it.
+func WithOwner(owner string) Opt {
+	return WithLabel(LabelOwner, owner)
+}
+
+// WithPurpose sets the well-known purpose label on a lease, describing why
+// it was created.
+func WithPurpose(purpose string) Opt {
+	return WithLabel(LabelPurpose, purpose)
+}
@@ -18,8 +18,10 @@ package proxy
 
 import (
 	"context"
+	"fmt"
 
 	leasesapi "github.com/containerd/containerd/api/services/leases/v1"
+	"github.com/containerd/errdefs"
 	"github.com/containerd/errdefs/pkg/errgrpc"
 
 	"github.com/containerd/containerd/v2/core/leases"
@@ -116,6 +118,14 @@ func (pm *proxyManager) DeleteResource(ctx context.Context, lease leases.Lease,
 	return errgrpc.ToNative(err)
 }
 
+// Update is not implemented: the leases v1 gRPC service has no RPC for it,
+// so there is no request this proxy could send the daemon. Renewing a
+// lease's expiration remotely today means deleting and recreating it with
+// leases.WithExpiration.
+func (pm *proxyManager) Update(ctx context.Context, lease leases.Lease, fieldpaths ...string) (leases.Lease, error) {
+	return leases.Lease{}, fmt.Errorf("updating a lease over the leases v1 grpc service: %w", errdefs.ErrNotImplemented)
+}
+
 func (pm *proxyManager) ListResources(ctx context.Context, lease leases.Lease) ([]leases.Resource, error) {
 	resp, err := pm.client.ListResources(ctx, &leasesapi.ListResourcesRequest{
 		ID: lease.ID,
@@ -0,0 +1,38 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package leases
+
+import (
+	"context"
+	"time"
+)
+
+// Renew extends an existing lease's expiration by d from now, by rewriting
+// its "containerd.io/gc.expire" label through Update. It is meant for
+// long-lived holders of a lease (a running task, an in-progress pull) that
+// want to keep renewing it a little at a time instead of creating it with
+// one large WithExpiration upfront, so a holder that disappears stops
+// renewing and the lease still expires on its own.
+//
+// Renew requires a Manager that can reach a live lease to update, which is
+// not true of every Manager implementation; see Update.
+func Renew(ctx context.Context, lm Manager, lease Lease, d time.Duration) (Lease, error) {
+	lease.Labels = map[string]string{
+		"containerd.io/gc.expire": time.Now().Add(d).Format(time.RFC3339),
+	}
+	return lm.Update(ctx, lease, "labels.containerd.io/gc.expire")
+}
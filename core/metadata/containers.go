@@ -19,6 +19,7 @@ package metadata
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -136,6 +137,10 @@ func (s *containerStore) Create(ctx context.Context, container containers.Contai
 	}
 
 	if err := update(ctx, s.db, func(tx *bolt.Tx) error {
+		if err := checkContainerLimit(tx, namespace); err != nil {
+			return err
+		}
+
 		bkt, err := createContainersBucket(tx, namespace)
 		if err != nil {
 			return err
@@ -306,6 +311,47 @@ func (s *containerStore) Delete(ctx context.Context, id string) error {
 	})
 }
 
+// checkContainerLimit enforces labels.LabelMaxContainers, if the namespace
+// has it set, by counting the containers that already exist in it. It must
+// run inside the same update transaction as the create it is guarding,
+// so the count and the new container's bucket creation are atomic.
+func checkContainerLimit(tx *bolt.Tx, namespace string) error {
+	lbkt := getNamespaceLabelsBucket(tx, namespace)
+	if lbkt == nil {
+		return nil
+	}
+
+	v := lbkt.Get([]byte(labels.LabelMaxContainers))
+	if v == nil {
+		return nil
+	}
+
+	limit, err := strconv.Atoi(string(v))
+	if err != nil {
+		return fmt.Errorf("invalid %s label %q: %w", labels.LabelMaxContainers, string(v), errdefs.ErrInvalidArgument)
+	}
+
+	bkt := getContainersBucket(tx, namespace)
+	if bkt == nil {
+		return nil
+	}
+
+	var count int
+	if err := bkt.ForEach(func(_, v []byte) error {
+		if v == nil {
+			count++
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if count >= limit {
+		return fmt.Errorf("namespace %q already has %d containers, at its %s limit of %d: %w", namespace, count, labels.LabelMaxContainers, limit, errdefs.ErrResourceExhausted)
+	}
+
+	return nil
+}
+
 func validateContainer(container *containers.Container) error {
 	if err := identifiers.Validate(container.ID); err != nil {
 		return fmt.Errorf("container.ID: %w", err)
@@ -27,6 +27,7 @@ import (
 	"time"
 
 	eventstypes "github.com/containerd/containerd/api/events"
+	"github.com/containerd/errdefs"
 	"github.com/containerd/log"
 	bolt "go.etcd.io/bbolt"
 
@@ -138,6 +139,117 @@ func NewDB(db Transactor, cs content.Store, ss map[string]snapshots.Snapshotter,
 	return m
 }
 
+// detectSchemaVersion walks the known migrations in reverse to find the
+// schema and version already stored in tx, if any, and the index into
+// migrations of the first one that still needs to run. A fresh database
+// reports schema "v0", version 0, and an index of 0 (run everything).
+func detectSchemaVersion(tx *bolt.Tx) (schema string, version int, pendingIdx int) {
+	schema = "v0"
+
+	// i represents the index of the first migration
+	// which must be run to get the database up to date.
+	// The migration's version will be checked in reverse
+	// order, decrementing i for each migration which
+	// represents a version newer than the current
+	// database version
+	i := len(migrations)
+
+	for ; i > 0; i-- {
+		migration := migrations[i-1]
+
+		bkt := tx.Bucket([]byte(migration.schema))
+		if bkt == nil {
+			// Hasn't encountered another schema, go to next migration
+			if schema == "v0" {
+				continue
+			}
+			break
+		}
+		if schema == "v0" {
+			schema = migration.schema
+			vb := bkt.Get(bucketKeyDBVersion)
+			if vb != nil {
+				v, _ := binary.Varint(vb)
+				version = int(v)
+			}
+		}
+
+		if version >= migration.version {
+			break
+		}
+	}
+
+	return schema, version, i
+}
+
+// MigrationStep describes a single pending schema migration, as reported
+// by DB.Migrations.
+type MigrationStep struct {
+	Schema      string
+	Version     int
+	Description string
+}
+
+// MigrationReport summarizes what Init would do (or did) to bring the
+// metadata store up to the schema and version this binary supports.
+type MigrationReport struct {
+	// FromSchema and FromVersion are what was already stored in the
+	// database, or "v0"/0 for a freshly created one.
+	FromSchema  string
+	FromVersion int
+	// ToSchema and ToVersion are this binary's own schema and version.
+	ToSchema  string
+	ToVersion int
+	// Pending lists the migrations that still need to run, in the order
+	// they would run in.
+	Pending []MigrationStep
+	// Downgrade is true when FromVersion is newer than ToVersion, i.e.
+	// the database was last written by a newer containerd than this one.
+	// Init refuses to proceed when this is true rather than silently
+	// rewriting the stored version and risking corrupting metadata this
+	// binary doesn't know how to read.
+	Downgrade bool
+}
+
+// Migrations reports what Init would do to the metadata store without
+// changing anything, by running the same schema detection Init does
+// inside a read-only transaction. It is meant for a "--dry-run" style
+// check before an upgrade or downgrade: it never calls a migration's
+// migrate function, so it cannot commit a partial migration, and a View
+// transaction makes any accidental write fail loudly rather than silently
+// persist.
+func (m *DB) Migrations(ctx context.Context) (MigrationReport, error) {
+	var report MigrationReport
+
+	if err := m.db.View(func(tx *bolt.Tx) error {
+		schema, version, i := detectSchemaVersion(tx)
+
+		report = MigrationReport{
+			FromSchema:  schema,
+			FromVersion: version,
+			ToSchema:    schemaVersion,
+			ToVersion:   dbVersion,
+			Downgrade:   schema != "v0" && version > dbVersion,
+		}
+
+		if schema != "v0" && !report.Downgrade {
+			for _, mg := range migrations[i:] {
+				report.Pending = append(report.Pending, MigrationStep{
+					Schema:      mg.schema,
+					Version:     mg.version,
+					Description: mg.description,
+				})
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return MigrationReport{}, err
+	}
+
+	return report, nil
+}
+
 // Init ensures the database is at the correct version
 // and performs any needed migrations.
 func (m *DB) Init(ctx context.Context) error {
@@ -147,47 +259,14 @@ func (m *DB) Init(ctx context.Context) error {
 	var errSkip = errors.New("skip update")
 
 	err := m.db.Update(func(tx *bolt.Tx) error {
-		var (
-			// current schema and version
-			schema  = "v0"
-			version = 0
-		)
-
-		// i represents the index of the first migration
-		// which must be run to get the database up to date.
-		// The migration's version will be checked in reverse
-		// order, decrementing i for each migration which
-		// represents a version newer than the current
-		// database version
-		i := len(migrations)
-
-		for ; i > 0; i-- {
-			migration := migrations[i-1]
-
-			bkt := tx.Bucket([]byte(migration.schema))
-			if bkt == nil {
-				// Hasn't encountered another schema, go to next migration
-				if schema == "v0" {
-					continue
-				}
-				break
-			}
-			if schema == "v0" {
-				schema = migration.schema
-				vb := bkt.Get(bucketKeyDBVersion)
-				if vb != nil {
-					v, _ := binary.Varint(vb)
-					version = int(v)
-				}
-			}
-
-			if version >= migration.version {
-				break
-			}
-		}
+		schema, version, i := detectSchemaVersion(tx)
 
 		// Previous version of database found
 		if schema != "v0" {
+			if version > dbVersion {
+				return fmt.Errorf("database was last written by a newer containerd (schema %s, version %d; this binary supports up to %s.%d): refusing to downgrade and risk corrupting metadata: %w", schema, version, schemaVersion, dbVersion, errdefs.ErrFailedPrecondition)
+			}
+
 			updates := migrations[i:]
 
 			// No migration updates, return immediately
@@ -101,6 +101,75 @@ func (lm *leaseManager) Create(ctx context.Context, opts ...leases.Opt) (leases.
 	return l, nil
 }
 
+// Update updates the labels on an existing lease. With no fieldpaths, all
+// labels are replaced with the ones on the provided lease; a "labels."
+// prefixed fieldpath updates just that one label, leaving the rest alone,
+// mirroring imageStore.Update.
+func (lm *leaseManager) Update(ctx context.Context, lease leases.Lease, fieldpaths ...string) (leases.Lease, error) {
+	namespace, err := namespaces.NamespaceRequired(ctx)
+	if err != nil {
+		return leases.Lease{}, err
+	}
+
+	if lease.ID == "" {
+		return leases.Lease{}, errors.New("lease id must be provided")
+	}
+
+	var updated leases.Lease
+
+	if err := update(ctx, lm.db, func(tx *bolt.Tx) error {
+		topbkt := getBucket(tx, bucketKeyVersion, []byte(namespace), bucketKeyObjectLeases)
+		if topbkt == nil {
+			return fmt.Errorf("lease %q: %w", lease.ID, errdefs.ErrNotFound)
+		}
+
+		txbkt := topbkt.Bucket([]byte(lease.ID))
+		if txbkt == nil {
+			return fmt.Errorf("lease %q: %w", lease.ID, errdefs.ErrNotFound)
+		}
+
+		updated.ID = lease.ID
+		if v := txbkt.Get(bucketKeyCreatedAt); v != nil {
+			if err := updated.CreatedAt.UnmarshalBinary(v); err != nil {
+				return err
+			}
+		}
+
+		labels, err := boltutil.ReadLabels(txbkt)
+		if err != nil {
+			return err
+		}
+		updated.Labels = labels
+
+		if len(fieldpaths) > 0 {
+			for _, path := range fieldpaths {
+				if strings.HasPrefix(path, "labels.") {
+					if updated.Labels == nil {
+						updated.Labels = map[string]string{}
+					}
+
+					key := strings.TrimPrefix(path, "labels.")
+					updated.Labels[key] = lease.Labels[key]
+					continue
+				}
+
+				if path != "labels" {
+					return fmt.Errorf("cannot update %q field on lease %q: %w", path, lease.ID, errdefs.ErrInvalidArgument)
+				}
+				updated.Labels = lease.Labels
+			}
+		} else {
+			updated.Labels = lease.Labels
+		}
+
+		return boltutil.WriteLabels(txbkt, updated.Labels)
+	}); err != nil {
+		return leases.Lease{}, err
+	}
+
+	return updated, nil
+}
+
 // Delete deletes the lease with the provided lease ID
 func (lm *leaseManager) Delete(ctx context.Context, lease leases.Lease, _ ...leases.DeleteOpt) error {
 	namespace, err := namespaces.NamespaceRequired(ctx)
@@ -26,7 +26,11 @@ import (
 type migration struct {
 	schema  string
 	version int
-	migrate func(*bolt.Tx) error
+	// description is a short, human-readable summary of what the
+	// migration does, surfaced in the dry-run migration report so an
+	// operator can see what an upgrade would change before it runs.
+	description string
+	migrate     func(*bolt.Tx) error
 }
 
 // migrations stores the list of database migrations
@@ -41,24 +45,28 @@ type migration struct {
 // of the database.
 var migrations = []migration{
 	{
-		schema:  "v1",
-		version: 1,
-		migrate: addChildLinks,
+		schema:      "v1",
+		version:     1,
+		description: "add children links to snapshots for consistency checks",
+		migrate:     addChildLinks,
 	},
 	{
-		schema:  "v1",
-		version: 2,
-		migrate: migrateIngests,
+		schema:      "v1",
+		version:     2,
+		description: "move ingests to a structured ingest bucket",
+		migrate:     migrateIngests,
 	},
 	{
-		schema:  "v1",
-		version: 3,
-		migrate: noOpMigration,
+		schema:      "v1",
+		version:     3,
+		description: "no-op migration to align version numbers",
+		migrate:     noOpMigration,
 	},
 	{
-		schema:  "v1",
-		version: 4,
-		migrate: migrateSandboxes,
+		schema:      "v1",
+		version:     4,
+		description: "add the sandboxes bucket",
+		migrate:     migrateSandboxes,
 	},
 }
 
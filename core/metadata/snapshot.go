@@ -666,6 +666,191 @@ func (s *snapshotter) Commit(ctx context.Context, name, key string, opts ...snap
 
 }
 
+// BatchCommitItem describes a single commit to perform as part of a
+// BatchCommit call.
+type BatchCommitItem struct {
+	// Name is the name of the committed snapshot, as would be passed as
+	// the first argument to Commit.
+	Name string
+	// Key is the active snapshot key being committed, as would be passed
+	// as the second argument to Commit.
+	Key string
+	// Opts are applied the same way as Commit's own opts.
+	Opts []snapshots.Opt
+}
+
+// BatchCommit commits every item in items inside a single metadata
+// transaction instead of one transaction per commit. It is meant for
+// callers, such as an image unpacker, committing many independent
+// snapshots in quick succession: bolt serializes all writers on a single
+// lock, so the dominant cost of committing N snapshots one at a time
+// during a large multi-layer unpack is the N-1 extra lock round trips,
+// not the backend snapshotter calls themselves.
+//
+// Each item is committed independently of the others: one item failing
+// does not prevent the rest of the batch from committing, and does not
+// roll back the transaction. The returned slice has one entry per item,
+// in the same order as items, nil for an item that committed
+// successfully.
+func (s *snapshotter) BatchCommit(ctx context.Context, items []BatchCommitItem) ([]error, error) {
+	s.l.RLock()
+	defer s.l.RUnlock()
+
+	ns, err := namespaces.NamespaceRequired(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make([]error, len(items))
+	committed := make([]bool, len(items))
+
+	if err := update(ctx, s.db, func(tx *bolt.Tx) error {
+		bkt := getSnapshotterBucket(tx, ns, s.name)
+		if bkt == nil {
+			return fmt.Errorf("can not find snapshotter %q: %w", s.name, errdefs.ErrNotFound)
+		}
+
+		for i, item := range items {
+			nameKey, bkey, labels, ferr := commitBucketEntry(ctx, tx, bkt, ns, s.name, item.Name, item.Key, item.Opts)
+			if ferr != nil {
+				errs[i] = ferr
+				continue
+			}
+
+			inheritedOpt := snapshots.WithLabels(snapshots.FilterInheritedLabels(labels))
+			if cerr := s.Snapshotter.Commit(ctx, nameKey, bkey, inheritedOpt); cerr != nil {
+				if errdefs.IsNotFound(cerr) {
+					log.G(ctx).WithField("snapshotter", s.name).WithField("key", item.Key).WithError(cerr).Error("uncommittable snapshot: missing in backend, snapshot should be removed")
+				}
+				errs[i] = cerr
+				continue
+			}
+
+			committed[i] = true
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if publisher := s.db.Publisher(ctx); publisher != nil {
+		for i, item := range items {
+			if !committed[i] {
+				continue
+			}
+			if err := publisher.Publish(ctx, "/snapshot/commit", &eventstypes.SnapshotCommit{
+				Key:         item.Key,
+				Name:        item.Name,
+				Snapshotter: s.name,
+			}); err != nil {
+				errs[i] = err
+			}
+		}
+	}
+
+	return errs, nil
+}
+
+// commitBucketEntry performs the metadata bookkeeping for a single commit
+// of key to name inside tx, returning the backend key pair (nameKey, bkey)
+// and the inheritable labels the caller must still commit on the backend
+// Snapshotter, or an error if the item itself is invalid, independent of
+// the rest of a batch. It is shared between Commit and BatchCommit so the
+// bucket layout only needs to be maintained in one place.
+func commitBucketEntry(ctx context.Context, tx *bolt.Tx, bkt *bolt.Bucket, ns, snapshotterName, name, key string, opts []snapshots.Opt) (nameKey, bkey string, labels map[string]string, err error) {
+	var base snapshots.Info
+	for _, opt := range opts {
+		if err := opt(&base); err != nil {
+			return "", "", nil, err
+		}
+	}
+
+	if err := validateSnapshot(&base); err != nil {
+		return "", "", nil, err
+	}
+
+	if err := addSnapshotLease(ctx, tx, snapshotterName, name); err != nil {
+		return "", "", nil, err
+	}
+
+	bbkt, err := bkt.CreateBucket([]byte(name))
+	if err != nil {
+		if err == errbolt.ErrBucketExists {
+			return "", "", nil, fmt.Errorf("snapshot %q: %w", name, errdefs.ErrAlreadyExists)
+		}
+		return "", "", nil, err
+	}
+
+	obkt := bkt.Bucket([]byte(key))
+	if obkt == nil {
+		if derr := bkt.DeleteBucket([]byte(name)); derr != nil {
+			return "", "", nil, derr
+		}
+		if derr := removeSnapshotLease(ctx, tx, snapshotterName, name); derr != nil {
+			return "", "", nil, derr
+		}
+		return "", "", nil, fmt.Errorf("snapshot %v does not exist: %w", key, errdefs.ErrNotFound)
+	}
+
+	bkey = string(obkt.Get(bucketKeyName))
+
+	sid, err := bkt.NextSequence()
+	if err != nil {
+		return "", "", nil, err
+	}
+	nameKey = createKey(sid, ns, name)
+
+	if err := bbkt.Put(bucketKeyName, []byte(nameKey)); err != nil {
+		return "", "", nil, err
+	}
+
+	parent := obkt.Get(bucketKeyParent)
+	if len(parent) > 0 {
+		pbkt := bkt.Bucket(parent)
+		if pbkt == nil {
+			if derr := bkt.DeleteBucket([]byte(name)); derr != nil {
+				return "", "", nil, derr
+			}
+			if derr := removeSnapshotLease(ctx, tx, snapshotterName, name); derr != nil {
+				return "", "", nil, derr
+			}
+			return "", "", nil, fmt.Errorf("parent snapshot %v does not exist: %w", string(parent), errdefs.ErrNotFound)
+		}
+
+		cbkt, err := pbkt.CreateBucketIfNotExists(bucketKeyChildren)
+		if err != nil {
+			return "", "", nil, err
+		}
+		if err := cbkt.Delete([]byte(key)); err != nil {
+			return "", "", nil, err
+		}
+		if err := cbkt.Put([]byte(name), nil); err != nil {
+			return "", "", nil, err
+		}
+		if err := bbkt.Put(bucketKeyParent, parent); err != nil {
+			return "", "", nil, err
+		}
+	}
+
+	ts := time.Now().UTC()
+	if err := boltutil.WriteTimestamps(bbkt, ts, ts); err != nil {
+		return "", "", nil, err
+	}
+	if err := boltutil.WriteLabels(bbkt, base.Labels); err != nil {
+		return "", "", nil, err
+	}
+
+	if err := bkt.DeleteBucket([]byte(key)); err != nil {
+		return "", "", nil, err
+	}
+	if err := removeSnapshotLease(ctx, tx, snapshotterName, key); err != nil {
+		return "", "", nil, err
+	}
+
+	return nameKey, bkey, base.Labels, nil
+}
+
 func (s *snapshotter) Remove(ctx context.Context, key string) error {
 	s.l.RLock()
 	defer s.l.RUnlock()
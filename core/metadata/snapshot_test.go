@@ -262,6 +262,94 @@ func TestSnapshotterWithRef(t *testing.T) {
 	}
 }
 
+func TestBatchCommit(t *testing.T) {
+	ctx, db := testDB(t, withSnapshotter("tmp", func(string) (snapshots.Snapshotter, error) {
+		return NewTmpSnapshotter(), nil
+	}))
+	snapshotterName := "tmp"
+	ctx, leased := snapshotLease(ctx, t, db, snapshotterName)
+	sn, ok := db.Snapshotter(snapshotterName).(*snapshotter)
+	if !ok {
+		t.Fatal("expected snapshotter implementation to support BatchCommit")
+	}
+
+	active := func(key string) string {
+		return key + "-active"
+	}
+
+	items := make([]BatchCommitItem, 3)
+	for i := range items {
+		key := fmt.Sprintf("committed%d", i)
+		opt := snapshots.WithLabels(map[string]string{labelSnapshotRef: key})
+		if _, err := sn.Prepare(ctx, active(key), "", opt); err != nil {
+			t.Fatal(err)
+		}
+		items[i] = BatchCommitItem{Name: key, Key: active(key), Opts: []snapshots.Opt{opt}}
+	}
+
+	errs, err := sn.BatchCommit(ctx, items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, item := range items {
+		if errs[i] != nil {
+			t.Fatalf("unexpected error committing %q: %v", item.Name, errs[i])
+		}
+		if _, err := sn.Stat(ctx, item.Name); err != nil {
+			t.Fatalf("committed snapshot %q not found: %v", item.Name, err)
+		}
+		if !leased(item.Name) {
+			t.Errorf("no lease for %q", item.Name)
+		}
+		if leased(item.Key) {
+			t.Errorf("lease for %q should be removed after commit", item.Key)
+		}
+	}
+}
+
+func TestBatchCommitPartialFailure(t *testing.T) {
+	ctx, db := testDB(t, withSnapshotter("tmp", func(string) (snapshots.Snapshotter, error) {
+		return NewTmpSnapshotter(), nil
+	}))
+	snapshotterName := "tmp"
+	ctx, leased := snapshotLease(ctx, t, db, snapshotterName)
+	sn, ok := db.Snapshotter(snapshotterName).(*snapshotter)
+	if !ok {
+		t.Fatal("expected snapshotter implementation to support BatchCommit")
+	}
+
+	okOpt := snapshots.WithLabels(map[string]string{labelSnapshotRef: "ok"})
+	if _, err := sn.Prepare(ctx, "ok-active", "", okOpt); err != nil {
+		t.Fatal(err)
+	}
+
+	items := []BatchCommitItem{
+		{Name: "ok", Key: "ok-active", Opts: []snapshots.Opt{okOpt}},
+		{Name: "missing", Key: "does-not-exist"},
+	}
+
+	errs, err := sn.BatchCommit(ctx, items)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if errs[0] != nil {
+		t.Fatalf("unexpected error committing %q: %v", items[0].Name, errs[0])
+	}
+	if _, err := sn.Stat(ctx, "ok"); err != nil {
+		t.Fatalf("committed snapshot %q not found: %v", items[0].Name, err)
+	}
+
+	if errs[1] == nil {
+		t.Fatal("expected an error committing a non-existent key")
+	} else if !errdefs.IsNotFound(errs[1]) {
+		t.Fatalf("expected not found error, got %v", errs[1])
+	}
+	if leased("missing") {
+		t.Errorf("lease for %q should not have been kept after failed commit", "missing")
+	}
+}
+
 func TestFilterInheritedLabels(t *testing.T) {
 	tests := []struct {
 		labels   map[string]string
@@ -24,7 +24,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-var cpuMetrics = []*metric{
+var cpuMetrics = append([]*metric{
 	{
 		name: "cpu_usage_usec",
 		help: "Total cpu usage (cgroup v2)",
@@ -121,4 +121,9 @@ var cpuMetrics = []*metric{
 			}
 		},
 	},
-}
+}, psiMetrics("cpu", func(stats *v2.Metrics) *v2.PSIStats {
+	if stats.CPU == nil {
+		return nil
+	}
+	return stats.CPU.PSI
+})...)
@@ -26,7 +26,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-var ioMetrics = []*metric{
+var ioMetrics = append([]*metric{
 	{
 		name:   "io_rbytes",
 		help:   "IO bytes read",
@@ -107,4 +107,9 @@ var ioMetrics = []*metric{
 			return out
 		},
 	},
-}
+}, psiMetrics("io", func(stats *v2.Metrics) *v2.PSIStats {
+	if stats.Io == nil {
+		return nil
+	}
+	return stats.Io.PSI
+})...)
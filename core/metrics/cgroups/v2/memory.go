@@ -24,7 +24,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-var memoryMetrics = []*metric{
+var memoryMetrics = append([]*metric{
 	{
 		name: "memory_usage",
 		help: "Current memory usage (cgroup v2)",
@@ -602,4 +602,9 @@ var memoryMetrics = []*metric{
 			}
 		},
 	},
-}
+}, psiMetrics("memory", func(stats *v2.Metrics) *v2.PSIStats {
+	if stats.Memory == nil {
+		return nil
+	}
+	return stats.Memory.PSI
+})...)
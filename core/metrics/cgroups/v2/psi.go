@@ -0,0 +1,98 @@
+//go:build linux
+
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package v2
+
+import (
+	v2 "github.com/containerd/containerd/v2/core/metrics/types/v2"
+	metrics "github.com/docker/go-metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// psiMetrics builds the set of PSI (pressure stall information) metrics
+// shared by the cpu, memory and io resources: some/full averages over the
+// last 10s/60s/300s, and the cumulative stalled time in microseconds.
+func psiMetrics(resource string, get func(stats *v2.Metrics) *v2.PSIStats) []*metric {
+	kinds := []struct {
+		name string
+		get  func(*v2.PSIStats) *v2.PSIData
+	}{
+		{"some", func(p *v2.PSIStats) *v2.PSIData { return p.Some }},
+		{"full", func(p *v2.PSIStats) *v2.PSIData { return p.Full }},
+	}
+
+	var out []*metric
+	for _, kind := range kinds {
+		kind := kind
+		out = append(out,
+			&metric{
+				name: resource + "_psi_" + kind.name + "_avg10",
+				help: "Share of time some/all tasks were stalled on " + resource + ", 10s average (cgroup v2)",
+				unit: metrics.Unit("percent"),
+				vt:   prometheus.GaugeValue,
+				getValues: func(stats *v2.Metrics) []value {
+					psi := get(stats)
+					if psi == nil || kind.get(psi) == nil {
+						return nil
+					}
+					return []value{{v: kind.get(psi).Avg10}}
+				},
+			},
+			&metric{
+				name: resource + "_psi_" + kind.name + "_avg60",
+				help: "Share of time some/all tasks were stalled on " + resource + ", 60s average (cgroup v2)",
+				unit: metrics.Unit("percent"),
+				vt:   prometheus.GaugeValue,
+				getValues: func(stats *v2.Metrics) []value {
+					psi := get(stats)
+					if psi == nil || kind.get(psi) == nil {
+						return nil
+					}
+					return []value{{v: kind.get(psi).Avg60}}
+				},
+			},
+			&metric{
+				name: resource + "_psi_" + kind.name + "_avg300",
+				help: "Share of time some/all tasks were stalled on " + resource + ", 300s average (cgroup v2)",
+				unit: metrics.Unit("percent"),
+				vt:   prometheus.GaugeValue,
+				getValues: func(stats *v2.Metrics) []value {
+					psi := get(stats)
+					if psi == nil || kind.get(psi) == nil {
+						return nil
+					}
+					return []value{{v: kind.get(psi).Avg300}}
+				},
+			},
+			&metric{
+				name: resource + "_psi_" + kind.name + "_total",
+				help: "Total time some/all tasks have been stalled on " + resource + " (cgroup v2)",
+				unit: metrics.Unit("microseconds"),
+				vt:   prometheus.GaugeValue,
+				getValues: func(stats *v2.Metrics) []value {
+					psi := get(stats)
+					if psi == nil || kind.get(psi) == nil {
+						return nil
+					}
+					return []value{{v: float64(kind.get(psi).Total)}}
+				},
+			},
+		)
+	}
+	return out
+}
@@ -33,4 +33,8 @@ type (
 	PidsStat = v2.PidsStat
 	// IOStat alias
 	IOStat = v2.IOStat
+	// PSIStats alias
+	PSIStats = v2.PSIStats
+	// PSIData alias
+	PSIData = v2.PSIData
 )
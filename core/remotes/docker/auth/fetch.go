@@ -75,6 +75,11 @@ type TokenOptions struct {
 	Username string
 	Secret   string
 
+	// Namespace is the upstream namespace to request the token for, set when
+	// talking to a pull-through/proxy registry distinguishing upstreams via
+	// the "ns" query parameter. Left empty when not proxying.
+	Namespace string
+
 	// FetchRefreshToken enables fetching a refresh token (aka "identity token", "offline token") along with the bearer token.
 	//
 	// For HTTP GET mode (FetchToken), FetchRefreshToken sets `offline_token=true` in the request.
@@ -106,6 +111,9 @@ func FetchTokenWithOAuth(ctx context.Context, client *http.Client, headers http.
 	}
 	form.Set("service", to.Service)
 	form.Set("client_id", clientID)
+	if to.Namespace != "" {
+		form.Set("ns", to.Namespace)
+	}
 
 	if to.Username == "" {
 		form.Set("grant_type", "refresh_token")
@@ -192,6 +200,10 @@ func FetchToken(ctx context.Context, client *http.Client, headers http.Header, t
 		reqParams.Add("scope", scope)
 	}
 
+	if to.Namespace != "" {
+		reqParams.Add("ns", to.Namespace)
+	}
+
 	if to.Secret != "" {
 		req.SetBasicAuth(to.Username, to.Secret)
 	}
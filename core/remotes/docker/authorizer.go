@@ -43,6 +43,10 @@ type dockerAuthorizer struct {
 	handlers map[string]*authHandler
 
 	onFetchRefreshToken OnFetchRefreshToken
+
+	// forceOAuth2 marks hosts that should always use the OAuth2 POST token
+	// flow, set with WithForceOAuth2.
+	forceOAuth2 map[string]bool
 }
 
 type authorizerConfig struct {
@@ -50,6 +54,7 @@ type authorizerConfig struct {
 	client              *http.Client
 	header              http.Header
 	onFetchRefreshToken OnFetchRefreshToken
+	forceOAuth2         map[string]bool
 }
 
 // AuthorizerOpt configures an authorizer
@@ -95,6 +100,23 @@ func WithFetchRefreshToken(f OnFetchRefreshToken) AuthorizerOpt {
 	}
 }
 
+// WithForceOAuth2 marks the given registry hosts as always using the OAuth2
+// POST token flow (auth.FetchTokenWithOAuth), even for anonymous requests
+// that would otherwise use the simpler GET flow. Some registries reject GET
+// /token requests outright with a status doBearerAuth's automatic GET/POST
+// fallback doesn't recognize, so operators who already know a host requires
+// the POST flow can configure it directly instead of relying on detection.
+func WithForceOAuth2(hosts ...string) AuthorizerOpt {
+	return func(opt *authorizerConfig) {
+		if opt.forceOAuth2 == nil {
+			opt.forceOAuth2 = make(map[string]bool, len(hosts))
+		}
+		for _, h := range hosts {
+			opt.forceOAuth2[h] = true
+		}
+	}
+}
+
 // NewDockerAuthorizer creates an authorizer using Docker's registry
 // authentication spec.
 // See https://distribution.github.io/distribution/spec/auth/
@@ -114,6 +136,7 @@ func NewDockerAuthorizer(opts ...AuthorizerOpt) Authorizer {
 		header:              ao.header,
 		handlers:            make(map[string]*authHandler),
 		onFetchRefreshToken: ao.onFetchRefreshToken,
+		forceOAuth2:         ao.forceOAuth2,
 	}
 }
 
@@ -125,7 +148,13 @@ func (a *dockerAuthorizer) Authorize(ctx context.Context, req *http.Request) err
 		return nil
 	}
 
-	auth, refreshToken, err := ah.authorize(ctx)
+	// ns identifies the upstream namespace being requested through a
+	// pull-through/proxy registry (see RegistryHost.isProxy/addNamespace).
+	// Tokens are scoped per upstream, so it must be part of the cache key
+	// and the token request, not just the resource scope.
+	ns := req.URL.Query().Get("ns")
+
+	auth, refreshToken, err := ah.authorize(ctx, ns)
 	if err != nil {
 		return err
 	}
@@ -190,7 +219,7 @@ func (a *dockerAuthorizer) AddResponses(ctx context.Context, responses []*http.R
 			}
 			common.FetchRefreshToken = a.onFetchRefreshToken != nil
 
-			a.handlers[host] = newAuthHandler(a.client, a.header, c.Scheme, common)
+			a.handlers[host] = newAuthHandler(a.client, a.header, c.Scheme, common, a.forceOAuth2[host])
 			return nil
 		} else if c.Scheme == auth.BasicAuth && a.credentials != nil {
 			username, secret, err := a.credentials(host)
@@ -205,7 +234,7 @@ func (a *dockerAuthorizer) AddResponses(ctx context.Context, responses []*http.R
 			a.handlers[host] = newAuthHandler(a.client, a.header, c.Scheme, auth.TokenOptions{
 				Username: username,
 				Secret:   secret,
-			})
+			}, false)
 			return nil
 		}
 	}
@@ -238,24 +267,29 @@ type authHandler struct {
 	// scopedTokens caches token indexed by scopes, which used in
 	// bearer auth case
 	scopedTokens map[string]*authResult
+
+	// forceOAuth2 makes doBearerAuth always use the OAuth2 POST token flow,
+	// set with WithForceOAuth2.
+	forceOAuth2 bool
 }
 
-func newAuthHandler(client *http.Client, hdr http.Header, scheme auth.AuthenticationScheme, opts auth.TokenOptions) *authHandler {
+func newAuthHandler(client *http.Client, hdr http.Header, scheme auth.AuthenticationScheme, opts auth.TokenOptions, forceOAuth2 bool) *authHandler {
 	return &authHandler{
 		header:       hdr,
 		client:       client,
 		scheme:       scheme,
 		common:       opts,
 		scopedTokens: map[string]*authResult{},
+		forceOAuth2:  forceOAuth2,
 	}
 }
 
-func (ah *authHandler) authorize(ctx context.Context) (string, string, error) {
+func (ah *authHandler) authorize(ctx context.Context, ns string) (string, string, error) {
 	switch ah.scheme {
 	case auth.BasicAuth:
 		return ah.doBasicAuth(ctx)
 	case auth.BearerAuth:
-		return ah.doBearerAuth(ctx)
+		return ah.doBearerAuth(ctx, ns)
 	default:
 		return "", "", fmt.Errorf("failed to find supported auth scheme: %s: %w", string(ah.scheme), errdefs.ErrNotImplemented)
 	}
@@ -272,14 +306,21 @@ func (ah *authHandler) doBasicAuth(ctx context.Context) (string, string, error)
 	return fmt.Sprintf("Basic %s", auth), "", nil
 }
 
-func (ah *authHandler) doBearerAuth(ctx context.Context) (token, refreshToken string, err error) {
+func (ah *authHandler) doBearerAuth(ctx context.Context, ns string) (token, refreshToken string, err error) {
 	// copy common tokenOptions
 	to := ah.common
+	to.Namespace = ns
 
 	to.Scopes = GetTokenScopes(ctx, to.Scopes)
 
 	// Docs: https://distribution.github.io/distribution/spec/auth/scope/
-	scoped := strings.Join(to.Scopes, " ")
+	//
+	// Proxy registries serve multiple upstream namespaces behind the same
+	// host, distinguished by the "ns" query parameter (see
+	// RegistryHost.isProxy/addNamespace), and issue tokens scoped to a
+	// single upstream. The cache key must include the namespace alongside
+	// the resource scope so tokens for different upstreams never collide.
+	scoped := strings.Join(to.Scopes, " ") + "|" + ns
 
 	// Keep track of the expiration time of cached bearer tokens so they can be
 	// refreshed when they expire without a server roundtrip.
@@ -305,49 +346,82 @@ func (ah *authHandler) doBearerAuth(ctx context.Context) (token, refreshToken st
 	}()
 
 	// fetch token for the resource scope
-	if to.Secret != "" {
+	if ah.forceOAuth2 || to.Secret != "" {
 		defer func() {
 			if err != nil {
 				err = fmt.Errorf("failed to fetch oauth token: %w", err)
 			}
 		}()
-		// credential information is provided, use oauth POST endpoint
+		// credential information is provided (or the host is known to
+		// require it), use oauth POST endpoint
 		// TODO: Allow setting client_id
 		resp, err := auth.FetchTokenWithOAuth(ctx, ah.client, ah.header, "containerd-client", to)
 		if err != nil {
-			var errStatus remoteerrors.ErrUnexpectedStatus
-			if errors.As(err, &errStatus) {
-				// Registries without support for POST may return 404 for POST /v2/token.
-				// As of September 2017, GCR is known to return 404.
-				// As of February 2018, JFrog Artifactory is known to return 401.
-				// As of January 2022, ACR is known to return 400.
-				if (errStatus.StatusCode == 405 && to.Username != "") || errStatus.StatusCode == 404 || errStatus.StatusCode == 401 || errStatus.StatusCode == 400 {
-					resp, err := auth.FetchToken(ctx, ah.client, ah.header, to)
-					if err != nil {
-						return "", "", err
-					}
-					expirationTime = getExpirationTime(resp.ExpiresInSeconds)
-					return resp.Token, resp.RefreshToken, nil
+			// ah.forceOAuth2 means the host is already known to require
+			// POST, so there is nothing to fall back to.
+			if !ah.forceOAuth2 && isTokenMethodFallbackStatus(err, to.Username != "") {
+				resp, err := auth.FetchToken(ctx, ah.client, ah.header, to)
+				if err != nil {
+					return "", "", err
 				}
-				log.G(ctx).WithFields(log.Fields{
-					"status": errStatus.Status,
-					"body":   string(errStatus.Body),
-				}).Debugf("token request failed")
+				expirationTime = getExpirationTime(resp.ExpiresInSeconds)
+				return resp.Token, resp.RefreshToken, nil
 			}
+			logTokenRequestFailure(ctx, err)
 			return "", "", err
 		}
 		expirationTime = getExpirationTime(resp.ExpiresInSeconds)
 		return resp.AccessToken, resp.RefreshToken, nil
 	}
-	// do request anonymously
+
+	// do request anonymously, using the simpler GET token flow
 	resp, err := auth.FetchToken(ctx, ah.client, ah.header, to)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to fetch anonymous token: %w", err)
+		if !isTokenMethodFallbackStatus(err, false) {
+			return "", "", fmt.Errorf("failed to fetch anonymous token: %w", err)
+		}
+		logTokenRequestFailure(ctx, err)
+
+		// Some registries reject GET /token outright rather than simply not
+		// supporting it, so fall back to the OAuth2 POST flow before giving up.
+		respOAuth, oerr := auth.FetchTokenWithOAuth(ctx, ah.client, ah.header, "containerd-client", to)
+		if oerr != nil {
+			return "", "", fmt.Errorf("failed to fetch anonymous token: %w", err)
+		}
+		expirationTime = getExpirationTime(respOAuth.ExpiresInSeconds)
+		return respOAuth.AccessToken, respOAuth.RefreshToken, nil
 	}
 	expirationTime = getExpirationTime(resp.ExpiresInSeconds)
 	return resp.Token, resp.RefreshToken, nil
 }
 
+// isTokenMethodFallbackStatus reports whether err looks like the registry
+// rejected the token request because of the HTTP method used (GET vs POST)
+// rather than for a credentials or scope reason, meaning it's worth retrying
+// with the other method.
+//
+// Registries without support for POST may return 404 for POST /v2/token.
+// As of September 2017, GCR is known to return 404.
+// As of February 2018, JFrog Artifactory is known to return 401.
+// As of January 2022, ACR is known to return 400.
+func isTokenMethodFallbackStatus(err error, hasUsername bool) bool {
+	var errStatus remoteerrors.ErrUnexpectedStatus
+	if !errors.As(err, &errStatus) {
+		return false
+	}
+	return (errStatus.StatusCode == 405 && hasUsername) || errStatus.StatusCode == 404 || errStatus.StatusCode == 401 || errStatus.StatusCode == 400
+}
+
+func logTokenRequestFailure(ctx context.Context, err error) {
+	var errStatus remoteerrors.ErrUnexpectedStatus
+	if errors.As(err, &errStatus) {
+		log.G(ctx).WithFields(log.Fields{
+			"status": errStatus.Status,
+			"body":   string(errStatus.Body),
+		}).Debugf("token request failed")
+	}
+}
+
 func getExpirationTime(expiresInSeconds int) *time.Time {
 	if expiresInSeconds <= 0 {
 		return nil
@@ -0,0 +1,299 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestDockerAuthorizerBearerRefreshOnExpiry verifies that a cached bearer
+// token is re-fetched once it expires, using the same credentials callback
+// that was supplied at registry-auth-challenge time (e.g. the short-lived,
+// per-pull identity token CRI hands the authorizer). This mirrors the
+// ServiceAccount-token-based image pull flow, where the token handed to a
+// single pull can expire mid-transfer and must be refreshed rather than
+// failing the pull outright.
+func TestDockerAuthorizerBearerRefreshOnExpiry(t *testing.T) {
+	var (
+		tokenCalls int32
+		credCalls  int32
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&tokenCalls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": fmt.Sprintf("token-%d", n),
+			"expires_in":   1,
+		})
+	}))
+	defer ts.Close()
+
+	credentials := func(host string) (string, string, error) {
+		atomic.AddInt32(&credCalls, 1)
+		return "", "identity-token", nil
+	}
+
+	a := NewDockerAuthorizer(WithAuthCreds(credentials))
+
+	req, err := http.NewRequest(http.MethodGet, "https://registry.example.com/v2/foo/bar/manifests/latest", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	challenge := &http.Response{
+		Request: req,
+		Header: http.Header{
+			"Www-Authenticate": {fmt.Sprintf(`Bearer realm=%q,service="registry.example.com",scope="repository:foo/bar:pull"`, ts.URL)},
+		},
+	}
+	if err := a.AddResponses(context.Background(), []*http.Response{challenge}); err != nil {
+		t.Fatalf("AddResponses failed: %v", err)
+	}
+
+	authReq, err := http.NewRequest(http.MethodGet, req.URL.String(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Authorize(context.Background(), authReq); err != nil {
+		t.Fatalf("Authorize failed: %v", err)
+	}
+	first := authReq.Header.Get("Authorization")
+	if first != "Bearer token-1" {
+		t.Fatalf("expected first token to be Bearer token-1, got %q", first)
+	}
+
+	// A second, immediate call should reuse the cached token rather than
+	// fetching a new one.
+	authReq2, err := http.NewRequest(http.MethodGet, req.URL.String(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Authorize(context.Background(), authReq2); err != nil {
+		t.Fatalf("Authorize failed: %v", err)
+	}
+	if got := authReq2.Header.Get("Authorization"); got != first {
+		t.Fatalf("expected cached token %q to be reused, got %q", first, got)
+	}
+	if calls := atomic.LoadInt32(&tokenCalls); calls != 1 {
+		t.Fatalf("expected exactly 1 token fetch before expiry, got %d", calls)
+	}
+
+	// Wait for the cached token to expire, then confirm the authorizer
+	// fetches a fresh one using the same credentials callback instead of
+	// failing the request.
+	time.Sleep(1100 * time.Millisecond)
+
+	authReq3, err := http.NewRequest(http.MethodGet, req.URL.String(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Authorize(context.Background(), authReq3); err != nil {
+		t.Fatalf("Authorize failed after expiry: %v", err)
+	}
+	if got := authReq3.Header.Get("Authorization"); got == first || got == "" {
+		t.Fatalf("expected a refreshed token after expiry, got %q (previous: %q)", got, first)
+	}
+	if calls := atomic.LoadInt32(&tokenCalls); calls != 2 {
+		t.Fatalf("expected a second token fetch after expiry, got %d", calls)
+	}
+	if calls := atomic.LoadInt32(&credCalls); calls != 1 {
+		t.Fatalf("expected the credentials callback to be invoked only once, at challenge time, got %d", calls)
+	}
+}
+
+// TestDockerAuthorizerBearerPerNamespace verifies that requests carrying
+// different "ns" query parameters (the upstream namespace a pull-through
+// registry is proxying, see RegistryHost.isProxy/addNamespace) get distinct
+// cached tokens for the same resource scope, rather than one upstream's
+// token leaking into requests for another.
+func TestDockerAuthorizerBearerPerNamespace(t *testing.T) {
+	var tokenCalls int32
+	var gotNamespaces []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&tokenCalls, 1)
+		gotNamespaces = append(gotNamespaces, r.URL.Query().Get("ns"))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"token": fmt.Sprintf("token-%d", n),
+		})
+	}))
+	defer ts.Close()
+
+	a := NewDockerAuthorizer()
+
+	req, err := http.NewRequest(http.MethodGet, "https://mirror.example.com/v2/foo/bar/manifests/latest", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	challenge := &http.Response{
+		Request: req,
+		Header: http.Header{
+			"Www-Authenticate": {fmt.Sprintf(`Bearer realm=%q,service="mirror.example.com",scope="repository:foo/bar:pull"`, ts.URL)},
+		},
+	}
+	if err := a.AddResponses(context.Background(), []*http.Response{challenge}); err != nil {
+		t.Fatalf("AddResponses failed: %v", err)
+	}
+
+	authorize := func(ns string) string {
+		u := req.URL.String()
+		if ns != "" {
+			u += "?ns=" + ns
+		}
+		authReq, err := http.NewRequest(http.MethodGet, u, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := a.Authorize(context.Background(), authReq); err != nil {
+			t.Fatalf("Authorize failed: %v", err)
+		}
+		return authReq.Header.Get("Authorization")
+	}
+
+	upstreamA := authorize("upstream-a.example.com")
+	upstreamB := authorize("upstream-b.example.com")
+	if upstreamA == upstreamB {
+		t.Fatalf("expected distinct tokens for distinct namespaces, got %q for both", upstreamA)
+	}
+
+	// Re-authorizing the same namespace should hit the cache, not fetch again.
+	if got := authorize("upstream-a.example.com"); got != upstreamA {
+		t.Fatalf("expected cached token %q for upstream-a, got %q", upstreamA, got)
+	}
+
+	if calls := atomic.LoadInt32(&tokenCalls); calls != 2 {
+		t.Fatalf("expected exactly 2 token fetches (one per namespace), got %d", calls)
+	}
+	if len(gotNamespaces) != 2 || gotNamespaces[0] != "upstream-a.example.com" || gotNamespaces[1] != "upstream-b.example.com" {
+		t.Fatalf("expected the ns parameter to be forwarded to the token request, got %v", gotNamespaces)
+	}
+}
+
+// TestDockerAuthorizerBearerOAuth2Fallback verifies that an anonymous token
+// request which is rejected in a way that looks method-related (see
+// isTokenMethodFallbackStatus) is automatically retried using the OAuth2
+// POST flow instead of failing the pull outright.
+func TestDockerAuthorizerBearerOAuth2Fallback(t *testing.T) {
+	var getCalls, postCalls int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			atomic.AddInt32(&postCalls, 1)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "oauth-token"})
+			return
+		}
+		atomic.AddInt32(&getCalls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	a := NewDockerAuthorizer()
+
+	req, err := http.NewRequest(http.MethodGet, "https://registry.example.com/v2/foo/bar/manifests/latest", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	challenge := &http.Response{
+		Request: req,
+		Header: http.Header{
+			"Www-Authenticate": {fmt.Sprintf(`Bearer realm=%q,service="registry.example.com",scope="repository:foo/bar:pull"`, ts.URL)},
+		},
+	}
+	if err := a.AddResponses(context.Background(), []*http.Response{challenge}); err != nil {
+		t.Fatalf("AddResponses failed: %v", err)
+	}
+
+	authReq, err := http.NewRequest(http.MethodGet, req.URL.String(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Authorize(context.Background(), authReq); err != nil {
+		t.Fatalf("Authorize failed: %v", err)
+	}
+	if got := authReq.Header.Get("Authorization"); got != "Bearer oauth-token" {
+		t.Fatalf("expected fallback to OAuth2 POST to produce Bearer oauth-token, got %q", got)
+	}
+	if calls := atomic.LoadInt32(&getCalls); calls != 1 {
+		t.Fatalf("expected exactly 1 GET attempt before falling back, got %d", calls)
+	}
+	if calls := atomic.LoadInt32(&postCalls); calls != 1 {
+		t.Fatalf("expected exactly 1 POST fallback attempt, got %d", calls)
+	}
+}
+
+// TestDockerAuthorizerBearerForceOAuth2 verifies that WithForceOAuth2 skips
+// the GET token flow entirely for the configured host, going straight to
+// the OAuth2 POST flow even for an otherwise-anonymous request.
+func TestDockerAuthorizerBearerForceOAuth2(t *testing.T) {
+	var getCalls, postCalls int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			atomic.AddInt32(&postCalls, 1)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "oauth-token"})
+			return
+		}
+		atomic.AddInt32(&getCalls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"token": "get-token"})
+	}))
+	defer ts.Close()
+
+	a := NewDockerAuthorizer(WithForceOAuth2("registry.example.com"))
+
+	req, err := http.NewRequest(http.MethodGet, "https://registry.example.com/v2/foo/bar/manifests/latest", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	challenge := &http.Response{
+		Request: req,
+		Header: http.Header{
+			"Www-Authenticate": {fmt.Sprintf(`Bearer realm=%q,service="registry.example.com",scope="repository:foo/bar:pull"`, ts.URL)},
+		},
+	}
+	if err := a.AddResponses(context.Background(), []*http.Response{challenge}); err != nil {
+		t.Fatalf("AddResponses failed: %v", err)
+	}
+
+	authReq, err := http.NewRequest(http.MethodGet, req.URL.String(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Authorize(context.Background(), authReq); err != nil {
+		t.Fatalf("Authorize failed: %v", err)
+	}
+	if got := authReq.Header.Get("Authorization"); got != "Bearer oauth-token" {
+		t.Fatalf("expected forced OAuth2 POST to produce Bearer oauth-token, got %q", got)
+	}
+	if calls := atomic.LoadInt32(&getCalls); calls != 0 {
+		t.Fatalf("expected no GET attempts with WithForceOAuth2, got %d", calls)
+	}
+	if calls := atomic.LoadInt32(&postCalls); calls != 1 {
+		t.Fatalf("expected exactly 1 POST attempt, got %d", calls)
+	}
+}
@@ -0,0 +1,84 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package docker
+
+import "errors"
+
+// hasErrorCode reports whether err, or anything it wraps, carries the given
+// registry error code. Fetch/push/resolve errors surface this either as a
+// bare ErrorCode, a single Error, or an Errors envelope joined with the
+// unexpected-status error by unexpectedResponseErr - errors.As unwraps all
+// three without callers needing to know which shape they got.
+func hasErrorCode(err error, code ErrorCode) bool {
+	var ec ErrorCode
+	if errors.As(err, &ec) && ec == code {
+		return true
+	}
+
+	var e Error
+	if errors.As(err, &e) && e.Code == code {
+		return true
+	}
+
+	var errs Errors
+	if errors.As(err, &errs) {
+		for _, sub := range errs {
+			if hasErrorCode(sub, code) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// IsUnauthorized returns true if err indicates the registry rejected the
+// request for lack of (or invalid) credentials, rather than the resource
+// simply not existing, so callers can prompt for credentials instead of
+// treating it as a missing image.
+func IsUnauthorized(err error) bool {
+	return hasErrorCode(err, ErrorCodeUnauthorized) || hasErrorCode(err, ErrorCodeDenied)
+}
+
+// IsRateLimited returns true if err indicates the registry throttled the
+// request (HTTP 429 / TOOMANYREQUESTS), so callers can back off and retry
+// instead of treating it as a permanent failure.
+func IsRateLimited(err error) bool {
+	return hasErrorCode(err, ErrorCodeTooManyRequests)
+}
+
+// IsNameUnknown returns true if err indicates the registry does not know
+// the repository name used to resolve a reference.
+func IsNameUnknown(err error) bool {
+	return hasErrorCode(err, ErrorCodeNameUnknown)
+}
+
+// IsManifestUnknown returns true if err indicates the registry does not
+// have the requested manifest (the image name resolved, but the tag or
+// digest did not), as distinct from a name it has never heard of or a
+// registry-side failure.
+func IsManifestUnknown(err error) bool {
+	return hasErrorCode(err, ErrorCodeManifestUnknown)
+}
+
+// IsBlobUnknown returns true if err indicates the registry does not have a
+// blob referenced by a manifest or requested directly - most often seen
+// when a manifest outlives the layers it points at, or a mirror has not
+// finished replicating them yet.
+func IsBlobUnknown(err error) bool {
+	return hasErrorCode(err, ErrorCodeBlobUnknown) || hasErrorCode(err, ErrorCodeManifestBlobUnknown)
+}
@@ -89,6 +89,75 @@ var (
 		service too many times`,
 		HTTPStatusCode: http.StatusTooManyRequests,
 	})
+
+	// ErrorCodeNameUnknown is returned when the repository name is not known.
+	ErrorCodeNameUnknown = Register("errcode", ErrorDescriptor{
+		Value:   "NAME_UNKNOWN",
+		Message: "repository name not known to registry",
+		Description: `This is returned if the name used during an
+		operation is unknown to the registry.`,
+		HTTPStatusCode: http.StatusNotFound,
+	})
+
+	// ErrorCodeManifestUnknown is returned when the manifest is not found.
+	ErrorCodeManifestUnknown = Register("errcode", ErrorDescriptor{
+		Value:   "MANIFEST_UNKNOWN",
+		Message: "manifest unknown",
+		Description: `This error is returned when the manifest, identified
+		by name and tag is unknown to the repository.`,
+		HTTPStatusCode: http.StatusNotFound,
+	})
+
+	// ErrorCodeManifestInvalid is returned when the manifest fails validation.
+	ErrorCodeManifestInvalid = Register("errcode", ErrorDescriptor{
+		Value:   "MANIFEST_INVALID",
+		Message: "manifest invalid",
+		Description: `During upload, manifests undergo several checks
+		ensuring validity. If those checks fail, this error may be
+		returned, unless a more specific error is included. The
+		detail will contain information the failed validation.`,
+		HTTPStatusCode: http.StatusBadRequest,
+	})
+
+	// ErrorCodeManifestBlobUnknown is returned when a manifest references
+	// an unknown blob.
+	ErrorCodeManifestBlobUnknown = Register("errcode", ErrorDescriptor{
+		Value:   "MANIFEST_BLOB_UNKNOWN",
+		Message: "blob unknown to registry",
+		Description: `This error is returned when a manifest blob is
+		unknown to the registry.`,
+		HTTPStatusCode: http.StatusNotFound,
+	})
+
+	// ErrorCodeBlobUnknown is returned when a blob is not found.
+	ErrorCodeBlobUnknown = Register("errcode", ErrorDescriptor{
+		Value:   "BLOB_UNKNOWN",
+		Message: "blob unknown to registry",
+		Description: `This error may be returned when a blob is unknown
+		to the registry in a specified repository. This can be returned
+		with a standard get or if a manifest references an unknown blob.`,
+		HTTPStatusCode: http.StatusNotFound,
+	})
+
+	// ErrorCodeDigestInvalid is returned when the digest check on a blob fails.
+	ErrorCodeDigestInvalid = Register("errcode", ErrorDescriptor{
+		Value:   "DIGEST_INVALID",
+		Message: "provided digest did not match uploaded content",
+		Description: `When a blob is uploaded, the registry will check
+		that the content matches the digest provided by the client.
+		This error is returned when that match fails.`,
+		HTTPStatusCode: http.StatusBadRequest,
+	})
+
+	// ErrorCodeNameInvalid is returned when the repository name fails
+	// validation.
+	ErrorCodeNameInvalid = Register("errcode", ErrorDescriptor{
+		Value:   "NAME_INVALID",
+		Message: "invalid repository name",
+		Description: `Invalid repository name encountered either during
+		manifest validation or any API operation.`,
+		HTTPStatusCode: http.StatusBadRequest,
+	})
 )
 
 var nextCode = 1000
@@ -217,6 +217,13 @@ type dockerFetcher struct {
 	*dockerBase
 }
 
+// Hosts implements FetcherHosts, returning every registry host configured
+// for this fetcher's reference, mirrors and upstream alike, regardless of
+// which ones pulling actually ends up using.
+func (r dockerFetcher) Hosts() []RegistryHost {
+	return r.hosts
+}
+
 func (r dockerFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
 	ctx = log.WithLogger(ctx, log.G(ctx).WithField("digest", desc.Digest))
 
@@ -253,13 +260,14 @@ func (r dockerFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.R
 				Capabilities: HostCapabilityPull,
 			}
 			req := r.request(host, http.MethodGet)
+			req.op = opBlobFetch
 			// Strip namespace from base
 			req.path = u.Path
 			if u.RawQuery != "" {
 				req.path = req.path + "?" + u.RawQuery
 			}
 
-			rc, err := r.open(ctx, req, desc.MediaType, offset, false)
+			rc, err := r.open(ctx, req, desc.MediaType, offset, false, nil, false)
 			if err != nil {
 				if errdefs.IsNotFound(err) {
 					continue // try one of the other urls.
@@ -283,11 +291,12 @@ func (r dockerFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.R
 				}
 
 				req := base.request(host, http.MethodGet, "manifests", desc.Digest.String())
+				req.op = opManifestFetch
 				if err := req.addNamespace(r.refspec.Hostname()); err != nil {
 					return nil, err
 				}
 
-				rc, err := r.open(ctx, req, desc.MediaType, offset, i == len(r.hosts)-1)
+				rc, err := r.open(ctx, req, desc.MediaType, offset, i == len(r.hosts)-1, nil, false)
 				if err != nil {
 					// Store the error for referencing later
 					if firstErr == nil {
@@ -312,11 +321,23 @@ func (r dockerFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.R
 			}
 
 			req := base.request(host, http.MethodGet, "blobs", desc.Digest.String())
+			req.op = opBlobFetch
 			if err := req.addNamespace(r.refspec.Hostname()); err != nil {
 				return nil, err
 			}
 
-			rc, err := r.open(ctx, req, desc.MediaType, offset, i == len(r.hosts)-1)
+			var hedge *request
+			if r.performances.HedgeDelay > 0 && i < len(r.hosts)-1 {
+				hedgeHost := r.hosts[i+1]
+				hedgeBase := r.withRewritesFromHost(hedgeHost)
+				hedgeReq := hedgeBase.request(hedgeHost, http.MethodGet, "blobs", desc.Digest.String())
+				hedgeReq.op = opBlobFetch
+				if err := hedgeReq.addNamespace(r.refspec.Hostname()); err == nil {
+					hedge = hedgeReq
+				}
+			}
+
+			rc, err := r.open(ctx, req, desc.MediaType, offset, i == len(r.hosts)-1, hedge, i+1 == len(r.hosts)-1)
 			if err != nil {
 				// Store the error for referencing later
 				if firstErr == nil {
@@ -347,6 +368,7 @@ func (r dockerFetcher) createGetReq(ctx context.Context, host RegistryHost, last
 	}
 
 	headReq := base.request(host, http.MethodHead, ps...)
+	headReq.op = opBlobFetch
 	if err := headReq.addNamespace(r.refspec.Hostname()); err != nil {
 		return nil, 0, err
 	}
@@ -369,6 +391,7 @@ func (r dockerFetcher) createGetReq(ctx context.Context, host RegistryHost, last
 	}
 
 	getReq := base.request(host, http.MethodGet, ps...)
+	getReq.op = opBlobFetch
 	if err := getReq.addNamespace(base.refspec.Hostname()); err != nil {
 		return nil, 0, err
 	}
@@ -432,7 +455,7 @@ func (r dockerFetcher) FetchByDigest(ctx context.Context, dgst digest.Digest, op
 	}
 
 	seeker, err := newHTTPReadSeeker(sz, func(offset int64) (io.ReadCloser, error) {
-		return r.open(ctx, getReq, config.Mediatype, offset, true)
+		return r.open(ctx, getReq, config.Mediatype, offset, true, nil, false)
 	})
 	if err != nil {
 		return nil, desc, err
@@ -449,7 +472,14 @@ func (r dockerFetcher) FetchByDigest(ctx context.Context, dgst digest.Digest, op
 	return seeker, desc, nil
 }
 
-func (r dockerFetcher) open(ctx context.Context, req *request, mediatype string, offset int64, lastHost bool) (_ io.ReadCloser, retErr error) {
+// open performs req and returns a decompressed, decoded reader over its
+// body. If hedge is non-nil and r.performances.HedgeDelay is positive, req
+// and hedge are raced: hedge is only sent if req hasn't responded within the
+// delay, and whichever host responds first is used for the rest of the
+// fetch (including any further chunk requests for parallel downloads),
+// trading a possible extra request for lower tail latency against slow or
+// degraded mirrors.
+func (r dockerFetcher) open(ctx context.Context, req *request, mediatype string, offset int64, lastHost bool, hedge *request, hedgeLastHost bool) (_ io.ReadCloser, retErr error) {
 	const minChunkSize = 512
 
 	chunkSize := int64(r.performances.ConcurrentLayerFetchBuffer)
@@ -468,10 +498,21 @@ func (r dockerFetcher) open(ctx context.Context, req *request, mediatype string,
 		req.setOffset(offset)
 	}
 
+	var hedgeCand *hedgeCandidate
+	if hedge != nil {
+		hedge.setMediaType(mediatype)
+		hedge.header.Set("Accept-Encoding", "zstd;q=1.0, gzip;q=0.8, deflate;q=0.5")
+		if parallelism > 1 || offset > 0 {
+			hedge.setOffset(offset)
+		}
+		hedgeCand = &hedgeCandidate{req: hedge, ctx: ctx, lastHost: hedgeLastHost}
+	}
+
 	if err := r.Acquire(ctx, 1); err != nil {
 		return nil, err
 	}
-	resp, err := req.doWithRetries(ctx, lastHost, withErrorCheck, withOffsetCheck(offset))
+	resp, err, winner := doHedged(r.performances.HedgeDelay, &hedgeCandidate{req: req, ctx: ctx, lastHost: lastHost}, hedgeCand, withErrorCheck, withOffsetCheck(offset))
+	req, ctx = winner.req, winner.ctx
 	switch err {
 	case nil:
 		// all good
@@ -58,7 +58,7 @@ func FuzzFetcher(f *testing.F) {
 
 		ctx := context.Background()
 		req := f.request(host, http.MethodGet)
-		rc, err := f.open(ctx, req, "", 0, true)
+		rc, err := f.open(ctx, req, "", 0, true, nil, false)
 		if err != nil {
 			return
 		}
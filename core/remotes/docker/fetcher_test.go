@@ -34,6 +34,7 @@ import (
 	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
@@ -83,7 +84,7 @@ func TestFetcherOpen(t *testing.T) {
 	checkReader := func(o int64) {
 		t.Helper()
 
-		rc, err := f.open(ctx, req, "", o, true)
+		rc, err := f.open(ctx, req, "", o, true, nil, false)
 		if err != nil {
 			t.Fatalf("failed to open: %+v", err)
 		}
@@ -123,7 +124,7 @@ func TestFetcherOpen(t *testing.T) {
 	// Check that server returning a different content range
 	// then requested errors
 	start = 30
-	_, err = f.open(ctx, req, "", 20, true)
+	_, err = f.open(ctx, req, "", 20, true, nil, false)
 	if err == nil {
 		t.Fatal("expected error opening with invalid server response")
 	}
@@ -215,7 +216,7 @@ func TestFetcherOpenParallel(t *testing.T) {
 	checkReader := func(offset int64) {
 		t.Helper()
 
-		rc, err := f.open(ctx, req, "", offset, true)
+		rc, err := f.open(ctx, req, "", offset, true, nil, false)
 		if err != nil {
 			t.Fatalf("failed to open: %+v", err)
 		}
@@ -260,7 +261,7 @@ func TestFetcherOpenParallel(t *testing.T) {
 	// Check that server returning a different content range
 	// than requested errors
 	forceRange = []httpRange{{start: 10, length: size - 20}}
-	_, err = f.open(ctx, req, "", 20, true)
+	_, err = f.open(ctx, req, "", 20, true, nil, false)
 	if err == nil {
 		t.Fatal("expected error opening with invalid server response")
 	}
@@ -272,14 +273,14 @@ func TestFetcherOpenParallel(t *testing.T) {
 
 	failAfter = 1
 	forceRange = []httpRange{{start: 20}}
-	_, err = f.open(ctx, req, "", 20, true)
+	_, err = f.open(ctx, req, "", 20, true, nil, false)
 	assert.ErrorContains(t, err, "unexpected status")
 	forceRange = nil
 	failAfter = 0
 
 	// test a case when a subsequent request fails and shouldn't have
 	failAfter = 1 * 1024 * 1024
-	body, err := f.open(ctx, req, "", 0, true)
+	body, err := f.open(ctx, req, "", 0, true, nil, false)
 	assert.NoError(t, err)
 	_, err = io.ReadAll(body)
 	assert.Error(t, err, "this should have failed")
@@ -407,7 +408,7 @@ func TestContentEncoding(t *testing.T) {
 
 			req := f.request(host, http.MethodGet)
 
-			rc, err := f.open(context.Background(), req, "", 0, true)
+			rc, err := f.open(context.Background(), req, "", 0, true, nil, false)
 			if err != nil {
 				t.Fatalf("failed to open for encoding %s: %+v", tc.encodingHeader, err)
 			}
@@ -542,7 +543,7 @@ func TestDockerFetcherOpen(t *testing.T) {
 
 			req := f.request(host, http.MethodGet)
 
-			got, err := f.open(context.TODO(), req, "", 0, tt.lastHost)
+			got, err := f.open(context.TODO(), req, "", 0, tt.lastHost, nil, false)
 			assert.Equal(t, tt.wantErr, err != nil)
 			assert.Equal(t, tt.want, got)
 			assert.Equal(t, 0, tt.retries)
@@ -559,6 +560,95 @@ func TestDockerFetcherOpen(t *testing.T) {
 	}
 }
 
+func TestFetcherOpenHedging(t *testing.T) {
+	handler := func(delay time.Duration, body string) http.HandlerFunc {
+		return func(rw http.ResponseWriter, r *http.Request) {
+			time.Sleep(delay)
+			rw.Header().Set("content-length", strconv.Itoa(len(body)))
+			rw.Write([]byte(body))
+		}
+	}
+
+	t.Run("uses primary when it responds before the hedge delay", func(t *testing.T) {
+		var hedgeHit atomic.Bool
+		primary := httptest.NewServer(handler(0, "primary"))
+		defer primary.Close()
+		hedge := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			hedgeHit.Store(true)
+			handler(0, "hedge")(rw, r)
+		}))
+		defer hedge.Close()
+
+		f := dockerFetcher{&dockerBase{
+			repository:   "ns",
+			performances: transfer.ImageResolverPerformanceSettings{HedgeDelay: time.Second},
+		}}
+
+		primaryReq := f.request(serverHost(t, primary), http.MethodGet)
+		hedgeReq := f.request(serverHost(t, hedge), http.MethodGet)
+
+		rc, err := f.open(context.Background(), primaryReq, "", 0, true, hedgeReq, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rc.Close()
+
+		got, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != "primary" {
+			t.Fatalf("expected body from primary, got %q", got)
+		}
+		if hedgeHit.Load() {
+			t.Fatal("hedge should not have been used when primary responded in time")
+		}
+	})
+
+	t.Run("uses hedge when primary is slower than the hedge delay", func(t *testing.T) {
+		primary := httptest.NewServer(handler(200*time.Millisecond, "primary"))
+		defer primary.Close()
+		hedge := httptest.NewServer(handler(0, "hedge"))
+		defer hedge.Close()
+
+		f := dockerFetcher{&dockerBase{
+			repository:   "ns",
+			performances: transfer.ImageResolverPerformanceSettings{HedgeDelay: 10 * time.Millisecond},
+		}}
+
+		primaryReq := f.request(serverHost(t, primary), http.MethodGet)
+		hedgeReq := f.request(serverHost(t, hedge), http.MethodGet)
+
+		rc, err := f.open(context.Background(), primaryReq, "", 0, true, hedgeReq, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rc.Close()
+
+		got, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != "hedge" {
+			t.Fatalf("expected body from hedge, got %q", got)
+		}
+	})
+}
+
+func serverHost(t *testing.T, s *httptest.Server) RegistryHost {
+	t.Helper()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return RegistryHost{
+		Client: s.Client(),
+		Host:   u.Host,
+		Scheme: u.Scheme,
+		Path:   u.Path,
+	}
+}
+
 // httpRange specifies the byte range to be sent to the client.
 type httpRange struct {
 	start, length int64
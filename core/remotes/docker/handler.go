@@ -30,8 +30,43 @@ import (
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
+// FetcherHosts is implemented by Fetchers returned from this package's
+// Resolver that know the ordered list of registry hosts configured for the
+// reference they were created for, mirrors included. AppendDistributionSourceLabel
+// uses it, via WithSourceHosts, to record mirror provenance in addition to
+// the nominal upstream host parsed from the pull reference.
+type FetcherHosts interface {
+	// Hosts returns the registry hosts this Fetcher may pull from, in the
+	// order they are tried.
+	Hosts() []RegistryHost
+}
+
+// DistributionSourceLabelOpt configures AppendDistributionSourceLabel.
+type DistributionSourceLabelOpt func(*distributionSourceLabelConfig)
+
+type distributionSourceLabelConfig struct {
+	hosts []RegistryHost
+}
+
+// WithSourceHosts makes AppendDistributionSourceLabel also record a
+// distribution source label for every configured host that differs from the
+// nominal upstream parsed from the pull reference (e.g. a mirror), so a
+// later push can still find it as a mount-from candidate even though it
+// connects through a different host than the one the user originally
+// referenced.
+//
+// A host is skipped unless it has HostCapabilityResolve: most pull-through
+// mirrors do not, and an unauthenticated mirror's say-so about what a repo
+// contains is not something mount-from or cache-reuse decisions should trust
+// to the same degree as the upstream or a private mirror.
+func WithSourceHosts(hosts []RegistryHost) DistributionSourceLabelOpt {
+	return func(c *distributionSourceLabelConfig) {
+		c.hosts = hosts
+	}
+}
+
 // AppendDistributionSourceLabel updates the label of blob with distribution source.
-func AppendDistributionSourceLabel(manager content.Manager, ref string) (images.HandlerFunc, error) {
+func AppendDistributionSourceLabel(manager content.Manager, ref string, opts ...DistributionSourceLabelOpt) (images.HandlerFunc, error) {
 	refspec, err := reference.Parse(ref)
 	if err != nil {
 		return nil, err
@@ -42,36 +77,56 @@ func AppendDistributionSourceLabel(manager content.Manager, ref string) (images.
 		return nil, err
 	}
 
+	var config distributionSourceLabelConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
 	source, repo := u.Hostname(), strings.TrimPrefix(u.Path, "/")
+
+	sources := []string{source}
+	for _, h := range config.hosts {
+		if h.Host == source || !h.Capabilities.Has(HostCapabilityResolve) {
+			continue
+		}
+		sources = append(sources, h.Host)
+	}
+
 	return func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
 		info, err := manager.Info(ctx, desc.Digest)
 		if err != nil {
 			return nil, err
 		}
 
-		key := distributionSourceLabelKey(source)
+		updates := map[string]string{}
+		for _, source := range sources {
+			key := distributionSourceLabelKey(source)
 
-		originLabel := ""
-		if info.Labels != nil {
-			originLabel = info.Labels[key]
+			originLabel := ""
+			if info.Labels != nil {
+				originLabel = info.Labels[key]
+			}
+			value := appendDistributionSourceLabel(originLabel, repo)
+
+			// The repo name has been limited under 256 and the distribution
+			// label might hit the limitation of label size, when blob data
+			// is used as the very, very common layer.
+			if err := labels.Validate(key, value); err != nil {
+				log.G(ctx).Warnf("skip to append distribution label: %s", err)
+				continue
+			}
+			updates[key] = value
 		}
-		value := appendDistributionSourceLabel(originLabel, repo)
-
-		// The repo name has been limited under 256 and the distribution
-		// label might hit the limitation of label size, when blob data
-		// is used as the very, very common layer.
-		if err := labels.Validate(key, value); err != nil {
-			log.G(ctx).Warnf("skip to append distribution label: %s", err)
+		if len(updates) == 0 {
 			return nil, nil
 		}
 
-		info = content.Info{
-			Digest: desc.Digest,
-			Labels: map[string]string{
-				key: value,
-			},
+		fields := make([]string, 0, len(updates))
+		for key := range updates {
+			fields = append(fields, fmt.Sprintf("labels.%s", key))
 		}
-		_, err = manager.Update(ctx, info, fmt.Sprintf("labels.%s", key))
+
+		_, err = manager.Update(ctx, content.Info{Digest: desc.Digest, Labels: updates}, fields...)
 		return nil, err
 	}, nil
 }
@@ -17,9 +17,14 @@
 package docker
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/containerd/containerd/v2/core/content"
 	"github.com/containerd/containerd/v2/pkg/labels"
 	"github.com/containerd/containerd/v2/pkg/reference"
 )
@@ -104,6 +109,53 @@ func TestCommonPrefixComponents(t *testing.T) {
 	}
 }
 
+// fakeManager is a minimal content.Manager that only backs Info/Update,
+// enough to exercise AppendDistributionSourceLabel's handler without a real
+// content store.
+type fakeManager struct {
+	content.Manager
+
+	info content.Info
+}
+
+func (m *fakeManager) Info(_ context.Context, _ digest.Digest) (content.Info, error) {
+	return m.info, nil
+}
+
+func (m *fakeManager) Update(_ context.Context, info content.Info, _ ...string) (content.Info, error) {
+	m.info = info
+	return m.info, nil
+}
+
+func TestAppendDistributionSourceLabelWithSourceHosts(t *testing.T) {
+	manager := &fakeManager{}
+	handler, err := AppendDistributionSourceLabel(manager, "registry.example.com/library/redis",
+		WithSourceHosts([]RegistryHost{
+			{Host: "registry.example.com", Capabilities: HostCapabilityPull | HostCapabilityResolve | HostCapabilityPush},
+			{Host: "mirror.internal.example.com", Capabilities: HostCapabilityPull | HostCapabilityResolve},
+			{Host: "public-mirror.example.com", Capabilities: HostCapabilityPull},
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	desc := ocispec.Descriptor{Digest: digest.FromString("fake content")}
+	if _, err := handler(context.Background(), desc); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := manager.info.Labels[labels.LabelDistributionSource+".registry.example.com"], "library/redis"; got != want {
+		t.Fatalf("upstream label = %q, want %q", got, want)
+	}
+	if got, want := manager.info.Labels[labels.LabelDistributionSource+".mirror.internal.example.com"], "library/redis"; got != want {
+		t.Fatalf("resolve-capable mirror label = %q, want %q", got, want)
+	}
+	if _, ok := manager.info.Labels[labels.LabelDistributionSource+".public-mirror.example.com"]; ok {
+		t.Fatal("pull-only mirror without HostCapabilityResolve should not get a distribution source label")
+	}
+}
+
 func TestSelectRepositoryMountCandidate(t *testing.T) {
 	for _, tc := range []struct {
 		refspec  reference.Spec
@@ -113,6 +113,7 @@ func (p dockerPusher) push(ctx context.Context, desc ocispec.Descriptor, ref str
 	}
 
 	req := base.request(host, http.MethodHead, existCheck...)
+	req.op = opPush
 	req.header.Set("Accept", strings.Join([]string{desc.MediaType, `*/*`}, ", "))
 
 	log.G(ctx).WithField("url", req.String()).Debugf("checking and pushing to")
@@ -161,10 +162,12 @@ func (p dockerPusher) push(ctx context.Context, desc ocispec.Descriptor, ref str
 	if isManifest {
 		putPath := getManifestPath(p.object, desc.Digest)
 		req = base.request(host, http.MethodPut, putPath...)
+		req.op = opPush
 		req.header.Add("Content-Type", desc.MediaType)
 	} else {
 		// Start upload request
 		req = base.request(host, http.MethodPost, "blobs", "uploads/")
+		req.op = opPush
 
 		mountedFrom := ""
 		var resp *http.Response
@@ -267,6 +270,7 @@ func (p dockerPusher) push(ctx context.Context, desc ocispec.Descriptor, ref str
 		q.Add("digest", desc.Digest.String())
 
 		req = p.request(lhost, http.MethodPut)
+		req.op = opPush
 		req.header.Set("Content-Type", "application/octet-stream")
 		req.path = lurl.Path + "?" + q.Encode()
 	}
@@ -17,10 +17,13 @@
 package docker
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
 	"net"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -77,6 +80,50 @@ type RegistryHost struct {
 	Capabilities HostCapabilities
 	Header       http.Header
 	Rewrites     map[string]string
+
+	// Timeouts bounds how long the different classes of requests this host
+	// sees may run, in place of a single blanket Client.Timeout that either
+	// kills a slow-but-healthy layer download or lets a stalled connection
+	// linger until something else gives up. A nil Timeouts (the default)
+	// leaves every class unbounded except for whatever Client.Timeout or its
+	// Transport already enforces.
+	Timeouts *OperationTimeouts
+}
+
+// OperationTimeouts configures per-operation-class timeouts for a
+// RegistryHost. Each field is independent and optional; a zero value leaves
+// that class unbounded.
+type OperationTimeouts struct {
+	// Resolve bounds a manifest HEAD request used to resolve a reference to
+	// a digest without fetching its content.
+	Resolve time.Duration
+
+	// ManifestFetch bounds a manifest or index GET request. Manifests are
+	// small, so unlike blobs this is a single end-to-end timeout rather
+	// than being split into first-byte and idle phases.
+	ManifestFetch time.Duration
+
+	// BlobFetchFirstByte bounds how long a blob GET may wait for the
+	// response headers to arrive. It does not apply once the body has
+	// started streaming, so it will not abort a slow-but-progressing
+	// layer download.
+	BlobFetchFirstByte time.Duration
+
+	// BlobFetchIdle bounds how long a blob GET's body read may go without
+	// making progress once streaming has started. It is reset on every
+	// successful read, so a download that is merely slow, rather than
+	// stalled, is never killed by it.
+	BlobFetchIdle time.Duration
+
+	// TokenExchange bounds a single call to this host's Authorizer, which
+	// typically performs its own HTTP round trip to a token or OAuth2
+	// endpoint to obtain or refresh credentials.
+	TokenExchange time.Duration
+
+	// Push bounds the HEAD existence check and the upload requests (the
+	// start-upload POST, and the PUT that completes either a manifest put
+	// or a monolithic blob upload) this host's Pusher sends.
+	Push time.Duration
 }
 
 func (h RegistryHost) isProxy(refhost string) bool {
@@ -118,11 +165,34 @@ type registryOpts struct {
 	plainHTTP  func(string) (bool, error)
 	host       func(string) (string, error)
 	client     *http.Client
+
+	maxIdleConnsPerHost int
+	idleConnTimeout     time.Duration
 }
 
 // RegistryOpt defines a registry default option
 type RegistryOpt func(*registryOpts)
 
+// WithMaxIdleConnsPerHost configures the maximum number of idle (keep-alive)
+// connections the default transport pool retains per registry host. Only
+// applies when no explicit client is configured with WithClient; a value of
+// 0 leaves the transport's own default in place.
+func WithMaxIdleConnsPerHost(n int) RegistryOpt {
+	return func(opts *registryOpts) {
+		opts.maxIdleConnsPerHost = n
+	}
+}
+
+// WithIdleConnTimeout configures how long the default transport pool keeps
+// an idle connection to a registry host open before closing it. Only
+// applies when no explicit client is configured with WithClient; a value of
+// 0 leaves the transport's own default in place.
+func WithIdleConnTimeout(d time.Duration) RegistryOpt {
+	return func(opts *registryOpts) {
+		opts.idleConnTimeout = d
+	}
+}
+
 // WithPlainHTTP configures registries to use plaintext http scheme
 // for the provided host match function.
 func WithPlainHTTP(f func(string) (bool, error)) RegistryOpt {
@@ -162,6 +232,13 @@ func ConfigureDefaultRegistries(ropts ...RegistryOpt) RegistryHosts {
 		opt(&opts)
 	}
 
+	// Only pool transports when no explicit client was given: a caller using
+	// WithClient has already taken ownership of connection management.
+	var pool *transportPool
+	if opts.client == nil {
+		pool = newTransportPool(opts.maxIdleConnsPerHost, opts.idleConnTimeout)
+	}
+
 	return func(host string) ([]RegistryHost, error) {
 		config := RegistryHost{
 			Client:       opts.client,
@@ -172,12 +249,6 @@ func ConfigureDefaultRegistries(ropts ...RegistryOpt) RegistryHosts {
 			Capabilities: HostCapabilityPull | HostCapabilityResolve | HostCapabilityPush,
 		}
 
-		if config.Client == nil {
-			config.Client = &http.Client{
-				Transport: DefaultHTTPTransport(nil),
-			}
-		}
-
 		if opts.plainHTTP != nil {
 			match, err := opts.plainHTTP(host)
 			if err != nil {
@@ -198,10 +269,97 @@ func ConfigureDefaultRegistries(ropts ...RegistryOpt) RegistryHosts {
 			config.Host = "registry-1.docker.io"
 		}
 
+		// Client is built last so it is keyed off the final scheme/host and
+		// reused across calls to this RegistryHosts, instead of a fresh
+		// transport (and fresh idle connection pool) being dialed every time.
+		if config.Client == nil {
+			config.Client = pool.client(config.Scheme, config.Host)
+		}
+
 		return []RegistryHost{config}, nil
 	}
 }
 
+// transportPool maintains a shared *http.Transport per registry host so that
+// idle TCP/TLS connections are reused across repeated Resolve/Fetch calls
+// instead of being redialed for every request. It is safe for concurrent use.
+type transportPool struct {
+	maxIdleConnsPerHost int
+	idleConnTimeout     time.Duration
+
+	mu         sync.Mutex
+	transports map[string]*pooledTransport
+}
+
+// pooledTransport is an *http.Transport together with the connection reuse
+// counters for TransportPoolStats.
+type pooledTransport struct {
+	*http.Transport
+
+	dials    uint64
+	requests uint64
+}
+
+// TransportPoolStats reports connection reuse metrics for a transportPool.
+type TransportPoolStats struct {
+	// Requests is the number of requests made through the pool's transports.
+	Requests uint64
+	// Dials is the number of new connections the pool's transports have
+	// established. Requests minus Dials approximates the number of requests
+	// that reused an already open connection.
+	Dials uint64
+}
+
+func newTransportPool(maxIdleConnsPerHost int, idleConnTimeout time.Duration) *transportPool {
+	return &transportPool{
+		maxIdleConnsPerHost: maxIdleConnsPerHost,
+		idleConnTimeout:     idleConnTimeout,
+		transports:          make(map[string]*pooledTransport),
+	}
+}
+
+// client returns the pooled *http.Client for scheme://host, creating and
+// caching its transport on first use.
+func (p *transportPool) client(scheme, host string) *http.Client {
+	key := scheme + "://" + host
+
+	p.mu.Lock()
+	t, ok := p.transports[key]
+	if !ok {
+		t = &pooledTransport{Transport: DefaultHTTPTransport(nil)}
+		if p.maxIdleConnsPerHost > 0 {
+			t.MaxIdleConnsPerHost = p.maxIdleConnsPerHost
+		}
+		if p.idleConnTimeout > 0 {
+			t.IdleConnTimeout = p.idleConnTimeout
+		}
+		dial := t.DialContext
+		t.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			atomic.AddUint64(&t.dials, 1)
+			return dial(ctx, network, addr)
+		}
+		p.transports[key] = t
+	}
+	p.mu.Unlock()
+
+	atomic.AddUint64(&t.requests, 1)
+	return &http.Client{Transport: t.Transport}
+}
+
+// Stats returns aggregate request/dial counts across every host the pool has
+// created a transport for.
+func (p *transportPool) Stats() TransportPoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var stats TransportPoolStats
+	for _, t := range p.transports {
+		stats.Requests += atomic.LoadUint64(&t.requests)
+		stats.Dials += atomic.LoadUint64(&t.dials)
+	}
+	return stats
+}
+
 // MatchAllHosts is a host match function which is always true.
 func MatchAllHosts(string) (bool, error) {
 	return true, nil
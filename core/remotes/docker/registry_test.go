@@ -16,7 +16,47 @@
 
 package docker
 
-import "testing"
+import (
+	"net/http"
+	"testing"
+)
+
+func TestConfigureDefaultRegistriesReusesTransportPerHost(t *testing.T) {
+	hosts := ConfigureDefaultRegistries()
+
+	first, err := hosts("registry.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := hosts("registry.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := hosts("other.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first[0].Client.Transport != second[0].Client.Transport {
+		t.Fatal("expected repeated lookups of the same host to reuse the same transport")
+	}
+	if first[0].Client.Transport == other[0].Client.Transport {
+		t.Fatal("expected different hosts to get independent transports")
+	}
+}
+
+func TestConfigureDefaultRegistriesWithClientSkipsPool(t *testing.T) {
+	client := &http.Client{}
+	hosts := ConfigureDefaultRegistries(WithClient(client))
+
+	config, err := hosts("registry.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config[0].Client != client {
+		t.Fatal("expected the explicitly configured client to be used as-is")
+	}
+}
 
 func TestHasCapability(t *testing.T) {
 	var (
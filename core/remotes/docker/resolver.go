@@ -18,6 +18,7 @@ package docker
 
 import (
 	"context"
+	_ "crypto/sha512" // Ensure sha384, sha512 in addition to sha256 are available
 	"crypto/tls"
 	"errors"
 	"fmt"
@@ -30,6 +31,8 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/containerd/errdefs"
 	"github.com/containerd/log"
@@ -128,6 +131,13 @@ type ResolverOptions struct {
 	//
 	// Deprecated: use Hosts.
 	Client *http.Client
+
+	// StatsTracker, when set, records lightweight per-host request counts,
+	// errors, and latency for every request this resolver and any Fetcher
+	// or Pusher it creates sends, for on-node debugging (see
+	// "ctr registry status"). A single tracker may be shared across
+	// resolvers to accumulate stats across more than one pull or push.
+	StatsTracker *StatsTracker
 }
 
 // DefaultHost is the default host function.
@@ -143,6 +153,7 @@ type dockerResolver struct {
 	header        http.Header
 	resolveHeader http.Header
 	tracker       StatusTracker
+	stats         *StatsTracker
 	config        transfer.ImageResolverOptions
 }
 
@@ -202,7 +213,21 @@ func NewResolver(options ResolverOptions) remotes.Resolver {
 		header:        options.Headers,
 		resolveHeader: resolveHeader,
 		tracker:       options.Tracker,
+		stats:         options.StatsTracker,
+	}
+}
+
+// acceptHeader returns the Accept header to send when resolving a
+// reference: the caller-supplied override from transfer.WithAcceptMediaTypes
+// if one was set via SetOptions, otherwise the resolver's own default built
+// in NewResolver.
+func (r *dockerResolver) acceptHeader() http.Header {
+	if len(r.config.AcceptMediaTypes) > 0 {
+		header := http.Header{}
+		header.Set("Accept", strings.Join(r.config.AcceptMediaTypes, ", "))
+		return header
 	}
+	return r.resolveHeader
 }
 
 func getManifestMediaType(resp *http.Response) string {
@@ -233,6 +258,33 @@ func (r *countingReader) Read(p []byte) (int, error) {
 
 var _ remotes.ResolverWithOptions = &dockerResolver{}
 
+// resolveCandidate builds a hedgeCandidate for a request against host,
+// carrying the per-host rewritten base and repository-scoped context that
+// downstream resolution steps need once a winner is picked.
+func (r *dockerResolver) resolveCandidate(ctx context.Context, base *dockerBase, host RegistryHost, method string, ps []string, lastHost bool) (*hedgeCandidate, error) {
+	hostCtx := log.WithLogger(ctx, log.G(ctx).WithField("host", host.Host))
+	hostBase := base.withRewritesFromHost(host)
+	hostCtx, err := ContextWithRepositoryScope(hostCtx, hostBase.refspec, false)
+	if err != nil {
+		return nil, err
+	}
+	req := hostBase.request(host, method, ps...)
+	req.op = opResolve
+	if err := req.addNamespace(hostBase.refspec.Hostname()); err != nil {
+		return nil, err
+	}
+	for key, value := range r.acceptHeader() {
+		req.header[key] = append(req.header[key], value...)
+	}
+	return &hedgeCandidate{
+		req:      req,
+		ctx:      hostCtx,
+		host:     host,
+		base:     hostBase,
+		lastHost: lastHost,
+	}, nil
+}
+
 func (r *dockerResolver) Resolve(ctx context.Context, ref string) (string, ocispec.Descriptor, error) {
 	base, err := r.resolveDockerBase(ref)
 	if err != nil {
@@ -289,23 +341,23 @@ func (r *dockerResolver) Resolve(ctx context.Context, ref string) (string, ocisp
 
 	for _, u := range paths {
 		for i, host := range hosts {
-			ctx := log.WithLogger(ctx, log.G(ctx).WithField("host", host.Host))
-			base := base.withRewritesFromHost(host)
-			ctx, err = ContextWithRepositoryScope(ctx, base.refspec, false)
+			cand, err := r.resolveCandidate(ctx, base, host, http.MethodHead, u, i == len(hosts)-1)
 			if err != nil {
 				return "", ocispec.Descriptor{}, err
 			}
-			req := base.request(host, http.MethodHead, u...)
-			if err := req.addNamespace(base.refspec.Hostname()); err != nil {
-				return "", ocispec.Descriptor{}, err
-			}
 
-			for key, value := range r.resolveHeader {
-				req.header[key] = append(req.header[key], value...)
+			var hedge *hedgeCandidate
+			if base.performances.HedgeDelay > 0 && i < len(hosts)-1 {
+				if hedge, err = r.resolveCandidate(ctx, base, hosts[i+1], http.MethodHead, u, i+1 == len(hosts)-1); err != nil {
+					hedge = nil
+				}
 			}
 
-			log.G(ctx).Debug("resolving")
-			resp, err := req.doWithRetries(ctx, i == len(hosts)-1)
+			log.G(cand.ctx).Debug("resolving")
+			resp, err, winner := doHedged(base.performances.HedgeDelay, cand, hedge)
+			ctx := winner.ctx
+			host := winner.host
+			base := winner.base
 			if err != nil {
 				if errors.Is(err, ErrInvalidAuthorization) {
 					err = fmt.Errorf("pull access denied, repository does not exist or may require authorization: %w", err)
@@ -360,12 +412,13 @@ func (r *dockerResolver) Resolve(ctx context.Context, ref string) (string, ocisp
 			if dgst == "" || size == -1 {
 				log.G(ctx).Debug("no Docker-Content-Digest header, fetching manifest instead")
 
-				req = base.request(host, http.MethodGet, u...)
+				req := base.request(host, http.MethodGet, u...)
+				req.op = opManifestFetch
 				if err := req.addNamespace(base.refspec.Hostname()); err != nil {
 					return "", ocispec.Descriptor{}, err
 				}
 
-				for key, value := range r.resolveHeader {
+				for key, value := range r.acceptHeader() {
 					req.header[key] = append(req.header[key], value...)
 				}
 
@@ -442,6 +495,7 @@ func (r *dockerBase) withRewritesFromHost(host RegistryHost) *dockerBase {
 				},
 				repository: rr,
 				header:     r.header,
+				stats:      r.stats,
 			}
 		}
 	}
@@ -494,6 +548,7 @@ type dockerBase struct {
 	header       http.Header
 	performances transfer.ImageResolverPerformanceSettings
 	limiter      *semaphore.Weighted
+	stats        *StatsTracker
 }
 
 func (r *dockerBase) Acquire(ctx context.Context, weight int64) error {
@@ -522,6 +577,7 @@ func (r *dockerResolver) base(refspec reference.Spec) (*dockerBase, error) {
 		header:       r.header,
 		performances: r.config.Performances,
 		limiter:      r.config.DownloadLimiter,
+		stats:        r.stats,
 	}, nil
 }
 
@@ -555,16 +611,35 @@ func (r *dockerBase) request(host RegistryHost, method string, ps ...string) *re
 		p = p + "/"
 	}
 	return &request{
-		method: method,
-		path:   p,
-		header: header,
-		host:   host,
+		method:  method,
+		path:    p,
+		header:  header,
+		host:    host,
+		tracker: r.stats,
 	}
 }
 
+// operationClass identifies which of RegistryHost.Timeouts' fields, if any,
+// bounds a request, so that do() doesn't need to know what each call site is
+// doing beyond the class it was tagged with.
+type operationClass int
+
+const (
+	opUnknown operationClass = iota
+	opResolve
+	opManifestFetch
+	opBlobFetch
+	opPush
+)
+
 func (r *request) authorize(ctx context.Context, req *http.Request) error {
 	// Check if has header for host
 	if r.host.Authorizer != nil {
+		if t := r.host.Timeouts; t != nil && t.TokenExchange > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, t.TokenExchange)
+			defer cancel()
+		}
 		if err := r.host.Authorizer.Authorize(ctx, req); err != nil {
 			return err
 		}
@@ -597,12 +672,14 @@ func (r *request) addNamespace(ns string) (err error) {
 }
 
 type request struct {
-	method string
-	path   string
-	header http.Header
-	host   RegistryHost
-	body   func() (io.ReadCloser, error)
-	size   int64
+	method  string
+	path    string
+	header  http.Header
+	host    RegistryHost
+	body    func() (io.ReadCloser, error)
+	size    int64
+	tracker *StatsTracker
+	op      operationClass
 }
 
 func (r *request) clone() *request {
@@ -613,8 +690,52 @@ func (r *request) clone() *request {
 
 func (r *request) do(ctx context.Context) (*http.Response, error) {
 	u := r.host.Scheme + "://" + r.host.Host + r.path
+
+	// cancel releases the timeout context below, if one was set up for
+	// r.op; it always runs, either directly on an error return here or
+	// from the returned response body's Close, once the caller is done
+	// reading it. onFirstByte and wrapBody are only non-trivial for
+	// opBlobFetch, see below.
+	cancel := func() {}
+	onFirstByte := func() {}
+	wrapBody := func(rc io.ReadCloser) io.ReadCloser { return rc }
+
+	if t := r.host.Timeouts; t != nil {
+		switch r.op {
+		case opResolve:
+			if t.Resolve > 0 {
+				ctx, cancel = context.WithTimeout(ctx, t.Resolve)
+			}
+		case opManifestFetch:
+			if t.ManifestFetch > 0 {
+				ctx, cancel = context.WithTimeout(ctx, t.ManifestFetch)
+			}
+		case opPush:
+			if t.Push > 0 {
+				ctx, cancel = context.WithTimeout(ctx, t.Push)
+			}
+		case opBlobFetch:
+			if t.BlobFetchFirstByte > 0 || t.BlobFetchIdle > 0 {
+				var cancelFn context.CancelFunc
+				ctx, cancelFn = context.WithCancel(ctx)
+				cancel = cancelFn
+				if t.BlobFetchFirstByte > 0 {
+					timer := time.AfterFunc(t.BlobFetchFirstByte, cancelFn)
+					onFirstByte = func() { timer.Stop() }
+				}
+				if t.BlobFetchIdle > 0 {
+					idle := t.BlobFetchIdle
+					wrapBody = func(rc io.ReadCloser) io.ReadCloser {
+						return newIdleTimeoutReadCloser(rc, cancelFn, idle)
+					}
+				}
+			}
+		}
+	}
+
 	req, err := http.NewRequestWithContext(ctx, r.method, u, nil)
 	if err != nil {
+		cancel()
 		return nil, err
 	}
 	if r.header == nil {
@@ -625,6 +746,7 @@ func (r *request) do(ctx context.Context) (*http.Response, error) {
 	if r.body != nil {
 		body, err := r.body()
 		if err != nil {
+			cancel()
 			return nil, err
 		}
 		req.Body = body
@@ -637,6 +759,7 @@ func (r *request) do(ctx context.Context) (*http.Response, error) {
 	ctx = log.WithLogger(ctx, log.G(ctx).WithField("url", u))
 	log.G(ctx).WithFields(requestFields(req)).Debug("do request")
 	if err := r.authorize(ctx, req); err != nil {
+		cancel()
 		return nil, fmt.Errorf("failed to authorize: %w", err)
 	}
 
@@ -658,14 +781,68 @@ func (r *request) do(ctx context.Context) (*http.Response, error) {
 
 	tracing.UpdateHTTPClient(client, tracing.Name("remotes.docker.resolver", "HTTPRequest"))
 
+	start := time.Now()
 	resp, err := client.Do(req)
+	onFirstByte()
 	if err != nil {
+		cancel()
+		r.tracker.record(r.host.Host, 0, time.Since(start), err)
 		return nil, fmt.Errorf("failed to do request: %w", err)
 	}
+	r.tracker.record(r.host.Host, resp.StatusCode, time.Since(start), nil)
+	resp.Body = cancelOnClose(wrapBody(resp.Body), cancel)
 	log.G(ctx).WithFields(responseFields(resp)).Debug("fetch response received")
 	return resp, nil
 }
 
+// cancelOnClose wraps rc so that cancel runs once rc is closed, releasing
+// whatever timeout context do() set up for the request, once the caller is
+// actually done with the response body rather than as soon as do() returns.
+func cancelOnClose(rc io.ReadCloser, cancel context.CancelFunc) io.ReadCloser {
+	return &cancelOnCloseReadCloser{ReadCloser: rc, cancel: cancel}
+}
+
+type cancelOnCloseReadCloser struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnCloseReadCloser) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+// idleTimeoutReadCloser cancels cancel if no Read on rc completes within
+// idle of the previous one (or of creation, for the first Read), so a blob
+// download that stalls partway through is aborted without bounding how long
+// the download may take overall.
+type idleTimeoutReadCloser struct {
+	rc     io.ReadCloser
+	cancel context.CancelFunc
+	idle   time.Duration
+	timer  *time.Timer
+}
+
+func newIdleTimeoutReadCloser(rc io.ReadCloser, cancel context.CancelFunc, idle time.Duration) *idleTimeoutReadCloser {
+	return &idleTimeoutReadCloser{
+		rc:     rc,
+		cancel: cancel,
+		idle:   idle,
+		timer:  time.AfterFunc(idle, cancel),
+	}
+}
+
+func (r *idleTimeoutReadCloser) Read(p []byte) (int, error) {
+	n, err := r.rc.Read(p)
+	r.timer.Reset(r.idle)
+	return n, err
+}
+
+func (r *idleTimeoutReadCloser) Close() error {
+	r.timer.Stop()
+	return r.rc.Close()
+}
+
 type doChecks func(r *request, resp *http.Response) error
 
 func withErrorCheck(r *request, resp *http.Response) error {
@@ -750,6 +927,85 @@ func (r *request) doWithRetriesInner(ctx context.Context, responses []*http.Resp
 	return resp, err
 }
 
+// hedgeCandidate is a single host's side of a hedged request: the request
+// itself, the context scoped to that host (e.g. carrying its repository
+// scope), and whether it's the last host so failures shouldn't be retried
+// against yet another host.
+type hedgeCandidate struct {
+	req      *request
+	ctx      context.Context
+	host     RegistryHost
+	base     *dockerBase
+	lastHost bool
+}
+
+type hedgeResult struct {
+	resp *http.Response
+	err  error
+}
+
+// doHedged performs primary.req, and if hedgeDelay is positive, hedge is
+// non-nil, and primary hasn't responded within hedgeDelay, concurrently also
+// performs hedge.req against the next mirror, returning whichever finishes
+// first and canceling the other in-flight attempt. The hedgeCandidate that
+// produced the returned response/error is also returned, so callers that
+// need the winning host (e.g. to continue a multi-step resolution against
+// the host that actually answered) don't have to guess.
+func doHedged(hedgeDelay time.Duration, primary, hedge *hedgeCandidate, checks ...doChecks) (*http.Response, error, *hedgeCandidate) {
+	if hedgeDelay <= 0 || hedge == nil {
+		resp, err := primary.req.doWithRetries(primary.ctx, primary.lastHost, checks...)
+		return resp, err, primary
+	}
+
+	pctx, pcancel := context.WithCancel(primary.ctx)
+	defer pcancel()
+	pch := make(chan hedgeResult, 1)
+	go func() {
+		resp, err := primary.req.doWithRetries(pctx, primary.lastHost, checks...)
+		pch <- hedgeResult{resp, err}
+	}()
+
+	timer := time.NewTimer(hedgeDelay)
+	defer timer.Stop()
+
+	select {
+	case res := <-pch:
+		return res.resp, res.err, primary
+	case <-timer.C:
+	}
+
+	log.G(hedge.ctx).Debug("hedging request to next mirror")
+
+	hctx, hcancel := context.WithCancel(hedge.ctx)
+	defer hcancel()
+	hch := make(chan hedgeResult, 1)
+	go func() {
+		resp, err := hedge.req.doWithRetries(hctx, hedge.lastHost, checks...)
+		hch <- hedgeResult{resp, err}
+	}()
+
+	select {
+	case res := <-pch:
+		hcancel()
+		go closeHedgeLoser(hch)
+		return res.resp, res.err, primary
+	case res := <-hch:
+		pcancel()
+		go closeHedgeLoser(pch)
+		return res.resp, res.err, hedge
+	}
+}
+
+// closeHedgeLoser waits for the losing side of a hedged request to finish
+// (its context was already canceled, so this should be quick) and closes its
+// response body, if any, so the connection isn't leaked.
+func closeHedgeLoser(ch <-chan hedgeResult) {
+	res := <-ch
+	if res.resp != nil {
+		res.resp.Body.Close()
+	}
+}
+
 func (r *request) retryRequest(ctx context.Context, responses []*http.Response, lastHost bool) (bool, error) {
 	if len(responses) > 5 {
 		return false, nil
@@ -939,3 +1195,67 @@ func isPortError(err error, host string) bool {
 
 	return false
 }
+
+// NewProtocolFallback returns an http.RoundTripper which attempts requests
+// with preferred first, falling back to fallback for any request preferred
+// fails to complete. This allows a RegistryHost.Client to opt into a transport
+// that outperforms HTTP/1.1 or HTTP/2 under some conditions, such as an
+// HTTP/3 (QUIC) RoundTripper, which some CDNs fronting registries serve
+// significantly better over lossy links, while remaining usable against
+// registries or middleboxes that don't support it.
+//
+// containerd does not implement HTTP/3 itself: dialing, 0-RTT, and connection
+// migration are all handled by whatever RoundTripper is passed as preferred
+// (for example, quic-go/http3.Transport). Use Stats to report metrics on how
+// often preferred is actually used versus fallback.
+func NewProtocolFallback(preferred, fallback http.RoundTripper) *ProtocolFallback {
+	return &ProtocolFallback{
+		preferred: preferred,
+		fallback:  fallback,
+	}
+}
+
+// ProtocolFallback is an http.RoundTripper which prefers one transport over
+// another, falling back only when the preferred transport fails outright.
+// See NewProtocolFallback.
+type ProtocolFallback struct {
+	preferred http.RoundTripper
+	fallback  http.RoundTripper
+
+	preferredCount uint64
+	fallbackCount  uint64
+}
+
+func (f *ProtocolFallback) RoundTrip(r *http.Request) (*http.Response, error) {
+	if f.preferred != nil {
+		resp, err := f.preferred.RoundTrip(r)
+		if err == nil {
+			atomic.AddUint64(&f.preferredCount, 1)
+			return resp, nil
+		}
+
+		// The request body, if any, was already consumed by the failed
+		// attempt above. Only retry with fallback if it can be rewound; a
+		// request without a body is always safe to retry.
+		if r.Body != nil {
+			if r.GetBody == nil {
+				return nil, err
+			}
+			body, berr := r.GetBody()
+			if berr != nil {
+				return nil, err
+			}
+			r = r.Clone(r.Context())
+			r.Body = body
+		}
+	}
+
+	atomic.AddUint64(&f.fallbackCount, 1)
+	return f.fallback.RoundTrip(r)
+}
+
+// Stats returns the number of requests completed by the preferred and
+// fallback transports so far, for reporting protocol-selection metrics.
+func (f *ProtocolFallback) Stats() (preferred, fallback uint64) {
+	return atomic.LoadUint64(&f.preferredCount), atomic.LoadUint64(&f.fallbackCount)
+}
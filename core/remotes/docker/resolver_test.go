@@ -41,6 +41,7 @@ import (
 	"github.com/containerd/containerd/v2/core/remotes"
 	"github.com/containerd/containerd/v2/core/remotes/docker/auth"
 	remoteerrors "github.com/containerd/containerd/v2/core/remotes/errors"
+	"github.com/containerd/containerd/v2/core/transfer"
 )
 
 func TestHTTPResolver(t *testing.T) {
@@ -58,6 +59,32 @@ func TestHTTPSResolver(t *testing.T) {
 	runBasicTest(t, "testname", tlsServer)
 }
 
+// TestSHA512DigestAvailable ensures sha384 and sha512 digests can be
+// validated and computed from this package, not just sha256. Algorithm
+// availability is process-global (it depends on crypto/sha512 having been
+// imported somewhere), so this would silently regress if that import were
+// ever dropped.
+func TestSHA512DigestAvailable(t *testing.T) {
+	if !digest.SHA384.Available() {
+		t.Fatal("expected SHA384 to be available")
+	}
+	if !digest.SHA512.Available() {
+		t.Fatal("expected SHA512 to be available")
+	}
+
+	p := []byte("containerd")
+	dgst := digest.SHA512.FromBytes(p)
+	if err := dgst.Validate(); err != nil {
+		t.Fatalf("expected a sha512 digest to validate: %v", err)
+	}
+
+	verifier := dgst.Verifier()
+	verifier.Write(p)
+	if !verifier.Verified() {
+		t.Fatal("expected sha512 verifier to confirm matching content")
+	}
+}
+
 func TestResolverOptionsRace(t *testing.T) {
 	header := http.Header{}
 	header.Set("X-Test", "test")
@@ -82,6 +109,49 @@ func TestResolverOptionsRace(t *testing.T) {
 	}
 }
 
+func TestAcceptMediaTypesOverride(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		name = "testname"
+		tag  = "latest"
+		r    = http.NewServeMux()
+	)
+
+	m := newManifest(
+		newContent(ocispec.MediaTypeImageConfig, []byte("1")),
+		newContent(ocispec.MediaTypeImageLayerGzip, []byte("2")),
+	)
+	mc := newContent(ocispec.MediaTypeImageManifest, m.OCIManifest())
+	m.RegisterHandler(r, name)
+
+	var acceptHeader string
+	manifestPath := fmt.Sprintf("/v2/%s/manifests/%s", name, tag)
+	r.HandleFunc(manifestPath, func(rw http.ResponseWriter, req *http.Request) {
+		acceptHeader = req.Header.Get("Accept")
+		mc.ServeHTTP(rw, req)
+	})
+
+	s := httptest.NewServer(logHandler{t, r})
+	defer s.Close()
+
+	resolver := NewResolver(ResolverOptions{})
+	withOptions, ok := resolver.(remotes.ResolverWithOptions)
+	if !ok {
+		t.Fatal("resolver does not implement ResolverWithOptions")
+	}
+	withOptions.SetOptions(transfer.WithAcceptMediaTypes(ocispec.MediaTypeImageManifest, ocispec.MediaTypeImageIndex))
+
+	image := fmt.Sprintf("%s/%s:%s", s.URL[7:], name, tag)
+	if _, _, err := resolver.Resolve(ctx, image); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := strings.Join([]string{ocispec.MediaTypeImageManifest, ocispec.MediaTypeImageIndex}, ", ")
+	if acceptHeader != expected {
+		t.Fatalf("unexpected Accept header: %q, expected %q", acceptHeader, expected)
+	}
+}
+
 func TestBasicResolver(t *testing.T) {
 	basicAuth := func(h http.Handler) (string, ResolverOptions, func()) {
 		// Wrap with basic auth
@@ -510,6 +580,69 @@ func TestHTTPFallbackPortError(t *testing.T) {
 
 }
 
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestProtocolFallback(t *testing.T) {
+	ok := &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+
+	t.Run("uses preferred when it succeeds", func(t *testing.T) {
+		fallback := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			t.Fatal("fallback should not be used")
+			return nil, nil
+		})
+		preferred := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			return ok, nil
+		})
+
+		req, err := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f := NewProtocolFallback(preferred, fallback)
+		if _, err := f.RoundTrip(req); err != nil {
+			t.Fatal(err)
+		}
+
+		preferredCount, fallbackCount := f.Stats()
+		if preferredCount != 1 || fallbackCount != 0 {
+			t.Fatalf("unexpected stats: preferred=%d fallback=%d", preferredCount, fallbackCount)
+		}
+	})
+
+	t.Run("falls back when preferred fails", func(t *testing.T) {
+		preferred := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			return nil, errors.New("protocol not supported")
+		})
+		fallback := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			return ok, nil
+		})
+
+		req, err := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f := NewProtocolFallback(preferred, fallback)
+		resp, err := f.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp != ok {
+			t.Fatal("expected response from fallback transport")
+		}
+
+		preferredCount, fallbackCount := f.Stats()
+		if preferredCount != 0 || fallbackCount != 1 {
+			t.Fatalf("unexpected stats: preferred=%d fallback=%d", preferredCount, fallbackCount)
+		}
+	})
+}
+
 func TestResolveProxy(t *testing.T) {
 	var (
 		ctx  = context.Background()
@@ -0,0 +1,126 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package docker
+
+import (
+	"sync"
+	"time"
+)
+
+// HostStats is a point-in-time snapshot of the requests a resolver, fetcher,
+// or pusher has sent to a single registry host. It is deliberately narrow:
+// this package has no circuit breaker and no client that persists across
+// pulls, so there is no breaker state or connection count to report here,
+// only what every request already passes through do().
+type HostStats struct {
+	Host string
+
+	// Requests and Errors count every request sent to Host, successful or
+	// not; Errors is also included in Requests.
+	Requests uint64
+	Errors   uint64
+
+	// LastStatus is the HTTP status code of the most recent response, or 0
+	// if the most recent request never got a response (e.g. a dial
+	// failure).
+	LastStatus int
+
+	// LastError is the error from the most recent failed request, if any.
+	LastError   string
+	LastErrorAt time.Time
+
+	// LastLatency is the wall-clock time the most recent request took,
+	// measured from just before the request was sent to just after the
+	// response (or error) came back, headers only, not body.
+	LastLatency time.Duration
+}
+
+// StatsTracker accumulates HostStats across every request it is given,
+// across as many resolvers, fetchers, and pushers as are constructed with
+// it set as ResolverOptions.StatsTracker. It exists for on-node debugging,
+// e.g. a "ctr registry status" command, not as a general metrics pipeline.
+type StatsTracker struct {
+	mu    sync.Mutex
+	hosts map[string]*HostStats
+}
+
+// NewStatsTracker returns an empty StatsTracker ready to be passed as
+// ResolverOptions.StatsTracker.
+func NewStatsTracker() *StatsTracker {
+	return &StatsTracker{hosts: map[string]*HostStats{}}
+}
+
+// record updates the stats for host with the outcome of one request. A nil
+// StatsTracker is valid and simply discards the record, so callers never
+// need to nil-check before recording.
+func (t *StatsTracker) record(host string, status int, latency time.Duration, err error) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.hosts[host]
+	if !ok {
+		s = &HostStats{Host: host}
+		t.hosts[host] = s
+	}
+
+	s.Requests++
+	s.LastStatus = status
+	s.LastLatency = latency
+	if err != nil {
+		s.Errors++
+		s.LastError = err.Error()
+		s.LastErrorAt = time.Now()
+	}
+}
+
+// Stats returns a snapshot of every host this tracker has recorded a
+// request for, in no particular order. A nil StatsTracker returns nil.
+func (t *StatsTracker) Stats() []HostStats {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	out := make([]HostStats, 0, len(t.hosts))
+	for _, s := range t.hosts {
+		out = append(out, *s)
+	}
+	return out
+}
+
+// Host returns the recorded stats for a single host, and whether any
+// requests have been recorded for it at all.
+func (t *StatsTracker) Host(host string) (HostStats, bool) {
+	if t == nil {
+		return HostStats{}, false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.hosts[host]
+	if !ok {
+		return HostStats{}, false
+	}
+	return *s, true
+}
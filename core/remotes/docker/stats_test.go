@@ -0,0 +1,74 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package docker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStatsTrackerRecord(t *testing.T) {
+	tracker := NewStatsTracker()
+
+	tracker.record("registry.example.com", 200, time.Millisecond, nil)
+	tracker.record("registry.example.com", 500, 2*time.Millisecond, errors.New("boom"))
+	tracker.record("mirror.example.com", 200, time.Millisecond, nil)
+
+	s, ok := tracker.Host("registry.example.com")
+	if !ok {
+		t.Fatal("expected stats for registry.example.com")
+	}
+	if s.Requests != 2 {
+		t.Fatalf("Requests = %d, want 2", s.Requests)
+	}
+	if s.Errors != 1 {
+		t.Fatalf("Errors = %d, want 1", s.Errors)
+	}
+	if s.LastStatus != 500 {
+		t.Fatalf("LastStatus = %d, want 500", s.LastStatus)
+	}
+	if s.LastError != "boom" {
+		t.Fatalf("LastError = %q, want %q", s.LastError, "boom")
+	}
+	if s.LastErrorAt.IsZero() {
+		t.Fatal("expected LastErrorAt to be set")
+	}
+
+	if _, ok := tracker.Host("unknown.example.com"); ok {
+		t.Fatal("expected no stats for a host that was never recorded")
+	}
+
+	if got, want := len(tracker.Stats()), 2; got != want {
+		t.Fatalf("Stats() returned %d hosts, want %d", got, want)
+	}
+}
+
+func TestStatsTrackerNil(t *testing.T) {
+	var tracker *StatsTracker
+
+	// A nil tracker must be safe to use: ResolverOptions.StatsTracker is
+	// optional, and every call site records unconditionally.
+	tracker.record("registry.example.com", 200, time.Millisecond, nil)
+
+	if tracker.Stats() != nil {
+		t.Fatal("expected nil Stats() from a nil tracker")
+	}
+	if _, ok := tracker.Host("registry.example.com"); ok {
+		t.Fatal("expected no stats from a nil tracker")
+	}
+}
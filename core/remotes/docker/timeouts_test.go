@@ -0,0 +1,164 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package docker
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func testRequest(t *testing.T, server *httptest.Server, op operationClass, timeouts *OperationTimeouts) *request {
+	t.Helper()
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &request{
+		method: http.MethodGet,
+		path:   "/",
+		host: RegistryHost{
+			Client:   server.Client(),
+			Host:     u.Host,
+			Scheme:   u.Scheme,
+			Timeouts: timeouts,
+		},
+		op: op,
+	}
+}
+
+func TestBlobFetchFirstByteTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte("too late"))
+	}))
+	defer server.Close()
+
+	req := testRequest(t, server, opBlobFetch, &OperationTimeouts{BlobFetchFirstByte: 20 * time.Millisecond})
+
+	_, err := req.do(context.Background())
+	if err == nil {
+		t.Fatal("expected an error from a response that never arrives within BlobFetchFirstByte")
+	}
+}
+
+func TestBlobFetchIdleTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, _ := w.(http.Flusher)
+		w.Write([]byte("first chunk"))
+		if flusher != nil {
+			flusher.Flush()
+		}
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte("second chunk, arrives too late"))
+	}))
+	defer server.Close()
+
+	req := testRequest(t, server, opBlobFetch, &OperationTimeouts{BlobFetchIdle: 20 * time.Millisecond})
+
+	resp, err := req.do(context.Background())
+	if err != nil {
+		t.Fatalf("expected headers to arrive before any idle timeout: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.ReadAll(resp.Body); err == nil {
+		t.Fatal("expected reading the body to fail once the idle timeout elapses without progress")
+	}
+}
+
+func TestBlobFetchIdleTimeoutResetsOnProgress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher := w.(http.Flusher)
+		for i := 0; i < 5; i++ {
+			w.Write([]byte("chunk"))
+			flusher.Flush()
+			time.Sleep(15 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+
+	req := testRequest(t, server, opBlobFetch, &OperationTimeouts{BlobFetchIdle: 100 * time.Millisecond})
+
+	resp, err := req.do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("expected a steady trickle of chunks, each well within the idle timeout, to succeed: %v", err)
+	}
+	if got, want := string(body), "chunkchunkchunkchunkchunk"; got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+}
+
+func TestManifestFetchTimeoutIsEndToEnd(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher := w.(http.Flusher)
+		w.Write([]byte("first chunk"))
+		flusher.Flush()
+		// A manifest fetch has no idle/first-byte split: even though this
+		// keeps progressing, the single end-to-end timeout still applies.
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte("second chunk"))
+	}))
+	defer server.Close()
+
+	req := testRequest(t, server, opManifestFetch, &OperationTimeouts{ManifestFetch: 30 * time.Millisecond})
+
+	resp, err := req.do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.ReadAll(resp.Body); err == nil {
+		t.Fatal("expected the end-to-end ManifestFetch timeout to abort the body read")
+	}
+}
+
+func TestNoTimeoutsConfigured(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	req := testRequest(t, server, opBlobFetch, nil)
+
+	resp, err := req.do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "ok" {
+		t.Fatalf("body = %q, want %q", string(body), "ok")
+	}
+}
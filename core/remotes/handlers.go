@@ -28,6 +28,7 @@ import (
 	"github.com/containerd/containerd/v2/core/content"
 	"github.com/containerd/containerd/v2/core/images"
 	"github.com/containerd/containerd/v2/pkg/labels"
+	"github.com/containerd/containerd/v2/pkg/namespaces"
 	"github.com/containerd/errdefs"
 	"github.com/containerd/log"
 	"github.com/containerd/platforms"
@@ -110,8 +111,54 @@ func FetchHandler(ingester content.Ingester, fetcher Fetcher) images.HandlerFunc
 	}
 }
 
+// fetchResult fans in concurrent Fetch calls for the same digest within this
+// process, the same way authHandler's scopedTokens caches in-flight token
+// requests in the docker resolver.
+type fetchResult struct {
+	sync.WaitGroup
+	err error
+}
+
+var (
+	inFlightFetchesMu sync.Mutex
+	inFlightFetches   = map[string]*fetchResult{}
+)
+
 // Fetch fetches the given digest into the provided ingester
 func Fetch(ctx context.Context, ingester content.Ingester, fetcher Fetcher, desc ocispec.Descriptor) error {
+	// Multiple concurrent pulls that share a layer each independently race
+	// into content.OpenWriter for the same ref, where only the ingest lock
+	// actually stops duplicate work - and only after each has already paid
+	// for resolving/opening its own fetch. Namespace the digest so pulls
+	// into different namespaces never share a result, attach any fetch
+	// already in flight for this digest to that single download instead.
+	ns, _ := namespaces.Namespace(ctx)
+	key := ns + "@" + desc.Digest.String()
+
+	inFlightFetchesMu.Lock()
+	if r, ok := inFlightFetches[key]; ok {
+		inFlightFetchesMu.Unlock()
+		log.G(ctx).Debug("attaching to in-flight fetch for shared digest")
+		r.Wait()
+		return r.err
+	}
+	r := &fetchResult{}
+	r.Add(1)
+	inFlightFetches[key] = r
+	inFlightFetchesMu.Unlock()
+
+	defer func() {
+		inFlightFetchesMu.Lock()
+		delete(inFlightFetches, key)
+		inFlightFetchesMu.Unlock()
+	}()
+
+	r.err = fetch(ctx, ingester, fetcher, desc)
+	r.Done()
+	return r.err
+}
+
+func fetch(ctx context.Context, ingester content.Ingester, fetcher Fetcher, desc ocispec.Descriptor) error {
 	log.G(ctx).Debug("fetch")
 
 	cw, err := content.OpenWriter(ctx, ingester, content.WithRef(MakeRefKey(ctx, desc)), content.WithDescriptor(desc))
@@ -302,6 +349,31 @@ func SkipNonDistributableBlobs(f images.HandlerFunc) images.HandlerFunc {
 	}
 }
 
+// SkipAttestationManifests returns a handler that excludes manifests
+// annotated by the producer (e.g. BuildKit) as carrying build attestations
+// rather than platform image content, so neither the attestation manifest
+// nor its config and layers are fetched. This applies regardless of any
+// platform matcher, since attestation manifests typically aren't tied to a
+// single real platform.
+func SkipAttestationManifests(f images.HandlerFunc) images.HandlerFunc {
+	return func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		children, err := f(ctx, desc)
+		if err != nil {
+			return nil, err
+		}
+
+		out := make([]ocispec.Descriptor, 0, len(children))
+		for _, child := range children {
+			if images.IsAttestationManifest(child) {
+				log.G(ctx).WithField("digest", child.Digest).Debug("Skipping attestation manifest")
+				continue
+			}
+			out = append(out, child)
+		}
+		return out, nil
+	}
+}
+
 // FilterManifestByPlatformHandler allows Handler to handle non-target
 // platform's manifest and configuration data.
 func FilterManifestByPlatformHandler(f images.HandlerFunc, m platforms.Matcher) images.HandlerFunc {
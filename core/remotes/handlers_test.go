@@ -17,14 +17,19 @@
 package remotes
 
 import (
+	"bytes"
 	"context"
 	_ "crypto/sha256"
 	"encoding/json"
+	"io"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/containerd/containerd/v2/core/content"
 	"github.com/containerd/containerd/v2/core/images"
+	"github.com/containerd/containerd/v2/pkg/namespaces"
 	"github.com/containerd/containerd/v2/plugins/content/local"
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
@@ -171,6 +176,34 @@ func TestSkipNonDistributableBlobs(t *testing.T) {
 	}
 }
 
+func TestSkipAttestationManifests(t *testing.T) {
+	ctx := context.Background()
+
+	out, err := SkipAttestationManifests(images.HandlerFunc(func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		return []ocispec.Descriptor{
+			{MediaType: ocispec.MediaTypeImageManifest, Digest: "test:1", Platform: &ocispec.Platform{OS: "linux", Architecture: "amd64"}},
+			{
+				MediaType: ocispec.MediaTypeImageManifest,
+				Digest:    "test:2",
+				Platform:  &ocispec.Platform{OS: "unknown", Architecture: "unknown"},
+				Annotations: map[string]string{
+					images.AnnotationDockerReferenceType: images.AttestationManifestReferenceType,
+				},
+			},
+		}, nil
+	}))(ctx, ocispec.Descriptor{MediaType: ocispec.MediaTypeImageIndex})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(out) != 1 {
+		t.Fatalf("unexpected number of descriptors returned: %d", len(out))
+	}
+	if out[0].Digest != "test:1" {
+		t.Fatalf("unexpected digest returned: %s", out[0].Digest)
+	}
+}
+
 type memoryLabelStore struct {
 	l      sync.Mutex
 	labels map[digest.Digest]map[string]string
@@ -216,3 +249,92 @@ func (mls *memoryLabelStore) Update(d digest.Digest, update map[string]string) (
 
 	return labels, nil
 }
+
+// slowFetcher serves a fixed blob, counting how many times Fetch was
+// actually called and blocking for delay before returning each one, to give
+// concurrent Fetch callers a window in which to race.
+type slowFetcher struct {
+	data  []byte
+	delay time.Duration
+	calls atomic.Int32
+}
+
+func (f *slowFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	f.calls.Add(1)
+	time.Sleep(f.delay)
+	return io.NopCloser(bytes.NewReader(f.data)), nil
+}
+
+// TestFetchDedupesConcurrentSharedDigest verifies that concurrent Fetch
+// calls for the same digest in the same namespace attach to a single
+// in-flight download rather than each calling the fetcher independently,
+// and that a different namespace is not affected by the dedup.
+func TestFetchDedupesConcurrentSharedDigest(t *testing.T) {
+	dir := t.TempDir()
+	cs, err := local.NewLabeledStore(dir, newMemoryLabelStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("shared layer contents")
+	dgst := digest.SHA256.FromBytes(data)
+	desc := ocispec.Descriptor{
+		MediaType: images.MediaTypeDockerSchema2Layer,
+		Digest:    dgst,
+		Size:      int64(len(data)),
+	}
+
+	fetcher := &slowFetcher{data: data, delay: 50 * time.Millisecond}
+
+	ctx := namespaces.WithNamespace(context.Background(), "ns1")
+
+	const concurrency = 5
+	var wg sync.WaitGroup
+	errs := make([]error, concurrency)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = Fetch(ctx, cs, fetcher, desc)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("fetch %d failed: %v", i, err)
+		}
+	}
+	if calls := fetcher.calls.Load(); calls != 1 {
+		t.Fatalf("expected exactly 1 underlying fetch for a shared digest, got %d", calls)
+	}
+
+	ra, err := cs.ReaderAt(ctx, desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ra.Close()
+	got := make([]byte, len(data))
+	if _, err := ra.ReadAt(got, 0); err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(data) {
+		t.Fatalf("unexpected content committed: %q", got)
+	}
+
+	// A different namespace must not be short-circuited by the first
+	// namespace's in-flight fetch, even for the exact same digest.
+	otherDir := t.TempDir()
+	otherCS, err := local.NewLabeledStore(otherDir, newMemoryLabelStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherFetcher := &slowFetcher{data: data, delay: 0}
+	otherCtx := namespaces.WithNamespace(context.Background(), "ns2")
+	if err := Fetch(otherCtx, otherCS, otherFetcher, desc); err != nil {
+		t.Fatal(err)
+	}
+	if calls := otherFetcher.calls.Load(); calls != 1 {
+		t.Fatalf("expected the other namespace to fetch independently, got %d calls", calls)
+	}
+}
@@ -22,6 +22,10 @@
 // Setting the LogPathLabel on a container will setup the task's IO to be redirected
 // to a log file when running a task within the restart manager.
 //
+// Setting the BackoffLabel on a container instructs the restart monitor to
+// space out repeated restart attempts instead of retrying immediately; see
+// NextBackoff.
+//
 // The restart labels can be cleared off of a container using the WithNoRestarts Opt.
 //
 // The restart monitor has one option in the containerd config under the [plugins.restart]
@@ -35,6 +39,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	containerd "github.com/containerd/containerd/v2/client"
 	"github.com/containerd/containerd/v2/core/containers"
@@ -53,8 +58,22 @@ const (
 	CountLabel = "containerd.io/restart.count"
 	// ExplicitlyStoppedLabel sets the restart explicitly stopped label for a container
 	ExplicitlyStoppedLabel = "containerd.io/restart.explicitly-stopped"
+	// BackoffLabel sets the base restart backoff duration for a container,
+	// e.g. "1s". The delay between restart attempts doubles after each
+	// attempt, up to the cap described by NextBackoff. A container with no
+	// BackoffLabel set restarts immediately, as it always has.
+	BackoffLabel = "containerd.io/restart.backoff"
+	// LastStartLabel records the time, in RFC3339Nano, that the restart
+	// monitor last (re)started the container's task. It is maintained by
+	// the restart monitor and used together with BackoffLabel to decide
+	// whether enough time has elapsed to attempt another restart.
+	LastStartLabel = "containerd.io/restart.laststart"
 )
 
+// maxBackoff caps the exponential growth applied by NextBackoff so that a
+// crash-looping container never waits longer than this between attempts.
+const maxBackoff = 1 * time.Minute
+
 // Policy represents the restart policies of a container.
 type Policy struct {
 	name              string
@@ -121,9 +140,10 @@ func Reconcile(status containerd.Status, labels map[string]string) bool {
 		log.L.WithError(err).Error("policy reconcile")
 		return false
 	}
+	var due bool
 	switch rp.Name() {
 	case "", "always":
-		return true
+		due = true
 	case "on-failure":
 		restartCount, err := strconv.Atoi(labels[CountLabel])
 		if err != nil && labels[CountLabel] != "" {
@@ -131,15 +151,57 @@ func Reconcile(status containerd.Status, labels map[string]string) bool {
 			return false
 		}
 		if status.ExitStatus != 0 && (rp.maximumRetryCount == 0 || restartCount < rp.maximumRetryCount) {
-			return true
+			due = true
 		}
 	case "unless-stopped":
 		explicitlyStopped, _ := strconv.ParseBool(labels[ExplicitlyStoppedLabel])
 		if !explicitlyStopped {
-			return true
+			due = true
 		}
 	}
-	return false
+	if !due {
+		return false
+	}
+	return backoffElapsed(labels, time.Now())
+}
+
+// NextBackoff returns the delay to wait before the next restart attempt,
+// given the configured base backoff duration and the number of restarts
+// already recorded for the container. The delay doubles for every prior
+// restart and is capped at maxBackoff. A non-positive base disables
+// backoff entirely, returning 0.
+func NextBackoff(base time.Duration, restartCount int) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+	if restartCount < 0 {
+		restartCount = 0
+	}
+	d := base
+	for i := 0; i < restartCount && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
+
+// backoffElapsed reports whether enough time has passed since the last
+// restart attempt for another one to be due, based on BackoffLabel,
+// LastStartLabel, and CountLabel. Containers with no valid BackoffLabel or
+// LastStartLabel restart immediately, preserving the pre-existing behavior.
+func backoffElapsed(labels map[string]string, now time.Time) bool {
+	base, err := time.ParseDuration(labels[BackoffLabel])
+	if err != nil || base <= 0 {
+		return true
+	}
+	last, err := time.Parse(time.RFC3339Nano, labels[LastStartLabel])
+	if err != nil {
+		return true
+	}
+	restartCount, _ := strconv.Atoi(labels[CountLabel])
+	return now.Sub(last) >= NextBackoff(base, restartCount)
 }
 
 // WithLogURI sets the specified log uri for a container.
@@ -174,6 +236,16 @@ func WithPolicy(policy *Policy) func(context.Context, *containerd.Client, *conta
 	}
 }
 
+// WithBackoff sets the base restart backoff duration for a container. See
+// NextBackoff for how the delay grows between repeated restart attempts.
+func WithBackoff(base time.Duration) func(context.Context, *containerd.Client, *containers.Container) error {
+	return func(_ context.Context, _ *containerd.Client, c *containers.Container) error {
+		ensureLabels(c)
+		c.Labels[BackoffLabel] = base.String()
+		return nil
+	}
+}
+
 // WithNoRestarts clears any restart information from the container
 func WithNoRestarts(_ context.Context, _ *containerd.Client, c *containers.Container) error {
 	if c.Labels == nil {
@@ -182,6 +254,8 @@ func WithNoRestarts(_ context.Context, _ *containerd.Client, c *containers.Conta
 	delete(c.Labels, StatusLabel)
 	delete(c.Labels, PolicyLabel)
 	delete(c.Labels, LogURILabel)
+	delete(c.Labels, BackoffLabel)
+	delete(c.Labels, LastStartLabel)
 	return nil
 }
 
@@ -18,6 +18,7 @@ package restart
 
 import (
 	"testing"
+	"time"
 
 	containerd "github.com/containerd/containerd/v2/client"
 	"github.com/stretchr/testify/assert"
@@ -219,3 +220,70 @@ func TestRestartPolicyReconcile(t *testing.T) {
 		assert.Equal(t, testCase.want, result, testCase)
 	}
 }
+
+func TestNextBackoff(t *testing.T) {
+	tests := []struct {
+		base         time.Duration
+		restartCount int
+		want         time.Duration
+	}{
+		{base: 0, restartCount: 0, want: 0},
+		{base: time.Second, restartCount: 0, want: time.Second},
+		{base: time.Second, restartCount: 1, want: 2 * time.Second},
+		{base: time.Second, restartCount: 2, want: 4 * time.Second},
+		{base: time.Second, restartCount: -1, want: time.Second},
+		{base: time.Second, restartCount: 20, want: maxBackoff},
+	}
+	for _, testCase := range tests {
+		assert.Equal(t, testCase.want, NextBackoff(testCase.base, testCase.restartCount), testCase)
+	}
+}
+
+func TestRestartPolicyReconcileBackoff(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   bool
+	}{
+		{
+			name: "restart due immediately when no backoff configured",
+			labels: map[string]string{
+				PolicyLabel: "always",
+			},
+			want: true,
+		},
+		{
+			name: "restart withheld within the backoff window",
+			labels: map[string]string{
+				PolicyLabel:    "always",
+				BackoffLabel:   "1m",
+				LastStartLabel: now.Format(time.RFC3339Nano),
+			},
+			want: false,
+		},
+		{
+			name: "restart allowed once the backoff window elapses",
+			labels: map[string]string{
+				PolicyLabel:    "always",
+				BackoffLabel:   "1s",
+				LastStartLabel: now.Add(-2 * time.Second).Format(time.RFC3339Nano),
+			},
+			want: true,
+		},
+		{
+			name: "later backoff window grows with restart count",
+			labels: map[string]string{
+				PolicyLabel:    "always",
+				BackoffLabel:   "1s",
+				CountLabel:     "3",
+				LastStartLabel: now.Add(-2 * time.Second).Format(time.RFC3339Nano),
+			},
+			want: false,
+		},
+	}
+	for _, testCase := range tests {
+		result := Reconcile(containerd.Status{Status: containerd.Stopped}, testCase.labels)
+		assert.Equal(t, testCase.want, result, testCase.name)
+	}
+}
@@ -20,7 +20,6 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	gruntime "runtime"
@@ -40,6 +39,7 @@ type shimBinaryConfig struct {
 	address      string
 	ttrpcAddress string
 	env          []string
+	logConfig    ShimLogConfig
 }
 
 func shimBinary(bundle *Bundle, config shimBinaryConfig) *binary {
@@ -49,6 +49,7 @@ func shimBinary(bundle *Bundle, config shimBinaryConfig) *binary {
 		containerdAddress:      config.address,
 		containerdTTRPCAddress: config.ttrpcAddress,
 		env:                    config.env,
+		logConfig:              config.logConfig,
 	}
 }
 
@@ -58,6 +59,7 @@ type binary struct {
 	containerdTTRPCAddress string
 	bundle                 *Bundle
 	env                    []string
+	logConfig              ShimLogConfig
 }
 
 func (b *binary) Start(ctx context.Context, opts *types.Any, onClose func()) (_ *shim, err error) {
@@ -104,7 +106,7 @@ func (b *binary) Start(ctx context.Context, opts *types.Any, onClose func()) (_
 	// copy the shim's logs to containerd's output
 	go func() {
 		defer f.Close()
-		_, err := io.Copy(os.Stderr, f)
+		err := copyShimLog(shimCtx, b.logConfig, b.bundle, ns, b.bundle.ID, f, os.Stderr)
 		// To prevent flood of error messages, the expected error
 		// should be reset, like os.ErrClosed or os.ErrNotExist, which
 		// depends on platform.
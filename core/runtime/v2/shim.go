@@ -51,6 +51,7 @@ import (
 	"github.com/containerd/containerd/v2/pkg/atomicfile"
 	"github.com/containerd/containerd/v2/pkg/dialer"
 	"github.com/containerd/containerd/v2/pkg/identifiers"
+	"github.com/containerd/containerd/v2/pkg/namespaces"
 	"github.com/containerd/containerd/v2/pkg/protobuf"
 	ptypes "github.com/containerd/containerd/v2/pkg/protobuf/types"
 	client "github.com/containerd/containerd/v2/pkg/shim"
@@ -69,7 +70,7 @@ func init() {
 	timeout.Set(shutdownTimeout, 3*time.Second)
 }
 
-func loadShim(ctx context.Context, bundle *Bundle, onClose func()) (_ ShimInstance, retErr error) {
+func loadShim(ctx context.Context, bundle *Bundle, logConfig ShimLogConfig, onClose func()) (_ ShimInstance, retErr error) {
 	shimCtx, cancelShimLog := context.WithCancel(ctx)
 	defer func() {
 		if retErr != nil {
@@ -88,9 +89,10 @@ func loadShim(ctx context.Context, bundle *Bundle, onClose func()) (_ ShimInstan
 	// open the log pipe and block until the writer is ready
 	// this helps with synchronization of the shim
 	// copy the shim's logs to containerd's output
+	ns, _ := namespaces.Namespace(shimCtx)
 	go func() {
 		defer f.Close()
-		_, err := io.Copy(os.Stderr, f)
+		err := copyShimLog(shimCtx, logConfig, bundle, ns, bundle.ID, f, os.Stderr)
 		// To prevent flood of error messages, the expected error
 		// should be reset, like os.ErrClosed or os.ErrNotExist, which
 		// depends on platform.
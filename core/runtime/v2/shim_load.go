@@ -154,9 +154,10 @@ func (m *ShimManager) loadShim(ctx context.Context, bundle *Bundle) error {
 			address:      m.containerdAddress,
 			ttrpcAddress: m.containerdTTRPCAddress,
 			env:          m.env,
+			logConfig:    m.shimLog,
 		})
 	// TODO: It seems we can only call loadShim here if it is a sandbox shim?
-	shim, err := loadShimTask(ctx, bundle, func() {
+	shim, err := loadShimTask(ctx, bundle, m.shimLog, func() {
 		log.G(ctx).WithField("id", id).Info("shim disconnected")
 
 		cleanupAfterDeadShim(context.WithoutCancel(ctx), id, m.shims, m.events, binaryCall)
@@ -190,8 +191,8 @@ func (m *ShimManager) loadShim(ctx context.Context, bundle *Bundle) error {
 	return nil
 }
 
-func loadShimTask(ctx context.Context, bundle *Bundle, onClose func()) (_ *shimTask, retErr error) {
-	shim, err := loadShim(ctx, bundle, onClose)
+func loadShimTask(ctx context.Context, bundle *Bundle, logConfig ShimLogConfig, onClose func()) (_ *shimTask, retErr error) {
+	shim, err := loadShim(ctx, bundle, logConfig, onClose)
 	if err != nil {
 		return nil, err
 	}
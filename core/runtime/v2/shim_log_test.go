@@ -0,0 +1,97 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package v2
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyShimLogText(t *testing.T) {
+	var out strings.Builder
+	r := strings.NewReader("hello\nworld\n")
+
+	err := copyShimLog(context.Background(), ShimLogConfig{}, &Bundle{Path: t.TempDir(), ID: "test"}, "ns", "test", r, &out)
+	require.NoError(t, err)
+	assert.Equal(t, "hello\nworld\n", out.String())
+}
+
+func TestCopyShimLogStructured(t *testing.T) {
+	dir := t.TempDir()
+	r := strings.NewReader("panic: boom\nstack trace line\n")
+
+	err := copyShimLog(context.Background(), ShimLogConfig{Format: "json"}, &Bundle{Path: dir, ID: "test"}, "ns", "test", r, os.Stderr)
+	require.NoError(t, err)
+
+	f, err := os.Open(filepath.Join(dir, "shim.log.json"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	var lines []shimLogRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec shimLogRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &rec))
+		lines = append(lines, rec)
+	}
+	require.Len(t, lines, 2)
+	assert.Equal(t, "panic: boom", lines[0].Msg)
+	assert.Equal(t, "stack trace line", lines[1].Msg)
+	assert.Equal(t, "ns", lines[0].Namespace)
+	assert.Equal(t, "test", lines[0].ID)
+}
+
+func TestRateLimiterCapsWithinWindow(t *testing.T) {
+	l := newRateLimiter(2)
+	assert.True(t, l.Allow())
+	assert.True(t, l.Allow())
+	assert.False(t, l.Allow())
+}
+
+func TestRateLimiterUnlimitedWhenNegative(t *testing.T) {
+	l := newRateLimiter(-1)
+	for i := 0; i < 100; i++ {
+		assert.True(t, l.Allow())
+	}
+}
+
+func TestRotatingFileRotatesOnceOverMaxSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "shim.log.json")
+
+	w, err := newRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("12345678901"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	_, err = os.Stat(path + ".1")
+	assert.NoError(t, err, "expected a rotated backup to exist")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "12345678901", string(data), "write after rotation lands in the fresh active file")
+}
@@ -47,6 +47,9 @@ import (
 type ShimConfig struct {
 	// Env is environment variables added to shim processes
 	Env []string `toml:"env"`
+	// Log configures how the output shims write to their log pipe is
+	// collected by containerd.
+	Log ShimLogConfig `toml:"log"`
 }
 
 func init() {
@@ -81,6 +84,7 @@ func init() {
 				Events:       events,
 				Store:        cs,
 				ShimEnv:      config.Env,
+				ShimLog:      config.Log,
 				SandboxStore: ss,
 			})
 		},
@@ -123,6 +127,7 @@ type ManagerConfig struct {
 	TTRPCAddress string
 	SandboxStore sandbox.Store
 	ShimEnv      []string
+	ShimLog      ShimLogConfig
 }
 
 // NewShimManager creates a manager for v2 shims
@@ -134,6 +139,7 @@ func NewShimManager(config *ManagerConfig) (*ShimManager, error) {
 		events:                 config.Events,
 		containers:             config.Store,
 		env:                    config.ShimEnv,
+		shimLog:                config.ShimLog,
 		sandboxStore:           config.SandboxStore,
 	}
 
@@ -148,6 +154,7 @@ type ShimManager struct {
 	containerdAddress      string
 	containerdTTRPCAddress string
 	env                    []string
+	shimLog                ShimLogConfig
 	shims                  *runtime.NSMap[ShimInstance]
 	events                 *exchange.Exchange
 	containers             containers.Store
@@ -234,7 +241,7 @@ func (m *ShimManager) Start(ctx context.Context, id string, bundle *Bundle, opts
 			return nil, fmt.Errorf("failed to write bootstrap.json for bundle %s: %w", bundle.Path, err)
 		}
 
-		shim, err := loadShim(ctx, bundle, func() {})
+		shim, err := loadShim(ctx, bundle, m.shimLog, func() {})
 		if err != nil {
 			return nil, fmt.Errorf("failed to load sandbox task %q: %w", opts.SandboxID, err)
 		}
@@ -285,6 +292,7 @@ func (m *ShimManager) startShim(ctx context.Context, bundle *Bundle, id string,
 		address:      m.containerdAddress,
 		ttrpcAddress: m.containerdTTRPCAddress,
 		env:          m.env,
+		logConfig:    m.shimLog,
 	})
 	shim, err := b.Start(ctx, typeurl.MarshalProto(topts), func() {
 		log.G(ctx).WithField("id", id).Info("shim disconnected")
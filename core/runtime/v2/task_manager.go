@@ -24,6 +24,7 @@ import (
 	"os"
 	"os/exec"
 	"slices"
+	"time"
 
 	"github.com/containerd/errdefs"
 	"github.com/containerd/log"
@@ -39,6 +40,7 @@ import (
 	"github.com/containerd/containerd/v2/core/runtime"
 	"github.com/containerd/containerd/v2/pkg/protobuf/proto"
 	"github.com/containerd/containerd/v2/pkg/timeout"
+	"github.com/containerd/containerd/v2/pkg/tracing"
 	"github.com/containerd/containerd/v2/plugins"
 )
 
@@ -113,7 +115,20 @@ func (m *TaskManager) ID() string {
 
 // Create launches new shim instance and creates new task
 func (m *TaskManager) Create(ctx context.Context, taskID string, opts runtime.CreateOpts) (_ runtime.Task, retErr error) {
+	span := tracing.SpanFromContext(ctx)
+	stageStart := time.Now()
+	var bundleDuration, shimStartDuration, taskCreateDuration time.Duration
+	defer func() {
+		span.AddEvent("task start latency breakdown",
+			tracing.Attribute("bundle_create.duration", bundleDuration.String()),
+			tracing.Attribute("shim_start.duration", shimStartDuration.String()),
+			tracing.Attribute("task_create.duration", taskCreateDuration.String()),
+			tracing.Attribute("total.duration", time.Since(stageStart).String()),
+		)
+	}()
+
 	bundle, err := NewBundle(ctx, m.root, m.state, taskID, opts.Spec)
+	bundleDuration = time.Since(stageStart)
 	if err != nil {
 		return nil, err
 	}
@@ -123,7 +138,9 @@ func (m *TaskManager) Create(ctx context.Context, taskID string, opts runtime.Cr
 		}
 	}()
 
+	shimStartBegin := time.Now()
 	shim, err := m.manager.Start(ctx, taskID, bundle, opts)
+	shimStartDuration = time.Since(shimStartBegin)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start shim: %w", err)
 	}
@@ -141,6 +158,8 @@ func (m *TaskManager) Create(ctx context.Context, taskID string, opts runtime.Cr
 		return nil, fmt.Errorf("failed to validate OCI runtime features: %w", err)
 	}
 
+	taskCreateBegin := time.Now()
+	defer func() { taskCreateDuration = time.Since(taskCreateBegin) }()
 	t, err := func() (runtime.Task, error) {
 		t, err := shimTask.Create(ctx, opts)
 		if err == nil || !errdefs.IsNotImplemented(err) {
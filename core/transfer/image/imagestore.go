@@ -42,12 +42,20 @@ func init() {
 }
 
 type Store struct {
-	imageName     string
-	imageLabels   map[string]string
-	platforms     []ocispec.Platform
-	allMetadata   bool
-	labelMap      func(ocispec.Descriptor) []string
-	manifestLimit int
+	imageName                 string
+	imageLabels               map[string]string
+	platforms                 []ocispec.Platform
+	allMetadata               bool
+	// skipNonDistributableBlobs and skipAttestationManifests are not
+	// (de)serialized by MarshalAny/UnmarshalAny below, so they currently only
+	// take effect when the image store is used directly in-process (e.g. the
+	// default local transfer service). Wiring them through to the
+	// out-of-process transfer service would require adding fields to
+	// transfertypes.ImageStore and regenerating imagestore.pb.go.
+	skipNonDistributableBlobs bool
+	skipAttestationManifests  bool
+	labelMap                  func(ocispec.Descriptor) []string
+	manifestLimit             int
 
 	// extraReferences are used to store or lookup multiple references
 	extraReferences []Reference
@@ -113,6 +121,21 @@ func WithAllMetadata(s *Store) {
 	s.allMetadata = true
 }
 
+// WithSkipNonDistributableBlobs excludes non-distributable blobs, such as
+// Windows base layers or Docker "foreign" layers, from being fetched.
+func WithSkipNonDistributableBlobs(s *Store) {
+	s.skipNonDistributableBlobs = true
+}
+
+// WithSkipAttestationManifests excludes manifests which only carry build
+// attestations (e.g. BuildKit SBOM/provenance) from being fetched, along with
+// their config and layers. Unlike platform filtering, this applies even when
+// WithAllMetadata is set, since attestation manifests aren't runnable images
+// for any platform.
+func WithSkipAttestationManifests(s *Store) {
+	s.skipAttestationManifests = true
+}
+
 // WithNamedPrefix uses a named prefix to references images which only have a tag name
 // reference in the annotation or check full references annotations against. Images
 // with no reference resolved from matching annotations will not be stored.
@@ -196,6 +219,12 @@ func (is *Store) ImageFilter(h images.HandlerFunc, cs content.Store) images.Hand
 		p = platforms.Ordered(is.platforms...)
 	}
 	h = images.SetChildrenMappedLabels(cs, h, is.labelMap)
+	if is.skipNonDistributableBlobs {
+		h = remotes.SkipNonDistributableBlobs(h)
+	}
+	if is.skipAttestationManifests {
+		h = remotes.SkipAttestationManifests(h)
+	}
 	if is.allMetadata {
 		// Filter manifests by platforms but allow to handle manifest
 		// and configuration for not-target platforms
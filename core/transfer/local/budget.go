@@ -0,0 +1,76 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync/atomic"
+
+	"github.com/containerd/errdefs"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/containerd/containerd/v2/core/remotes"
+)
+
+// byteBudgetFetcher wraps a remotes.Fetcher to enforce a total byte budget
+// shared across every blob fetched through it over the lifetime of a single
+// pull (see transfer.Config.ByteLimit). It lets a pull that is already over
+// budget fail fast on the next fetch, and cuts off an in-progress fetch as
+// soon as reading it would push the running total past the limit.
+type byteBudgetFetcher struct {
+	remotes.Fetcher
+
+	limit    int64
+	consumed atomic.Int64
+}
+
+func newByteBudgetFetcher(fetcher remotes.Fetcher, limit int64) remotes.Fetcher {
+	return &byteBudgetFetcher{Fetcher: fetcher, limit: limit}
+}
+
+func (b *byteBudgetFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	if b.consumed.Load() >= b.limit {
+		return nil, fmt.Errorf("transfer byte budget of %d bytes already exhausted: %w", b.limit, errdefs.ErrResourceExhausted)
+	}
+
+	rc, err := b.Fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &budgetedReadCloser{ReadCloser: rc, fetcher: b}, nil
+}
+
+type budgetedReadCloser struct {
+	io.ReadCloser
+	fetcher *byteBudgetFetcher
+}
+
+func (b *budgetedReadCloser) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		if total := b.fetcher.consumed.Add(int64(n)); total > b.fetcher.limit {
+			// Override whatever the underlying reader reported (including a
+			// clean io.EOF) so the overrun is never mistaken for a
+			// successful read.
+			err = fmt.Errorf("transfer exceeded byte budget of %d bytes: %w", b.fetcher.limit, errdefs.ErrResourceExhausted)
+		}
+	}
+	return n, err
+}
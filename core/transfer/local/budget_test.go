@@ -0,0 +1,117 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/containerd/errdefs"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+type fakeFetcher struct {
+	blobs map[string][]byte
+}
+
+func (f *fakeFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	b, ok := f.blobs[desc.Digest.String()]
+	if !ok {
+		return nil, errdefs.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+// TestByteBudgetFetcherCutsOffOverBudgetRead verifies that a single fetch
+// which would push the running total past the configured limit fails with
+// errdefs.IsResourceExhausted, even though the underlying fetch itself
+// succeeded.
+func TestByteBudgetFetcherCutsOffOverBudgetRead(t *testing.T) {
+	desc := ocispec.Descriptor{Digest: "sha256:deadbeef", Size: 20}
+	fetcher := newByteBudgetFetcher(&fakeFetcher{blobs: map[string][]byte{
+		desc.Digest.String(): bytes.Repeat([]byte("x"), 20),
+	}}, 10)
+
+	rc, err := fetcher.Fetch(context.Background(), desc)
+	if err != nil {
+		t.Fatalf("expected Fetch itself to succeed, got: %v", err)
+	}
+	defer rc.Close()
+
+	_, err = io.ReadAll(rc)
+	if err == nil {
+		t.Fatal("expected reading past the byte budget to fail")
+	}
+	if !errdefs.IsResourceExhausted(err) {
+		t.Fatalf("expected a resource-exhausted error, got: %v", err)
+	}
+}
+
+// TestByteBudgetFetcherRejectsSubsequentFetchesOnceExhausted verifies that
+// once the shared budget has been exceeded, later Fetch calls on the same
+// wrapped fetcher fail fast without touching the underlying fetcher again.
+func TestByteBudgetFetcherRejectsSubsequentFetchesOnceExhausted(t *testing.T) {
+	descA := ocispec.Descriptor{Digest: "sha256:aaaa", Size: 10}
+	descB := ocispec.Descriptor{Digest: "sha256:bbbb", Size: 10}
+
+	underlying := &fakeFetcher{blobs: map[string][]byte{
+		descA.Digest.String(): bytes.Repeat([]byte("a"), 10),
+		descB.Digest.String(): bytes.Repeat([]byte("b"), 10),
+	}}
+	fetcher := newByteBudgetFetcher(underlying, 10)
+
+	rc, err := fetcher.Fetch(context.Background(), descA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(rc); err != nil {
+		t.Fatalf("expected the first fetch to exactly exhaust (not exceed) the budget, got: %v", err)
+	}
+	rc.Close()
+
+	if _, err := fetcher.Fetch(context.Background(), descB); err == nil {
+		t.Fatal("expected the second fetch to be rejected once the budget is exhausted")
+	} else if !errdefs.IsResourceExhausted(err) {
+		t.Fatalf("expected a resource-exhausted error, got: %v", err)
+	}
+}
+
+// TestByteBudgetFetcherAllowsWithinBudget verifies that fetches within the
+// configured budget are unaffected.
+func TestByteBudgetFetcherAllowsWithinBudget(t *testing.T) {
+	desc := ocispec.Descriptor{Digest: "sha256:cafe", Size: 5}
+	data := []byte("hello")
+	fetcher := newByteBudgetFetcher(&fakeFetcher{blobs: map[string][]byte{
+		desc.Digest.String(): data,
+	}}, 1024)
+
+	rc, err := fetcher.Fetch(context.Background(), desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatalf("unexpected content: got %q, want %q", got, data)
+	}
+}
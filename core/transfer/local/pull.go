@@ -36,12 +36,26 @@ import (
 )
 
 func (ts *localTransferService) pull(ctx context.Context, ir transfer.ImageFetcher, is transfer.ImageStorer, tops *transfer.Config) error {
-	ctx, done, err := ts.withLease(ctx)
+	var (
+		done func(context.Context) error
+		err  error
+	)
+	if tops.RetainLease != "" {
+		ctx, done, err = ts.withRetainedLease(ctx, tops.RetainLease, tops.RetainLeaseTTL)
+	} else {
+		ctx, done, err = ts.withLease(ctx)
+	}
 	if err != nil {
 		return err
 	}
 	defer done(ctx)
 
+	if !tops.Deadline.IsZero() {
+		var deadlineCancel context.CancelFunc
+		ctx, deadlineCancel = context.WithDeadline(ctx, tops.Deadline)
+		defer deadlineCancel()
+	}
+
 	if tops.Progress != nil {
 		tops.Progress(transfer.Progress{
 			Event: fmt.Sprintf("Resolving from %s", ir),
@@ -66,29 +80,8 @@ func (ts *localTransferService) pull(ctx context.Context, ir transfer.ImageFetch
 	}
 
 	// Verify image before pulling.
-	for vfName, vf := range ts.config.Verifiers {
-		logger := log.G(ctx).WithFields(log.Fields{
-			"name":     name,
-			"digest":   desc.Digest.String(),
-			"verifier": vfName,
-		})
-		logger.Debug("Verifying image pull")
-
-		jdg, err := vf.VerifyImage(ctx, name, desc)
-		if err != nil {
-			logger.WithError(err).Error("No judgement received from verifier")
-			return fmt.Errorf("blocking pull of %v with digest %v: image verifier %v returned error: %w", name, desc.Digest.String(), vfName, err)
-		}
-		logger = logger.WithFields(log.Fields{
-			"ok":     jdg.OK,
-			"reason": jdg.Reason,
-		})
-
-		if !jdg.OK {
-			logger.Warn("Image verifier blocked pull")
-			return fmt.Errorf("image verifier %s blocked pull of %v with digest %v for reason: %v", vfName, name, desc.Digest.String(), jdg.Reason)
-		}
-		logger.Debug("Image verifier allowed pull")
+	if err := ts.config.VerifierPolicy.Verify(ctx, name, desc); err != nil {
+		return err
 	}
 
 	// TODO: Handle already exists
@@ -106,6 +99,13 @@ func (ts *localTransferService) pull(ctx context.Context, ir transfer.ImageFetch
 	if err != nil {
 		return fmt.Errorf("failed to get fetcher for %q: %w", name, err)
 	}
+	var appendDistSrcLabelOpts []docker.DistributionSourceLabelOpt
+	if fh, ok := fetcher.(docker.FetcherHosts); ok {
+		appendDistSrcLabelOpts = append(appendDistSrcLabelOpts, docker.WithSourceHosts(fh.Hosts()))
+	}
+	if tops.ByteLimit > 0 {
+		fetcher = newByteBudgetFetcher(fetcher, tops.ByteLimit)
+	}
 
 	var (
 		handler images.Handler
@@ -147,7 +147,7 @@ func (ts *localTransferService) pull(ctx context.Context, ir transfer.ImageFetch
 		},
 	)
 
-	appendDistSrcLabelHandler, err := docker.AppendDistributionSourceLabel(store, name)
+	appendDistSrcLabelHandler, err := docker.AppendDistributionSourceLabel(store, name, appendDistSrcLabelOpts...)
 	if err != nil {
 		return err
 	}
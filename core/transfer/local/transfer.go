@@ -162,6 +162,37 @@ func (ts *localTransferService) withLease(ctx context.Context, opts ...leases.Op
 	}, nil
 }
 
+// withRetainedLease attaches ctx to the named lease instead of one created
+// and torn down for this transfer alone (see withLease). It is never
+// deleted here: if the lease doesn't already exist it is created with ttl
+// (or a short default), and if it does, it is reused as-is, so a retry
+// shortly after a cancelled or failed transfer can name the same lease and
+// pick up whatever partial content the earlier attempt left behind rather
+// than finding it already garbage collected.
+func (ts *localTransferService) withRetainedLease(ctx context.Context, id string, ttl time.Duration) (context.Context, func(context.Context) error, error) {
+	nop := func(context.Context) error { return nil }
+
+	_, ok := leases.FromContext(ctx)
+	if ok {
+		return ctx, nop, nil
+	}
+
+	ls := ts.config.Leases
+	if ls == nil {
+		return ctx, nop, nil
+	}
+
+	if ttl <= 0 {
+		ttl = 10 * time.Minute
+	}
+
+	if _, err := ls.Create(ctx, leases.WithID(id), leases.WithExpiration(ttl)); err != nil && !errdefs.IsAlreadyExists(err) {
+		return ctx, nop, err
+	}
+
+	return leases.WithLease(ctx, id), nop, nil
+}
+
 type TransferConfig struct {
 	// Leases manager is used to create leases during operations if none, exists
 	Leases leases.Manager
@@ -194,8 +225,8 @@ type TransferConfig struct {
 	// UnpackPlatforms are used to specify supported combination of platforms and snapshotters
 	UnpackPlatforms []unpack.Platform
 
-	// ImageVerifiers verify the image before saving into the image store.
-	Verifiers map[string]imageverifier.ImageVerifier
+	// VerifierPolicy verifies the image before saving into the image store.
+	VerifierPolicy imageverifier.Policy
 
 	// RegistryConfigPath is a path to the root directory containing registry-specific configurations
 	RegistryConfigPath string
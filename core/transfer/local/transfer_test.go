@@ -0,0 +1,91 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/containerd/errdefs"
+
+	"github.com/containerd/containerd/v2/core/leases"
+)
+
+// fakeLeaseManager is a minimal in-memory leases.Manager, enough to exercise
+// withRetainedLease's create-or-reuse behavior without a real database.
+type fakeLeaseManager struct {
+	leases.Manager
+
+	created map[string]leases.Lease
+}
+
+func (m *fakeLeaseManager) Create(_ context.Context, opts ...leases.Opt) (leases.Lease, error) {
+	var l leases.Lease
+	for _, opt := range opts {
+		if err := opt(&l); err != nil {
+			return leases.Lease{}, err
+		}
+	}
+	if m.created == nil {
+		m.created = map[string]leases.Lease{}
+	}
+	if _, ok := m.created[l.ID]; ok {
+		return leases.Lease{}, errdefs.ErrAlreadyExists
+	}
+	m.created[l.ID] = l
+	return l, nil
+}
+
+func (m *fakeLeaseManager) Delete(_ context.Context, l leases.Lease, _ ...leases.DeleteOpt) error {
+	delete(m.created, l.ID)
+	return nil
+}
+
+func TestWithRetainedLease(t *testing.T) {
+	ls := &fakeLeaseManager{}
+	ts := &localTransferService{config: TransferConfig{Leases: ls}}
+
+	ctx, done, err := ts.withRetainedLease(context.Background(), "retry-me", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lid, ok := leases.FromContext(ctx); !ok || lid != "retry-me" {
+		t.Fatalf("expected lease id %q on context, got %q (ok=%v)", "retry-me", lid, ok)
+	}
+	if _, ok := ls.created["retry-me"]; !ok {
+		t.Fatal("expected lease to be created")
+	}
+
+	// done must not delete the lease: it is the caller's, not ours.
+	if err := done(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ls.created["retry-me"]; !ok {
+		t.Fatal("retained lease should survive done(), only the caller deletes it")
+	}
+
+	// A second call naming the same lease (as a retry would) must reuse it
+	// rather than failing on AlreadyExists.
+	ctx2, _, err := ts.withRetainedLease(context.Background(), "retry-me", time.Minute)
+	if err != nil {
+		t.Fatalf("expected retained lease to be reused, got error: %v", err)
+	}
+	if lid, ok := leases.FromContext(ctx2); !ok || lid != "retry-me" {
+		t.Fatalf("expected lease id %q on context, got %q (ok=%v)", "retry-me", lid, ok)
+	}
+}
@@ -28,6 +28,7 @@ import (
 
 	transferapi "github.com/containerd/containerd/api/types/transfer"
 	"github.com/containerd/containerd/v2/core/streaming"
+	"github.com/containerd/containerd/v2/pkg/archive/compression"
 	"github.com/containerd/log"
 	"github.com/containerd/typeurl/v2"
 )
@@ -42,7 +43,48 @@ var bufPool = &sync.Pool{
 	},
 }
 
-func SendStream(ctx context.Context, r io.Reader, stream streaming.Stream) {
+type sendConfig struct {
+	compress bool
+}
+
+// SendOpt configures the behavior of SendStream.
+type SendOpt func(*sendConfig)
+
+// WithCompression zstd-compresses the stream content before it is broken
+// into window-limited frames and sent, trading CPU for bandwidth on slow
+// links. There is no wire negotiation of this choice: the receiving side
+// must read the stream with the matching WithDecompression ReceiveStream
+// option, the same way the sender and receiver already have to agree on
+// stream id out of band.
+func WithCompression() SendOpt {
+	return func(c *sendConfig) {
+		c.compress = true
+	}
+}
+
+func SendStream(ctx context.Context, r io.Reader, stream streaming.Stream, opts ...SendOpt) {
+	var cfg sendConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.compress {
+		src := r
+		pr, pw := io.Pipe()
+		go func() {
+			zw, err := compression.CompressStream(pw, compression.Zstd)
+			if err != nil {
+				pw.CloseWithError(fmt.Errorf("failed to create zstd compressor: %w", err))
+				return
+			}
+			_, err = io.Copy(zw, src)
+			if cerr := zw.Close(); err == nil {
+				err = cerr
+			}
+			pw.CloseWithError(err)
+		}()
+		r = pr
+	}
+
 	window := make(chan int32)
 	go func() {
 		defer close(window)
@@ -138,7 +180,27 @@ func SendStream(ctx context.Context, r io.Reader, stream streaming.Stream) {
 	}()
 }
 
-func ReceiveStream(ctx context.Context, stream streaming.Stream) io.Reader {
+type recvConfig struct {
+	decompress bool
+}
+
+// RecvOpt configures the behavior of ReceiveStream.
+type RecvOpt func(*recvConfig)
+
+// WithDecompression zstd-decompresses the received stream content. It must
+// be paired with WithCompression on the sending side; see WithCompression.
+func WithDecompression() RecvOpt {
+	return func(c *recvConfig) {
+		c.decompress = true
+	}
+}
+
+func ReceiveStream(ctx context.Context, stream streaming.Stream, opts ...RecvOpt) io.Reader {
+	var cfg recvConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	r, w := io.Pipe()
 	go func() {
 		defer stream.Close()
@@ -199,9 +261,43 @@ func ReceiveStream(ctx context.Context, stream streaming.Stream) io.Reader {
 
 	}()
 
+	if cfg.decompress {
+		return &lazyDecompressReader{r: r}
+	}
+
 	return r
 }
 
+// lazyDecompressReader defers creating the zstd decompressor until the
+// first Read, since compression.DecompressStream peeks the underlying
+// reader for a magic number and would otherwise block ReceiveStream on
+// data that has not been sent yet. It closes the decompressor (releasing
+// the zstd decoder's background goroutines) as soon as reading ends,
+// since ReceiveStream returns a plain io.Reader and callers have no other
+// opportunity to close it.
+type lazyDecompressReader struct {
+	r       *io.PipeReader
+	zr      io.ReadCloser
+	didInit bool
+}
+
+func (r *lazyDecompressReader) Read(p []byte) (int, error) {
+	if !r.didInit {
+		r.didInit = true
+		zr, err := compression.DecompressStream(r.r)
+		if err != nil {
+			r.r.CloseWithError(fmt.Errorf("failed to create zstd decompressor: %w", err))
+			return 0, err
+		}
+		r.zr = zr
+	}
+	n, err := r.zr.Read(p)
+	if err != nil {
+		r.zr.Close()
+	}
+	return n, err
+}
+
 func GenerateID(prefix string) string {
 	t := time.Now()
 	var b [3]byte
@@ -103,6 +103,32 @@ func runWriterFuzz(ctx context.Context, t *testing.T, expected []byte) {
 	}
 }
 
+func TestSendAndReceiveCompressed(t *testing.T) {
+	expected := bytes.Repeat([]byte("hello world, this is compressible "), 1024)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rs, ws := pipeStream()
+	r, w := io.Pipe()
+	SendStream(ctx, r, ws, WithCompression())
+	or := ReceiveStream(ctx, rs, WithDecompression())
+
+	go func() {
+		io.Copy(w, bytes.NewBuffer(expected))
+		w.Close()
+	}()
+
+	actual, err := io.ReadAll(or)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(expected, actual) {
+		t.Fatalf("received bytes are not equal\n\tactual: %v\n\texpected:%v", actual, expected)
+	}
+}
+
 func chainStreams(ctx context.Context, r io.Reader) io.Reader {
 	rs, ws := pipeStream()
 	SendStream(ctx, r, ws)
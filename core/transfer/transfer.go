@@ -19,6 +19,7 @@ package transfer
 import (
 	"context"
 	"io"
+	"time"
 
 	"golang.org/x/sync/semaphore"
 
@@ -46,11 +47,24 @@ type ImageResolverOption func(*ImageResolverOptions)
 type ImageResolverOptions struct {
 	DownloadLimiter *semaphore.Weighted
 	Performances    ImageResolverPerformanceSettings
+
+	// AcceptMediaTypes, when non-empty, overrides the Accept header sent
+	// when resolving a reference, replacing the resolver's own default
+	// (typically both Docker schema2 and OCI manifest/index types plus
+	// "*/*"). Use this to scope resolution to a narrower set, e.g. OCI
+	// manifests and artifact types only, refusing Docker schema2.
+	AcceptMediaTypes []string
 }
 
 type ImageResolverPerformanceSettings struct {
 	MaxConcurrentDownloads     int
 	ConcurrentLayerFetchBuffer int
+
+	// HedgeDelay is the amount of time to wait for a manifest HEAD or blob
+	// GET request to respond before also sending the same request to the
+	// next configured mirror, racing both and using whichever responds
+	// first. A zero value (the default) disables hedging.
+	HedgeDelay time.Duration
 }
 
 func WithDownloadLimiter(limiter *semaphore.Weighted) ImageResolverOption {
@@ -59,6 +73,17 @@ func WithDownloadLimiter(limiter *semaphore.Weighted) ImageResolverOption {
 	}
 }
 
+// WithAcceptMediaTypes overrides the Accept header used when resolving a
+// reference, restricting (or otherwise customizing) which manifest types the
+// resolver will accept instead of its built-in default of Docker schema2 and
+// OCI manifest/index types. Passing no media types has no effect; the
+// resolver's default is left in place.
+func WithAcceptMediaTypes(mediaTypes ...string) ImageResolverOption {
+	return func(opts *ImageResolverOptions) {
+		opts.AcceptMediaTypes = mediaTypes
+	}
+}
+
 func WithMaxConcurrentDownloads(maxConcurrentDownloads int) ImageResolverOption {
 	return func(opts *ImageResolverOptions) {
 		opts.Performances.MaxConcurrentDownloads = maxConcurrentDownloads
@@ -71,6 +96,17 @@ func WithConcurrentLayerFetchBuffer(ConcurrentLayerFetchBuffer int) ImageResolve
 	}
 }
 
+// WithHedgeDelay enables request hedging across mirrors: if a manifest HEAD
+// or blob GET request hasn't responded within delay, the same request is
+// also sent to the next mirror, and whichever responds first is used. This
+// trades extra requests under slow or degraded mirrors for lower tail
+// latency in multi-mirror setups. A zero or negative delay disables hedging.
+func WithHedgeDelay(delay time.Duration) ImageResolverOption {
+	return func(opts *ImageResolverOptions) {
+		opts.Performances.HedgeDelay = delay
+	}
+}
+
 type ImageFetcher interface {
 	ImageResolver
 
@@ -154,6 +190,37 @@ type ProgressFunc func(Progress)
 
 type Config struct {
 	Progress ProgressFunc
+
+	// ByteLimit caps the total number of bytes this transfer may fetch over
+	// the wire. Once the limit is reached, the transfer is aborted with an
+	// error satisfying errdefs.IsResourceExhausted. Zero (the default) means
+	// no limit. Not all Transferrer implementations enforce this; currently
+	// only image pulls do.
+	ByteLimit int64
+
+	// Deadline, when non-zero, bounds how long this transfer may run before
+	// it is aborted with an error satisfying errdefs.IsDeadlineExceeded. It
+	// is applied in addition to any deadline already present on the ctx
+	// passed to Transfer, whichever elapses first wins. Not all Transferrer
+	// implementations enforce this; currently only image pulls do.
+	Deadline time.Time
+
+	// RetainLease names a lease that this transfer's content, including any
+	// partially fetched ingests, should be attached to instead of one
+	// created and deleted for this transfer alone. Unlike that default
+	// lease, it is never deleted when the transfer finishes or fails: a
+	// retry shortly afterwards can pass the same name again and pick up
+	// whatever content survived, bounded only by the lease's own
+	// expiration. If the lease does not already exist it is created with
+	// RetainLeaseTTL; if it does, it is reused as-is. Only honored by
+	// image pulls.
+	RetainLease string
+
+	// RetainLeaseTTL bounds how long a newly created RetainLease keeps
+	// partial content alive before it becomes eligible for garbage
+	// collection. It has no effect when RetainLease is empty or already
+	// exists. Defaults to 10 minutes if unset.
+	RetainLeaseTTL time.Duration
 }
 
 type Opt func(*Config)
@@ -164,6 +231,32 @@ func WithProgress(f ProgressFunc) Opt {
 	}
 }
 
+// WithByteLimit sets the total byte budget for a transfer. See Config.ByteLimit.
+func WithByteLimit(n int64) Opt {
+	return func(opts *Config) {
+		opts.ByteLimit = n
+	}
+}
+
+// WithDeadline sets the deadline for a transfer. See Config.Deadline.
+func WithDeadline(d time.Time) Opt {
+	return func(opts *Config) {
+		opts.Deadline = d
+	}
+}
+
+// WithRetainLease attaches the transfer's content, including any partially
+// fetched ingests, to lease instead of one created and torn down for this
+// transfer alone, so a retry shortly after a cancelled or failed transfer
+// can name the same lease and pick up where it left off. See
+// Config.RetainLease and Config.RetainLeaseTTL.
+func WithRetainLease(lease string, ttl time.Duration) Opt {
+	return func(opts *Config) {
+		opts.RetainLease = lease
+		opts.RetainLeaseTTL = ttl
+	}
+}
+
 // Progress is used to represent a particular progress event or incremental
 // update for the provided named object. The parents represent the names of
 // the objects which initiated the progress for the provided named object.
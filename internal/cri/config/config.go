@@ -61,6 +61,14 @@ const (
 	// [1]: Fast commits for ext4 - https://lwn.net/Articles/842385/
 	// [2]: https://github.com/kubernetes/kubernetes/blob/1635c380b26a1d8cc25d36e9feace9797f4bae3c/cluster/gce/util.sh#L882
 	defaultImagePullProgressTimeoutDuration = 5 * time.Minute
+
+	// defaultImagePullRetryMaxAttempts is the default value of
+	// ImagePullRetryMaxAttempts.
+	defaultImagePullRetryMaxAttempts = 3
+
+	// defaultImagePullRetryBaseDelay is the default value of
+	// ImagePullRetryBaseDelay.
+	defaultImagePullRetryBaseDelay = 250 * time.Millisecond
 )
 
 type SandboxControllerMode string
@@ -293,6 +301,19 @@ type ImagePlatform struct {
 	// while using default snapshotters for operational simplicity.
 	// See https://github.com/containerd/containerd/issues/6657 for details.
 	Snapshotter string `toml:"snapshotter" json:"snapshotter"`
+
+	// Fallbacks is an ordered list of additional platforms to accept for this
+	// runtime if no manifest matches Platform, e.g. a wasm runtime listing an
+	// older wasi preview as a fallback behind its preferred one. Each entry is
+	// tried in order after Platform, and is itself subject to Strict.
+	Fallbacks []string `toml:"fallbacks" json:"fallbacks"`
+
+	// Strict disables containerd's default sub-platform compatibility when
+	// matching Platform and Fallbacks (e.g. arm/v8 also matching arm/v7), so
+	// that only an exact platform match is accepted. This is useful on
+	// mixed-architecture clusters where running the wrong CPU variant would
+	// otherwise go unnoticed.
+	Strict bool `toml:"strict" json:"strict"`
 }
 
 type ImageConfig struct {
@@ -361,6 +382,29 @@ type ImageConfig struct {
 	// When transfer service is used to pull images, pull related configs, like max_concurrent_downloads
 	// and unpack_config are configured under [plugins."io.containerd.transfer.v1.local"]
 	UseLocalImagePull bool `toml:"use_local_image_pull" json:"useLocalImagePull"`
+
+	// ImagePullRetryMaxAttempts is the maximum number of times PullImage will
+	// attempt a pull before giving up, when the failure looks transient (DNS
+	// resolution, connection timeouts, 5xx registry responses). Auth
+	// failures and missing images are never retried regardless of this
+	// setting. A value of 1 or less disables retrying.
+	ImagePullRetryMaxAttempts int `toml:"image_pull_retry_max_attempts" json:"imagePullRetryMaxAttempts"`
+
+	// ImagePullRetryBaseDelay is the base delay used for the jittered
+	// exponential backoff between image pull retries. It doubles on each
+	// attempt, capped at one minute.
+	//
+	// The string is in the golang duration format, see:
+	//   https://golang.org/pkg/time/#ParseDuration
+	ImagePullRetryBaseDelay string `toml:"image_pull_retry_base_delay" json:"imagePullRetryBaseDelay"`
+
+	// VerifierNamespaces restricts an image verifier plugin, by ID, to the
+	// listed Kubernetes/CRI namespaces. A verifier with no entry here runs
+	// for every namespace. This only applies when use_local_image_pull is
+	// true; when pulling through the transfer service, configure verifier
+	// namespace scoping under [plugins."io.containerd.transfer.v1.local"]
+	// instead.
+	VerifierNamespaces map[string][]string `toml:"verifier_namespaces" json:"verifierNamespaces"`
 }
 
 // RuntimeConfig contains toml config related to CRI plugin,
@@ -392,6 +436,14 @@ type RuntimeConfig struct {
 	// UnsetSeccompProfile is the profile containerd/cri will use If the provided seccomp profile is
 	// unset (`""`) for a container (default is `unconfined`)
 	UnsetSeccompProfile string `toml:"unset_seccomp_profile" json:"unsetSeccompProfile"`
+	// SeccompAgentSocket is the unix socket of an external agent that seccomp user-notification
+	// fds are forwarded to for syscall mediation. Leave unset to disable forwarding; containers
+	// then run with only the seccomp profile's own actions.
+	SeccompAgentSocket string `toml:"seccomp_agent_socket" json:"seccompAgentSocket"`
+	// SeccompAgentDeathPolicy controls what happens to a container if SeccompAgentSocket is set
+	// but unreachable: "ignore" leaves the container running without syscall mediation, "kill"
+	// terminates it. Defaults to "ignore".
+	SeccompAgentDeathPolicy string `toml:"seccomp_agent_death_policy" json:"seccompAgentDeathPolicy"`
 	// TolerateMissingHugetlbController if set to false will error out on create/update
 	// container requests with huge page limits if the cgroup controller for hugepages is not present.
 	// This helps with supporting Kubernetes <=1.18 out of the box. (default is `true`)
@@ -441,6 +493,18 @@ type RuntimeConfig struct {
 	// IgnoreDeprecationWarnings is the list of the deprecation IDs (such as "io.containerd.deprecation/pull-schema-1-image")
 	// that should be ignored for checking "ContainerdHasNoDeprecationWarnings" condition.
 	IgnoreDeprecationWarnings []string `toml:"ignore_deprecation_warnings" json:"ignoreDeprecationWarnings"`
+
+	// UsernsIDRangeStart is the first host UID/GID made available for user namespace
+	// allocation when a pod requests namespace mode POD without explicit mappings.
+	// Defaults to 0, which disables automatic allocation.
+	UsernsIDRangeStart int64 `toml:"userns_id_range_start" json:"usernsIDRangeStart"`
+	// UsernsIDRangeLength is the total number of host UIDs/GIDs available for
+	// allocation, starting at UsernsIDRangeStart.
+	UsernsIDRangeLength int64 `toml:"userns_id_range_length" json:"usernsIDRangeLength"`
+	// UsernsIDRangeSize is the number of IDs allocated to each pod out of the
+	// configured range. Defaults to 65536, matching the common container ID
+	// range size.
+	UsernsIDRangeSize int64 `toml:"userns_id_range_size" json:"usernsIDRangeSize"`
 }
 
 // X509KeyPairStreaming contains the x509 configuration for streaming
@@ -483,6 +547,9 @@ type ServerConfig struct {
 	EnableTLSStreaming bool `toml:"enable_tls_streaming" json:"enableTLSStreaming"`
 	// X509KeyPairStreaming is a x509 key pair used for TLS streaming
 	X509KeyPairStreaming `toml:"x509_key_pair_streaming" json:"x509KeyPairStreaming"`
+	// MaxStreamingConnectionsPerContainer is the maximum number of concurrent
+	// exec/attach streaming sessions allowed per container. 0 means no limit.
+	MaxStreamingConnectionsPerContainer int `toml:"max_streaming_connections_per_container" json:"maxStreamingConnectionsPerContainer"`
 }
 
 const (
@@ -698,6 +765,9 @@ func ValidateServerConfig(ctx context.Context, c *ServerConfig) ([]deprecation.W
 			return warnings, fmt.Errorf("invalid stream idle timeout: %w", err)
 		}
 	}
+	if c.MaxStreamingConnectionsPerContainer < 0 {
+		return warnings, errors.New("max_streaming_connections_per_container must not be negative")
+	}
 	return warnings, nil
 }
 
@@ -801,5 +871,6 @@ func DefaultServerConfig() ServerConfig {
 			TLSKeyFile:  "",
 			TLSCertFile: "",
 		},
+		MaxStreamingConnectionsPerContainer: 0,
 	}
 }
@@ -38,9 +38,11 @@ func DefaultImageConfig() ImageConfig {
 		PinnedImages: map[string]string{
 			"sandbox": DefaultSandboxImage,
 		},
-		ImagePullProgressTimeout: defaultImagePullProgressTimeoutDuration.String(),
-		ImagePullWithSyncFs:      false,
-		StatsCollectPeriod:       10,
+		ImagePullProgressTimeout:  defaultImagePullProgressTimeoutDuration.String(),
+		ImagePullWithSyncFs:       false,
+		StatsCollectPeriod:        10,
+		ImagePullRetryMaxAttempts: defaultImagePullRetryMaxAttempts,
+		ImagePullRetryBaseDelay:   defaultImagePullRetryBaseDelay.String(),
 	}
 }
 
@@ -105,5 +107,7 @@ func DefaultRuntimeConfig() RuntimeConfig {
 		DrainExecSyncIOTimeout:           "0s",
 		EnableUnprivilegedPorts:          true,
 		EnableUnprivilegedICMP:           true,
+		UsernsIDRangeSize:                65536,
+		SeccompAgentDeathPolicy:          "ignore",
 	}
 }
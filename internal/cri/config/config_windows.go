@@ -38,7 +38,9 @@ func DefaultImageConfig() ImageConfig {
 		PinnedImages: map[string]string{
 			"sandbox": DefaultSandboxImage,
 		},
-		ImagePullProgressTimeout: defaultImagePullProgressTimeoutDuration.String(),
+		ImagePullProgressTimeout:  defaultImagePullProgressTimeoutDuration.String(),
+		ImagePullRetryMaxAttempts: defaultImagePullRetryMaxAttempts,
+		ImagePullRetryBaseDelay:   defaultImagePullRetryBaseDelay.String(),
 	}
 }
 
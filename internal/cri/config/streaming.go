@@ -82,6 +82,7 @@ func (c *ServerConfig) StreamingConfig() (streaming.Config, error) {
 		}
 	}
 	config.Addr = net.JoinHostPort(addr, port)
+	config.MaxConnectionsPerContainer = c.MaxStreamingConnectionsPerContainer
 
 	tlsMode, err := getStreamListenerMode(c)
 	if err != nil {
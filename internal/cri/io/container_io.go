@@ -45,6 +45,12 @@ type ContainerIO struct {
 	stdoutGroup *cioutil.WriterGroup
 	stderrGroup *cioutil.WriterGroup
 
+	// stdoutBuf and stderrBuf retain a bounded backlog of output so that a
+	// client attaching (or reattaching after a dropped connection) can be
+	// replayed what it missed before it starts receiving live output.
+	stdoutBuf *replayBuffer
+	stderrBuf *replayBuffer
+
 	closer *wgCloser
 }
 
@@ -103,6 +109,8 @@ func NewContainerIO(id string, opts ...ContainerIOOpts) (_ *ContainerIO, err err
 		id:          id,
 		stdoutGroup: cioutil.NewWriterGroup(),
 		stderrGroup: cioutil.NewWriterGroup(),
+		stdoutBuf:   newReplayBuffer(defaultReplayBufferSize),
+		stderrBuf:   newReplayBuffer(defaultReplayBufferSize),
 	}
 	for _, opt := range opts {
 		if err := opt(c); err != nil {
@@ -119,6 +127,11 @@ func NewContainerIO(id string, opts ...ContainerIOOpts) (_ *ContainerIO, err err
 	}
 	c.stdioStream = stdio
 	c.closer = closer
+	// The replay buffers stay in the groups for the lifetime of the
+	// container, so they observe every write regardless of how many
+	// clients are attached, and keep the groups from ever going empty.
+	c.stdoutGroup.Add(streamKey(id, "replay", Stdout), c.stdoutBuf)
+	c.stderrGroup.Add(streamKey(id, "replay", Stderr), c.stderrBuf)
 	return c, nil
 }
 
@@ -211,12 +224,22 @@ func (c *ContainerIO) Attach(opts AttachOptions) {
 	}
 
 	if opts.Stdout != nil {
+		// Replay whatever of the backlog is still buffered before
+		// streaming live output, so a client that attaches late, or
+		// reattaches after a dropped connection, doesn't silently miss
+		// output produced in the meantime.
+		if err := c.stdoutBuf.replay(opts.Stdout); err != nil {
+			log.L.WithError(err).Errorf("Failed to replay buffered stdout for container attach %q", c.id)
+		}
 		wg.Add(1)
 		wc, close := cioutil.NewWriteCloseInformer(opts.Stdout)
 		c.stdoutGroup.Add(stdoutKey, wc)
 		go attachStream(stdoutKey, close)
 	}
 	if !opts.Tty && opts.Stderr != nil {
+		if err := c.stderrBuf.replay(opts.Stderr); err != nil {
+			log.L.WithError(err).Errorf("Failed to replay buffered stderr for container attach %q", c.id)
+		}
 		wg.Add(1)
 		wc, close := cioutil.NewWriteCloseInformer(opts.Stderr)
 		c.stderrGroup.Add(stderrKey, wc)
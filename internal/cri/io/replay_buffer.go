@@ -0,0 +1,81 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package io
+
+import (
+	"io"
+	"sync"
+)
+
+// defaultReplayBufferSize is the amount of output retained per stream for
+// replay to a client that (re)attaches, e.g. after a dropped connection.
+const defaultReplayBufferSize = 64 * 1024
+
+// replayBuffer is a bounded buffer that retains the most recently written
+// bytes of a stream so that a client attaching to it — including a client
+// reattaching after a dropped connection — can be given the backlog it
+// missed instead of only ever seeing output written after it attached.
+//
+// replayBuffer is meant to be added as a permanent member of a
+// cioutil.WriterGroup alongside the group's live attach writers, so it
+// observes every write for the lifetime of the container.
+type replayBuffer struct {
+	mu   sync.Mutex
+	data []byte
+	size int
+}
+
+var _ io.WriteCloser = &replayBuffer{}
+
+// newReplayBuffer creates a replay buffer retaining up to size bytes.
+func newReplayBuffer(size int) *replayBuffer {
+	return &replayBuffer{size: size}
+}
+
+// Write appends p to the buffer, dropping the oldest bytes once it grows
+// past its configured size. It never fails.
+func (b *replayBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.size == 0 {
+		return len(p), nil
+	}
+	b.data = append(b.data, p...)
+	if len(b.data) > b.size {
+		b.data = append([]byte(nil), b.data[len(b.data)-b.size:]...)
+	}
+	return len(p), nil
+}
+
+// replay writes the currently buffered backlog to w. It leaves the buffer
+// untouched, so multiple attaches each see the same backlog.
+func (b *replayBuffer) replay(w io.Writer) error {
+	b.mu.Lock()
+	data := append([]byte(nil), b.data...)
+	b.mu.Unlock()
+	if len(data) == 0 {
+		return nil
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// Close is a no-op. replayBuffer holds no resource of its own and must
+// outlive any single attach session for as long as the container runs.
+func (b *replayBuffer) Close() error {
+	return nil
+}
@@ -0,0 +1,70 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package io
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayBufferReplaysRecentBacklog(t *testing.T) {
+	b := newReplayBuffer(8)
+
+	n, err := b.Write([]byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+
+	var out bytes.Buffer
+	require.NoError(t, b.replay(&out))
+	assert.Equal(t, "hello", out.String())
+
+	// Replaying again must return the same backlog: it is not consumed.
+	out.Reset()
+	require.NoError(t, b.replay(&out))
+	assert.Equal(t, "hello", out.String())
+}
+
+func TestReplayBufferDropsOldestBytesOnceFull(t *testing.T) {
+	b := newReplayBuffer(8)
+
+	_, err := b.Write([]byte("helloworld"))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, b.replay(&out))
+	assert.Equal(t, "lloworld", out.String())
+
+	_, err = b.Write([]byte("!"))
+	require.NoError(t, err)
+	out.Reset()
+	require.NoError(t, b.replay(&out))
+	assert.Equal(t, "loworld!", out.String())
+}
+
+func TestReplayBufferZeroSizeDiscardsEverything(t *testing.T) {
+	b := newReplayBuffer(0)
+
+	_, err := b.Write([]byte("hello"))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, b.replay(&out))
+	assert.Empty(t, out.String())
+}
@@ -377,6 +377,15 @@ func (a *API) BlockPluginSync() *PluginSyncBlock {
 	return a.nri.BlockPluginSync()
 }
 
+// Status reports the most recently failed NRI hook call, if any, for use
+// in CRI runtime status introspection.
+func (a *API) Status() error {
+	if a.IsDisabled() {
+		return nil
+	}
+	return a.nri.Status()
+}
+
 //
 // NRI-CRI 'domain' interface
 //
@@ -116,6 +116,10 @@ func (*API) BlockPluginSync() *PluginSyncBlock {
 
 func (*PluginSyncBlock) Unblock() {}
 
+func (*API) Status() error {
+	return nil
+}
+
 //
 // NRI-CRI no-op 'domain' interface
 //
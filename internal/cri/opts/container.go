@@ -30,6 +30,7 @@ import (
 	"github.com/containerd/containerd/v2/core/containers"
 	"github.com/containerd/containerd/v2/core/mount"
 	"github.com/containerd/containerd/v2/core/snapshots"
+	"github.com/containerd/containerd/v2/pkg/tracing"
 	"github.com/containerd/errdefs"
 	"github.com/containerd/log"
 )
@@ -39,11 +40,14 @@ import (
 func WithNewSnapshot(id string, i containerd.Image, opts ...snapshots.Opt) containerd.NewContainerOpts {
 	f := containerd.WithNewSnapshot(id, i, opts...)
 	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		ctx, span := tracing.StartSpan(ctx, "opts.WithNewSnapshot", tracing.WithAttribute("container.id", id))
+		defer span.End()
 		if err := f(ctx, client, c); err != nil {
 			if !errdefs.IsNotFound(err) {
 				return err
 			}
 
+			span.AddEvent("snapshot not found, unpacking image before retrying")
 			if err := i.Unpack(ctx, c.Snapshotter); err != nil {
 				return fmt.Errorf("error unpacking image: %w", err)
 			}
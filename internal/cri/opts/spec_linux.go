@@ -76,7 +76,9 @@ var (
 	supportsHugetlb     bool
 )
 
-func isHugetlbControllerPresent() bool {
+// HugetlbControllerPresent returns true if the hugetlb cgroup controller is
+// available.
+func HugetlbControllerPresent() bool {
 	supportsHugetlbOnce.Do(func() {
 		supportsHugetlb = false
 		if IsCgroup2UnifiedMode() {
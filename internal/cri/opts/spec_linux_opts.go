@@ -384,13 +384,20 @@ func WithResources(resources *runtime.LinuxContainerResources, tolerateMissingHu
 		}
 
 		if !disableHugetlbController {
-			if isHugetlbControllerPresent() {
+			if HugetlbControllerPresent() {
+				// Replace rather than append: s.Linux.Resources.HugepageLimits may
+				// already hold limits copied from a prior spec (e.g. on a resource
+				// update for an already-running container), and hugepages here is
+				// always the full desired set, not a delta - including the empty
+				// set, which means the caller wants hugepage limits cleared.
+				hugepageLimits := make([]runtimespec.LinuxHugepageLimit, 0, len(hugepages))
 				for _, limit := range hugepages {
-					s.Linux.Resources.HugepageLimits = append(s.Linux.Resources.HugepageLimits, runtimespec.LinuxHugepageLimit{
+					hugepageLimits = append(hugepageLimits, runtimespec.LinuxHugepageLimit{
 						Pagesize: limit.PageSize,
 						Limit:    limit.Limit,
 					})
 				}
+				s.Linux.Resources.HugepageLimits = hugepageLimits
 			} else {
 				if !tolerateMissingHugetlbController {
 					return errors.New("huge pages limits are specified but hugetlb cgroup controller is missing. " +
@@ -0,0 +1,188 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package opts
+
+import (
+	"context"
+	"testing"
+
+	runtimespec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
+	crierrors "k8s.io/cri-api/pkg/errors"
+
+	containerdmount "github.com/containerd/containerd/v2/core/mount"
+	ostesting "github.com/containerd/containerd/v2/pkg/os/testing"
+)
+
+func TestWithMountsRecursiveReadOnly(t *testing.T) {
+	rroHandler := &runtime.RuntimeHandler{
+		Features: &runtime.RuntimeHandlerFeatures{RecursiveReadOnlyMounts: true},
+	}
+
+	for _, test := range []struct {
+		desc      string
+		mount     *runtime.Mount
+		handler   *runtime.RuntimeHandler
+		expectErr error
+		expectOpt string
+	}{
+		{
+			desc: "recursive read-only mount is allowed when the handler supports it",
+			mount: &runtime.Mount{
+				HostPath:          "/src",
+				ContainerPath:     "/dst",
+				Readonly:          true,
+				RecursiveReadOnly: true,
+				Propagation:       runtime.MountPropagation_PROPAGATION_PRIVATE,
+			},
+			handler:   rroHandler,
+			expectOpt: "rro",
+		},
+		{
+			desc: "recursive read-only mount is rejected when the handler is unset",
+			mount: &runtime.Mount{
+				HostPath:          "/src",
+				ContainerPath:     "/dst",
+				Readonly:          true,
+				RecursiveReadOnly: true,
+				Propagation:       runtime.MountPropagation_PROPAGATION_PRIVATE,
+			},
+			handler:   nil,
+			expectErr: crierrors.ErrRROUnsupported,
+		},
+		{
+			desc: "recursive read-only mount is rejected when the handler doesn't advertise support",
+			mount: &runtime.Mount{
+				HostPath:          "/src",
+				ContainerPath:     "/dst",
+				Readonly:          true,
+				RecursiveReadOnly: true,
+				Propagation:       runtime.MountPropagation_PROPAGATION_PRIVATE,
+			},
+			handler:   &runtime.RuntimeHandler{Features: &runtime.RuntimeHandlerFeatures{}},
+			expectErr: crierrors.ErrRROUnsupported,
+		},
+		{
+			desc: "recursive read-only mount requires private propagation",
+			mount: &runtime.Mount{
+				HostPath:          "/src",
+				ContainerPath:     "/dst",
+				Readonly:          true,
+				RecursiveReadOnly: true,
+				Propagation:       runtime.MountPropagation_PROPAGATION_HOST_TO_CONTAINER,
+			},
+			handler: rroHandler,
+		},
+		{
+			desc: "recursive read-only mount conflicts with a read-write mount",
+			mount: &runtime.Mount{
+				HostPath:          "/src",
+				ContainerPath:     "/dst",
+				Readonly:          false,
+				RecursiveReadOnly: true,
+				Propagation:       runtime.MountPropagation_PROPAGATION_PRIVATE,
+			},
+			handler: rroHandler,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			osi := ostesting.NewFakeOS()
+			osi.LookupMountFn = func(string) (containerdmount.Info, error) {
+				return containerdmount.Info{Mountpoint: "/", Optional: "shared:1"}, nil
+			}
+			config := &runtime.ContainerConfig{Mounts: []*runtime.Mount{test.mount}}
+			spec := &runtimespec.Spec{}
+			opt := withMounts(osi, config, nil, "", test.handler, false)
+			err := opt(context.Background(), nil, nil, spec)
+
+			if test.expectErr != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, test.expectErr)
+				return
+			}
+			if test.expectOpt == "" {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, spec.Mounts, 2)
+			assert.Contains(t, spec.Mounts[1].Options, test.expectOpt)
+		})
+	}
+}
+
+func TestWithMountsPropagation(t *testing.T) {
+	for _, test := range []struct {
+		desc        string
+		propagation runtime.MountPropagation
+		mountInfo   containerdmount.Info
+		expectErr   bool
+		expectOpt   string
+	}{
+		{
+			desc:        "bidirectional propagation succeeds on a shared mount",
+			propagation: runtime.MountPropagation_PROPAGATION_BIDIRECTIONAL,
+			mountInfo:   containerdmount.Info{Mountpoint: "/", Optional: "shared:1"},
+			expectOpt:   "rshared",
+		},
+		{
+			desc:        "bidirectional propagation fails on a private mount",
+			propagation: runtime.MountPropagation_PROPAGATION_BIDIRECTIONAL,
+			mountInfo:   containerdmount.Info{Mountpoint: "/", Optional: ""},
+			expectErr:   true,
+		},
+		{
+			desc:        "host-to-container propagation succeeds on a slave mount",
+			propagation: runtime.MountPropagation_PROPAGATION_HOST_TO_CONTAINER,
+			mountInfo:   containerdmount.Info{Mountpoint: "/", Optional: "master:1"},
+			expectOpt:   "rslave",
+		},
+		{
+			desc:        "host-to-container propagation fails on a private mount",
+			propagation: runtime.MountPropagation_PROPAGATION_HOST_TO_CONTAINER,
+			mountInfo:   containerdmount.Info{Mountpoint: "/", Optional: ""},
+			expectErr:   true,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			osi := ostesting.NewFakeOS()
+			osi.LookupMountFn = func(string) (containerdmount.Info, error) {
+				return test.mountInfo, nil
+			}
+			config := &runtime.ContainerConfig{
+				Mounts: []*runtime.Mount{{
+					HostPath:      "/src",
+					ContainerPath: "/dst",
+					Propagation:   test.propagation,
+				}},
+			}
+			spec := &runtimespec.Spec{}
+			opt := withMounts(osi, config, nil, "", nil, false)
+			err := opt(context.Background(), nil, nil, spec)
+
+			if test.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, spec.Mounts, 2)
+			assert.Contains(t, spec.Mounts[1].Options, test.expectOpt)
+		})
+	}
+}
@@ -26,7 +26,9 @@ import (
 	runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
 )
 
-func isHugetlbControllerPresent() bool {
+// HugetlbControllerPresent returns true if the hugetlb cgroup controller is
+// available. On non-Linux platforms, this always returns false.
+func HugetlbControllerPresent() bool {
 	return false
 }
 
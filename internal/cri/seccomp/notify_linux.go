@@ -0,0 +1,222 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package seccomp implements a broker for the OCI runtime seccomp
+// user-notification listener protocol, so that syscall mediation for a
+// container can be delegated to an external agent instead of being
+// implemented by patching the OCI runtime itself.
+package seccomp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/containerd/log"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"golang.org/x/sys/unix"
+)
+
+// AgentDeathPolicy controls what happens to a container's seccomp notify fd
+// when it can't be forwarded to the configured agent, e.g. because the
+// agent is unreachable or dies mid-connection.
+type AgentDeathPolicy string
+
+const (
+	// AgentDeathPolicyIgnore leaves the container running. The notify fd is
+	// dropped, and the kernel falls back to its default seccomp action for
+	// any syscall the agent would otherwise have mediated.
+	AgentDeathPolicyIgnore AgentDeathPolicy = "ignore"
+	// AgentDeathPolicyKill terminates the container, on the assumption that
+	// running it without syscall mediation is unsafe.
+	AgentDeathPolicyKill AgentDeathPolicy = "kill"
+)
+
+// BrokerConfig configures a Broker.
+type BrokerConfig struct {
+	// ListenerPath is the unix socket the broker listens on. It must match
+	// Linux.Seccomp.ListenerPath in the container's OCI spec, since that is
+	// the address the runtime connects to in order to hand over the
+	// notify fd.
+	ListenerPath string
+	// AgentSocket is the unix socket of the external agent the notify fd
+	// is forwarded to.
+	AgentSocket string
+	// DeathPolicy is applied if AgentSocket can't be reached.
+	DeathPolicy AgentDeathPolicy
+}
+
+// Broker receives a container's seccomp user-notification fd from the OCI
+// runtime and forwards it to an external agent, so that security tooling
+// can implement syscall mediation via containerd instead of patching each
+// runtime.
+//
+// The runtime seccomp listener protocol isn't protobuf or ttrpc based:
+// the runtime connects to ListenerPath exactly once and sends the notify
+// fd as SCM_RIGHTS ancillary data alongside a JSON-encoded
+// specs.ContainerProcessState (see the "Seccomp" section of the OCI runtime
+// spec). Broker relays both, unmodified, to AgentSocket using that same
+// wire format, so any agent speaking the upstream protocol can be plugged
+// in directly.
+type Broker struct {
+	cfg  BrokerConfig
+	kill func(context.Context) error
+}
+
+// NewBroker creates a Broker for cfg. kill is called if cfg.DeathPolicy is
+// AgentDeathPolicyKill and the agent can't be reached; it may be nil if
+// cfg.DeathPolicy is AgentDeathPolicyIgnore.
+func NewBroker(cfg BrokerConfig, kill func(context.Context) error) *Broker {
+	return &Broker{cfg: cfg, kill: kill}
+}
+
+// Serve listens on cfg.ListenerPath, accepts the runtime's connection, and
+// forwards the seccomp notify fd it hands over to cfg.AgentSocket. A
+// container only ever hands over its notify fd once, so Serve handles a
+// single connection and returns; callers run it in its own goroutine for
+// the lifetime of the container create/start call.
+//
+// If ready is non-nil, Serve closes it once cfg.ListenerPath is bound and
+// able to accept a connection. The caller hands that same path to the OCI
+// runtime as the seccomp listener address; if the runtime dials before the
+// broker is actually listening, the connection is refused and the notify
+// fd is lost silently. Callers should block on ready (or Serve returning an
+// error) before handing the spec to the runtime.
+func (b *Broker) Serve(ctx context.Context, ready chan<- struct{}) error {
+	if err := os.Remove(b.cfg.ListenerPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to clear stale seccomp listener socket %q: %w", b.cfg.ListenerPath, err)
+	}
+	l, err := net.Listen("unix", b.cfg.ListenerPath)
+	if err != nil {
+		return fmt.Errorf("failed to listen on seccomp listener socket %q: %w", b.cfg.ListenerPath, err)
+	}
+	defer l.Close()
+
+	if ready != nil {
+		close(ready)
+	}
+
+	type accepted struct {
+		conn net.Conn
+		err  error
+	}
+	acceptCh := make(chan accepted, 1)
+	go func() {
+		conn, err := l.Accept()
+		acceptCh <- accepted{conn, err}
+	}()
+
+	var conn net.Conn
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case a := <-acceptCh:
+		if a.err != nil {
+			return fmt.Errorf("failed to accept seccomp listener connection: %w", a.err)
+		}
+		conn = a.conn
+	}
+	defer conn.Close()
+
+	state, fd, err := receiveNotify(conn)
+	if err != nil {
+		return fmt.Errorf("failed to receive seccomp notify fd: %w", err)
+	}
+	defer unix.Close(fd)
+
+	if err := b.forward(state, fd); err != nil {
+		log.G(ctx).WithError(err).Warnf("Failed to forward seccomp notify fd to agent %q, applying death policy %q", b.cfg.AgentSocket, b.cfg.DeathPolicy)
+		if b.cfg.DeathPolicy == AgentDeathPolicyKill && b.kill != nil {
+			if kerr := b.kill(ctx); kerr != nil {
+				return fmt.Errorf("agent %q unreachable, failed to apply kill death policy: %w", b.cfg.AgentSocket, kerr)
+			}
+		}
+		return fmt.Errorf("failed to forward seccomp notify fd to agent %q: %w", b.cfg.AgentSocket, err)
+	}
+	return nil
+}
+
+// receiveNotify reads the JSON container process state and the seccomp
+// notify fd the runtime sends over conn as a single SCM_RIGHTS message.
+func receiveNotify(conn net.Conn) (*specs.ContainerProcessState, int, error) {
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return nil, -1, errors.New("seccomp listener connection is not a unix socket")
+	}
+	f, err := unixConn.File()
+	if err != nil {
+		return nil, -1, fmt.Errorf("get underlying fd: %w", err)
+	}
+	defer f.Close()
+
+	buf := make([]byte, 4096)
+	oob := make([]byte, unix.CmsgSpace(4))
+	n, oobn, _, _, err := unix.Recvmsg(int(f.Fd()), buf, oob, 0)
+	if err != nil {
+		return nil, -1, fmt.Errorf("recvmsg: %w", err)
+	}
+	scms, err := unix.ParseSocketControlMessage(oob[:oobn])
+	if err != nil {
+		return nil, -1, fmt.Errorf("parse control message: %w", err)
+	}
+	if len(scms) != 1 {
+		return nil, -1, fmt.Errorf("expected exactly one control message, got %d", len(scms))
+	}
+	fds, err := unix.ParseUnixRights(&scms[0])
+	if err != nil {
+		return nil, -1, fmt.Errorf("parse unix rights: %w", err)
+	}
+	if len(fds) != 1 {
+		for _, fd := range fds {
+			unix.Close(fd)
+		}
+		return nil, -1, fmt.Errorf("expected exactly one fd, got %d", len(fds))
+	}
+
+	var state specs.ContainerProcessState
+	if err := json.Unmarshal(buf[:n], &state); err != nil {
+		unix.Close(fds[0])
+		return nil, -1, fmt.Errorf("unmarshal container process state: %w", err)
+	}
+	return &state, fds[0], nil
+}
+
+// forward relays state and fd to the configured agent over a fresh
+// connection, using the same JSON-plus-SCM_RIGHTS wire format the runtime
+// used to hand them to the broker.
+func (b *Broker) forward(state *specs.ContainerProcessState, fd int) error {
+	conn, err := net.DialUnix("unix", nil, &net.UnixAddr{Name: b.cfg.AgentSocket, Net: "unix"})
+	if err != nil {
+		return fmt.Errorf("dial agent: %w", err)
+	}
+	defer conn.Close()
+
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal container process state: %w", err)
+	}
+
+	f, err := conn.File()
+	if err != nil {
+		return fmt.Errorf("get underlying fd: %w", err)
+	}
+	defer f.Close()
+
+	return unix.Sendmsg(int(f.Fd()), payload, unix.UnixRights(fd), nil, 0)
+}
@@ -0,0 +1,168 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package seccomp
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+// sendNotify dials listenerPath the way the OCI runtime does, handing over
+// state and fd as a single SCM_RIGHTS message.
+func sendNotify(t *testing.T, listenerPath string, state *specs.ContainerProcessState, fd int) {
+	t.Helper()
+	conn, err := net.DialUnix("unix", nil, &net.UnixAddr{Name: listenerPath, Net: "unix"})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	payload, err := json.Marshal(state)
+	require.NoError(t, err)
+
+	f, err := conn.File()
+	require.NoError(t, err)
+	defer f.Close()
+
+	require.NoError(t, unix.Sendmsg(int(f.Fd()), payload, unix.UnixRights(fd), nil, 0))
+}
+
+func TestBrokerForwardsNotifyFdToAgent(t *testing.T) {
+	dir := t.TempDir()
+	listenerPath := filepath.Join(dir, "seccomp.sock")
+	agentSocket := filepath.Join(dir, "agent.sock")
+
+	agentL, err := net.Listen("unix", agentSocket)
+	require.NoError(t, err)
+	defer agentL.Close()
+
+	killed := false
+	b := NewBroker(BrokerConfig{
+		ListenerPath: listenerPath,
+		AgentSocket:  agentSocket,
+		DeathPolicy:  AgentDeathPolicyKill,
+	}, func(context.Context) error {
+		killed = true
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ready := make(chan struct{})
+	serveErrCh := make(chan error, 1)
+	go func() { serveErrCh <- b.Serve(ctx, ready) }()
+
+	select {
+	case <-ready:
+	case <-time.After(2 * time.Second):
+		t.Fatal("broker never became ready")
+	}
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	defer r.Close()
+	defer w.Close()
+
+	wantState := &specs.ContainerProcessState{
+		Version: "1.1.0",
+		Fds:     []string{specs.SeccompFdName},
+		Pid:     1234,
+		State:   specs.State{Version: "1.1.0", ID: "test-container"},
+	}
+	sendNotify(t, listenerPath, wantState, int(w.Fd()))
+
+	agentConn, err := agentL.Accept()
+	require.NoError(t, err)
+	defer agentConn.Close()
+
+	unixConn := agentConn.(*net.UnixConn)
+	f, err := unixConn.File()
+	require.NoError(t, err)
+	defer f.Close()
+
+	buf := make([]byte, 4096)
+	oob := make([]byte, unix.CmsgSpace(4))
+	n, oobn, _, _, err := unix.Recvmsg(int(f.Fd()), buf, oob, 0)
+	require.NoError(t, err)
+
+	var gotState specs.ContainerProcessState
+	require.NoError(t, json.Unmarshal(buf[:n], &gotState))
+	require.Equal(t, *wantState, gotState)
+
+	scms, err := unix.ParseSocketControlMessage(oob[:oobn])
+	require.NoError(t, err)
+	require.Len(t, scms, 1)
+	fds, err := unix.ParseUnixRights(&scms[0])
+	require.NoError(t, err)
+	require.Len(t, fds, 1)
+	defer unix.Close(fds[0])
+
+	require.NoError(t, <-serveErrCh)
+	require.False(t, killed)
+}
+
+func TestBrokerAppliesKillDeathPolicyWhenAgentUnreachable(t *testing.T) {
+	dir := t.TempDir()
+	listenerPath := filepath.Join(dir, "seccomp.sock")
+	// No listener created at agentSocket: dialing it must fail.
+	agentSocket := filepath.Join(dir, "agent.sock")
+
+	killed := make(chan struct{})
+	b := NewBroker(BrokerConfig{
+		ListenerPath: listenerPath,
+		AgentSocket:  agentSocket,
+		DeathPolicy:  AgentDeathPolicyKill,
+	}, func(context.Context) error {
+		close(killed)
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ready := make(chan struct{})
+	serveErrCh := make(chan error, 1)
+	go func() { serveErrCh <- b.Serve(ctx, ready) }()
+
+	select {
+	case <-ready:
+	case <-time.After(2 * time.Second):
+		t.Fatal("broker never became ready")
+	}
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	defer r.Close()
+	defer w.Close()
+
+	sendNotify(t, listenerPath, &specs.ContainerProcessState{Version: "1.1.0"}, int(w.Fd()))
+
+	require.Error(t, <-serveErrCh)
+	select {
+	case <-killed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("kill death policy was not applied")
+	}
+}
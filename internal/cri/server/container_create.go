@@ -298,6 +298,8 @@ func (c *criService) createContainer(r *createContainerRequest) (_ string, retEr
 	}
 
 	spec, err := c.buildContainerSpec(
+		r.ctx,
+		*r.sandbox,
 		platform,
 		r.containerID,
 		r.sandboxID,
@@ -392,7 +394,7 @@ func (c *criService) createContainer(r *createContainerRequest) (_ string, retEr
 		}
 	}()
 
-	specOpts, err := c.platformSpecOpts(platform, r.containerConfig, r.imageConfig)
+	specOpts, err := c.platformSpecOpts(r.containerID, platform, r.containerConfig, r.imageConfig)
 	if err != nil {
 		return "", fmt.Errorf("failed to get container spec opts: %w", err)
 	}
@@ -575,6 +577,7 @@ const (
 // runtime information (rootfs mounted), or platform specific checks with
 // no defined workaround (yet) to specify for other platforms.
 func (c *criService) platformSpecOpts(
+	id string,
 	platform imagespec.Platform,
 	config *runtime.ContainerConfig,
 	imageConfig *imagespec.ImageConfig,
@@ -611,7 +614,7 @@ func (c *criService) platformSpecOpts(
 
 	// Now grab the truly platform specific options (seccomp, apparmor etc. for linux
 	// for example).
-	ctrSpecOpts, err := c.containerSpecOpts(config, imageConfig)
+	ctrSpecOpts, err := c.containerSpecOpts(id, config, imageConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -622,6 +625,8 @@ func (c *criService) platformSpecOpts(
 
 // buildContainerSpec build container's OCI spec depending on controller's target platform OS.
 func (c *criService) buildContainerSpec(
+	ctx context.Context,
+	sandbox sandbox.Sandbox,
 	platform imagespec.Platform,
 	id string,
 	sandboxID string,
@@ -653,6 +658,8 @@ func (c *criService) buildContainerSpec(
 		linuxMounts := c.linuxContainerMounts(sandboxID, config)
 
 		specOpts, err = c.buildLinuxSpec(
+			ctx,
+			sandbox,
 			id,
 			sandboxID,
 			sandboxPid,
@@ -700,6 +707,8 @@ func (c *criService) buildContainerSpec(
 }
 
 func (c *criService) buildLinuxSpec(
+	ctx context.Context,
+	sandbox sandbox.Sandbox,
 	id string,
 	sandboxID string,
 	sandboxPid uint32,
@@ -902,7 +911,7 @@ func (c *criService) buildLinuxSpec(
 		targetPid = status.Pid
 	}
 
-	uids, gids, err := parseUsernsIDs(nsOpts.GetUsernsOptions())
+	uids, gids, err := c.resolveContainerUsernsIDs(ctx, sandbox, nsOpts.GetUsernsOptions())
 	if err != nil {
 		return nil, fmt.Errorf("user namespace configuration: %w", err)
 	}
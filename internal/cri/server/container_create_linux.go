@@ -17,21 +17,29 @@
 package server
 
 import (
+	"context"
 	"fmt"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"syscall"
 
+	"github.com/containerd/errdefs"
+	"github.com/containerd/log"
 	imagespec "github.com/opencontainers/image-spec/specs-go/v1"
+	runtimespec "github.com/opencontainers/runtime-spec/specs-go"
 	runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
 
+	"github.com/containerd/containerd/v2/core/containers"
 	"github.com/containerd/containerd/v2/core/snapshots"
 	"github.com/containerd/containerd/v2/pkg/oci"
 
 	customopts "github.com/containerd/containerd/v2/internal/cri/opts"
+	criseccomp "github.com/containerd/containerd/v2/internal/cri/seccomp"
 	"github.com/containerd/containerd/v2/internal/cri/sputil"
 )
 
-func (c *criService) containerSpecOpts(config *runtime.ContainerConfig, imageConfig *imagespec.ImageConfig) ([]oci.SpecOpts, error) {
+func (c *criService) containerSpecOpts(id string, config *runtime.ContainerConfig, imageConfig *imagespec.ImageConfig) ([]oci.SpecOpts, error) {
 	var (
 		specOpts []oci.SpecOpts
 		err      error
@@ -100,12 +108,98 @@ func (c *criService) containerSpecOpts(config *runtime.ContainerConfig, imageCon
 	if seccompSpecOpts != nil {
 		specOpts = append(specOpts, seccompSpecOpts)
 	}
+	if c.config.SeccompAgentSocket != "" && !securityContext.GetPrivileged() {
+		specOpts = append(specOpts, c.withSeccompAgent(id))
+	}
 	if c.config.EnableCDI {
 		specOpts = append(specOpts, customopts.WithCDI(config.Annotations, config.CDIDevices))
 	}
 	return specOpts, nil
 }
 
+// withSeccompAgent starts a seccomp notify broker (see internal/cri/seccomp)
+// for container id and returns a SpecOpts that points the spec's seccomp
+// listener at it, so that any SCMP_ACT_NOTIFY syscall the container's
+// seccomp profile traps is forwarded to the configured agent.
+//
+// It only takes effect if an earlier SpecOpts already populated
+// s.Linux.Seccomp from the container's profile; containers running with no
+// seccomp profile have nothing to forward notifications for.
+func (c *criService) withSeccompAgent(id string) oci.SpecOpts {
+	return func(specOptsCtx context.Context, _ oci.Client, _ *containers.Container, s *runtimespec.Spec) error {
+		if s.Linux == nil || s.Linux.Seccomp == nil {
+			return nil
+		}
+		listenerPath := filepath.Join(c.getVolatileContainerRootDir(id), "seccomp-notify.sock")
+		deathPolicy := criseccomp.AgentDeathPolicy(c.config.SeccompAgentDeathPolicy)
+		if deathPolicy == "" {
+			deathPolicy = criseccomp.AgentDeathPolicyIgnore
+		}
+		broker := criseccomp.NewBroker(criseccomp.BrokerConfig{
+			ListenerPath: listenerPath,
+			AgentSocket:  c.config.SeccompAgentSocket,
+			DeathPolicy:  deathPolicy,
+		}, func(ctx context.Context) error {
+			return c.killContainerForSeccompAgentDeathPolicy(ctx, id)
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		c.seccompAgentBrokers.Store(id, cancel)
+
+		ready := make(chan struct{})
+		serveErr := make(chan error, 1)
+		go func() {
+			defer c.seccompAgentBrokers.Delete(id)
+			err := broker.Serve(ctx, ready)
+			if err != nil && ctx.Err() == nil {
+				log.G(ctx).WithError(err).Errorf("Seccomp notify broker for container %q exited", id)
+			}
+			serveErr <- err
+		}()
+
+		// The runtime is handed listenerPath below and will dial it as soon
+		// as it starts the container; block until the broker is actually
+		// listening so that dial can't lose the race and silently drop the
+		// notify fd.
+		select {
+		case <-ready:
+		case err := <-serveErr:
+			cancel()
+			return fmt.Errorf("failed to start seccomp notify broker for container %q: %w", id, err)
+		case <-specOptsCtx.Done():
+			cancel()
+			return specOptsCtx.Err()
+		}
+
+		s.Linux.Seccomp.ListenerPath = listenerPath
+		return nil
+	}
+}
+
+// killContainerForSeccompAgentDeathPolicy kills container id's task. It is
+// invoked by the seccomp notify broker when AgentDeathPolicyKill applies
+// because the configured agent can't be reached.
+func (c *criService) killContainerForSeccompAgentDeathPolicy(ctx context.Context, id string) error {
+	cntr, err := c.containerStore.Get(id)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	task, err := cntr.Container.Task(ctx, nil)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if err := task.Kill(ctx, syscall.SIGKILL); err != nil && !errdefs.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
 // snapshotterOpts returns any Linux specific snapshotter options for the rootfs snapshot
 func snapshotterOpts(config *runtime.ContainerConfig) ([]snapshots.Opt, error) {
 	nsOpts := config.GetLinux().GetSecurityContext().GetNamespaceOptions()
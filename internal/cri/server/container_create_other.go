@@ -26,7 +26,13 @@ import (
 	"github.com/containerd/containerd/v2/pkg/oci"
 )
 
-func (c *criService) containerSpecOpts(config *runtime.ContainerConfig, imageConfig *imagespec.ImageConfig) ([]oci.SpecOpts, error) {
+// containerSpecOpts returns the platform specific container spec opts.
+//
+// This does not translate config.GetLinux().GetResources() into anything,
+// since neither CRI's ContainerConfig nor the OCI runtime spec define a
+// resources section for this platform (e.g. FreeBSD jail rctl limits), so
+// there is nowhere to put it.
+func (c *criService) containerSpecOpts(id string, config *runtime.ContainerConfig, imageConfig *imagespec.ImageConfig) ([]oci.SpecOpts, error) {
 	return []oci.SpecOpts{}, nil
 }
 
@@ -0,0 +1,75 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureImageSubPath(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "data"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "file.txt"), []byte("hi"), 0644))
+
+	for _, test := range []struct {
+		desc    string
+		subPath string
+		want    string
+		wantErr bool
+	}{
+		{
+			desc:    "empty subpath returns mount point unchanged",
+			subPath: "",
+			want:    root,
+		},
+		{
+			desc:    "directory subpath resolves within mount point",
+			subPath: "data",
+			want:    filepath.Join(root, "data"),
+		},
+		{
+			desc:    "file subpath is rejected",
+			subPath: "file.txt",
+			wantErr: true,
+		},
+		{
+			desc:    "subpath escaping mount point is rejected",
+			subPath: "../",
+			wantErr: true,
+		},
+		{
+			desc:    "nonexistent subpath is rejected",
+			subPath: "does-not-exist",
+			wantErr: true,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := ensureImageSubPath(root, test.subPath)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
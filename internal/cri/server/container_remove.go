@@ -60,6 +60,7 @@ func (c *criService) RemoveContainer(ctx context.Context, r *runtime.RemoveConta
 		log.G(ctx).WithError(err).Warn("get container info failed")
 		c.containerStore.Delete(ctrID)
 		c.containerNameIndex.ReleaseByKey(ctrID)
+		c.stopSeccompAgentBroker(ctrID)
 		return &runtime.RemoveContainerResponse{}, nil
 	}
 
@@ -128,6 +129,7 @@ func (c *criService) RemoveContainer(ctx context.Context, r *runtime.RemoveConta
 	}
 
 	c.containerStore.Delete(id)
+	c.stopSeccompAgentBroker(id)
 
 	c.containerNameIndex.ReleaseByKey(id)
 
@@ -332,8 +332,12 @@ func (c *criService) windowsContainerMetrics(
 			return containerStats{}, fmt.Errorf("failed to extract container metrics: %w", err)
 		}
 		wstats := s.(*wstats.Statistics).GetWindows()
+		// wstats can be nil for HostProcess containers, which don't run inside
+		// a job object and therefore have no stats to report. Leave Cpu/Memory
+		// unset rather than failing the whole ListContainerStats call.
 		if wstats == nil {
-			return containerStats{}, errors.New("windows stats is empty")
+			log.L.Warnf("no windows stats found for container %q, skipping cpu/memory stats", meta.ID)
+			return containerStats{&cs, 0}, nil
 		}
 		if wstats.Processor != nil {
 			cs.Cpu = &runtime.CpuUsage{
@@ -415,9 +419,12 @@ func (c *criService) linuxContainerMetrics(
 			return containerStats{}, fmt.Errorf("failed to obtain memory stats: %w", err)
 		}
 		cs.Memory = memoryStats
+
+		ioStats, err := c.ioContainerStats(data, protobuf.FromTimestamp(stats.Timestamp))
 		if err != nil {
-			return containerStats{}, fmt.Errorf("failed to obtain pid count: %w", err)
+			return containerStats{}, fmt.Errorf("failed to obtain io stats: %w", err)
 		}
+		cs.Io = ioStats
 	}
 
 	return containerStats{&cs, pids}, nil
@@ -492,6 +499,7 @@ func (c *criService) cpuContainerStats(ID string, isSandbox bool, stats interfac
 			return &runtime.CpuUsage{
 				Timestamp:            timestamp.UnixNano(),
 				UsageCoreNanoSeconds: &runtime.UInt64Value{Value: usageCoreNanoSeconds},
+				Psi:                  toCRIPsiStats(metrics.CPU.PSI),
 			}, nil
 		}
 	default:
@@ -500,6 +508,52 @@ func (c *criService) cpuContainerStats(ID string, isSandbox bool, stats interfac
 	return nil, nil
 }
 
+// ioContainerStats extracts IO PSI stats from the given metrics. PSI is only
+// available on cgroup v2 (from io.pressure); cgroup v1 has no equivalent, so
+// this returns nil, nil for *cg1.Metrics.
+func (c *criService) ioContainerStats(stats interface{}, timestamp time.Time) (*runtime.IoUsage, error) {
+	switch metrics := stats.(type) {
+	case *cg1.Metrics:
+		return nil, nil
+	case *cg2.Metrics:
+		if metrics.Io == nil {
+			return nil, nil
+		}
+		psi := toCRIPsiStats(metrics.Io.PSI)
+		if psi == nil {
+			return nil, nil
+		}
+		return &runtime.IoUsage{
+			Timestamp: timestamp.UnixNano(),
+			Psi:       psi,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unexpected metrics type: %T from %s", metrics, reflect.TypeOf(metrics).Elem().PkgPath())
+	}
+}
+
+// toCRIPsiStats converts cgroup2 PSI stats to their CRI representation.
+func toCRIPsiStats(psi *cg2.PSIStats) *runtime.PsiStats {
+	if psi == nil {
+		return nil
+	}
+	toCRIPsiData := func(d *cg2.PSIData) *runtime.PsiData {
+		if d == nil {
+			return nil
+		}
+		return &runtime.PsiData{
+			Total:  d.Total,
+			Avg10:  d.Avg10,
+			Avg60:  d.Avg60,
+			Avg300: d.Avg300,
+		}
+	}
+	return &runtime.PsiStats{
+		Some: toCRIPsiData(psi.Some),
+		Full: toCRIPsiData(psi.Full),
+	}
+}
+
 func (c *criService) memoryContainerStats(ID string, stats interface{}, timestamp time.Time) (*runtime.MemoryUsage, error) {
 	switch metrics := stats.(type) {
 	case *cg1.Metrics:
@@ -534,6 +588,7 @@ func (c *criService) memoryContainerStats(ID string, stats interface{}, timestam
 				RssBytes:        &runtime.UInt64Value{Value: metrics.Memory.Anon},
 				PageFaults:      &runtime.UInt64Value{Value: metrics.Memory.Pgfault},
 				MajorPageFaults: &runtime.UInt64Value{Value: metrics.Memory.Pgmajfault},
+				Psi:             toCRIPsiStats(metrics.Memory.PSI),
 			}, nil
 		}
 	default:
@@ -541,3 +596,32 @@ func (c *criService) memoryContainerStats(ID string, stats interface{}, timestam
 	}
 	return nil, nil
 }
+
+// memorySwapUsageBytes computes the bytes of swap currently in use from raw
+// cgroup memory stats. cgroup v2 exposes swap-only usage directly. cgroup v1
+// only exposes memory+swap combined usage through the memsw accounting, so
+// swap alone is derived by subtracting plain memory usage from it, the same
+// way cAdvisor derives container swap usage on cgroup v1 hosts. ok is false
+// when the swap controller isn't available, so callers can omit the value
+// instead of reporting a misleading zero.
+func memorySwapUsageBytes(stats interface{}) (bytes uint64, ok bool) {
+	switch metrics := stats.(type) {
+	case *cg1.Metrics:
+		if metrics.Memory == nil || metrics.Memory.Usage == nil || metrics.Memory.Swap == nil {
+			return 0, false
+		}
+		memSwUsage := metrics.Memory.Swap.Usage
+		memUsage := metrics.Memory.Usage.Usage
+		if memSwUsage < memUsage {
+			return 0, false
+		}
+		return memSwUsage - memUsage, true
+	case *cg2.Metrics:
+		if metrics.Memory == nil {
+			return 0, false
+		}
+		return metrics.Memory.SwapUsage, true
+	default:
+		return 0, false
+	}
+}
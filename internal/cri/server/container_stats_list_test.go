@@ -345,6 +345,75 @@ func TestContainerMetricsMemory(t *testing.T) {
 	}
 }
 
+func TestMemorySwapUsageBytes(t *testing.T) {
+	for _, test := range []struct {
+		desc       string
+		metrics    interface{}
+		expected   uint64
+		expectedOK bool
+	}{
+		{
+			desc: "v1 metrics - swap controller available",
+			metrics: &v1.Metrics{
+				Memory: &v1.MemoryStat{
+					Usage: &v1.MemoryEntry{Usage: 1000},
+					Swap:  &v1.MemoryEntry{Usage: 1400},
+				},
+			},
+			expected:   400,
+			expectedOK: true,
+		},
+		{
+			desc: "v1 metrics - no swap controller",
+			metrics: &v1.Metrics{
+				Memory: &v1.MemoryStat{
+					Usage: &v1.MemoryEntry{Usage: 1000},
+				},
+			},
+			expectedOK: false,
+		},
+		{
+			desc: "v2 metrics - swap in use",
+			metrics: &v2.Metrics{
+				Memory: &v2.MemoryStat{SwapUsage: 256},
+			},
+			expected:   256,
+			expectedOK: true,
+		},
+		{
+			desc:       "v2 metrics - no memory stats",
+			metrics:    &v2.Metrics{},
+			expectedOK: false,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			got, ok := memorySwapUsageBytes(test.metrics)
+			assert.Equal(t, test.expectedOK, ok)
+			if test.expectedOK {
+				assert.Equal(t, test.expected, got)
+			}
+		})
+	}
+}
+
+func TestWindowsContainerMetricsHostProcess(t *testing.T) {
+	c := newTestCRIService()
+
+	// HostProcess containers run directly on the host rather than inside a
+	// job object, so the shim reports no windows-specific stats for them.
+	data, err := typeurl.MarshalAnyToProto(&wstats.Statistics{})
+	assert.NoError(t, err)
+
+	cs, err := c.windowsContainerMetrics(
+		containerstore.Metadata{ID: "hpc1"},
+		&types.Metric{ID: "hpc1", Data: data},
+		"",
+	)
+	assert.NoError(t, err)
+	assert.Nil(t, cs.stats.Cpu)
+	assert.Nil(t, cs.stats.Memory)
+}
+
 func TestListContainerStats(t *testing.T) {
 	if goruntime.GOOS == "darwin" {
 		t.Skip("not implemented on Darwin")
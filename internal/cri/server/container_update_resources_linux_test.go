@@ -29,6 +29,18 @@ import (
 	criopts "github.com/containerd/containerd/v2/internal/cri/opts"
 )
 
+// expectedHugepageLimits mirrors what updateOCIResource should produce for a
+// given requested set of hugepage limits: the requested set verbatim if the
+// hugetlb cgroup controller is available on this machine, or the original
+// spec's limits untouched otherwise (TolerateMissingHugetlbController skips
+// the update rather than erroring).
+func expectedHugepageLimits(original, requested []runtimespec.LinuxHugepageLimit) []runtimespec.LinuxHugepageLimit {
+	if criopts.HugetlbControllerPresent() {
+		return requested
+	}
+	return original
+}
+
 func TestUpdateOCILinuxResource(t *testing.T) {
 	oomscoreadj := new(int)
 	*oomscoreadj = -500
@@ -235,6 +247,75 @@ func TestUpdateOCILinuxResource(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "should replace rather than accumulate hugepage limits across repeated updates",
+			spec: &runtimespec.Spec{
+				Linux: &runtimespec.Linux{
+					Resources: &runtimespec.LinuxResources{
+						Memory: &runtimespec.LinuxMemory{Limit: proto.Int64(12345)},
+						HugepageLimits: []runtimespec.LinuxHugepageLimit{
+							{Pagesize: "2MB", Limit: 1},
+						},
+					},
+				},
+			},
+			request: &runtime.UpdateContainerResourcesRequest{
+				Linux: &runtime.LinuxContainerResources{
+					MemoryLimitInBytes: 54321,
+					HugepageLimits: []*runtime.HugepageLimit{
+						{PageSize: "2MB", Limit: 2},
+					},
+				},
+			},
+			expected: &runtimespec.Spec{
+				Linux: &runtimespec.Linux{
+					Resources: &runtimespec.LinuxResources{
+						Memory: &runtimespec.LinuxMemory{
+							Limit: proto.Int64(54321),
+							Swap:  expectedSwap(54321),
+						},
+						CPU: &runtimespec.LinuxCPU{},
+						HugepageLimits: expectedHugepageLimits(
+							[]runtimespec.LinuxHugepageLimit{{Pagesize: "2MB", Limit: 1}},
+							[]runtimespec.LinuxHugepageLimit{{Pagesize: "2MB", Limit: 2}},
+						),
+					},
+				},
+			},
+		},
+		{
+			desc: "should clear hugepage limits when the requested set is empty",
+			spec: &runtimespec.Spec{
+				Linux: &runtimespec.Linux{
+					Resources: &runtimespec.LinuxResources{
+						Memory: &runtimespec.LinuxMemory{Limit: proto.Int64(12345)},
+						HugepageLimits: []runtimespec.LinuxHugepageLimit{
+							{Pagesize: "2MB", Limit: 1},
+						},
+					},
+				},
+			},
+			request: &runtime.UpdateContainerResourcesRequest{
+				Linux: &runtime.LinuxContainerResources{
+					MemoryLimitInBytes: 54321,
+				},
+			},
+			expected: &runtimespec.Spec{
+				Linux: &runtimespec.Linux{
+					Resources: &runtimespec.LinuxResources{
+						Memory: &runtimespec.LinuxMemory{
+							Limit: proto.Int64(54321),
+							Swap:  expectedSwap(54321),
+						},
+						CPU: &runtimespec.LinuxCPU{},
+						HugepageLimits: expectedHugepageLimits(
+							[]runtimespec.LinuxHugepageLimit{{Pagesize: "2MB", Limit: 1}},
+							[]runtimespec.LinuxHugepageLimit{},
+						),
+					},
+				},
+			},
+		},
 	} {
 		t.Run(test.desc, func(t *testing.T) {
 			config := criconfig.Config{
@@ -24,22 +24,19 @@ import (
 
 	runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
 
-	containerstore "github.com/containerd/containerd/v2/internal/cri/store/container"
+	"github.com/containerd/errdefs"
 )
 
 // UpdateContainerResources updates ContainerConfig of the container.
+//
+// Neither the CRI ContainerConfig nor the OCI runtime spec have a resources
+// section for this platform, so there is nothing here to translate into a
+// runtime-specific limit (e.g. rctl on FreeBSD). Report this honestly as
+// unimplemented instead of silently reporting success without ever changing
+// the container's resources, which is what this used to do.
 func (c *criService) UpdateContainerResources(ctx context.Context, r *runtime.UpdateContainerResourcesRequest) (retRes *runtime.UpdateContainerResourcesResponse, retErr error) {
-	container, err := c.containerStore.Get(r.GetContainerId())
-	if err != nil {
+	if _, err := c.containerStore.Get(r.GetContainerId()); err != nil {
 		return nil, fmt.Errorf("failed to find container: %w", err)
 	}
-	// Update resources in status update transaction, so that:
-	// 1) There won't be race condition with container start.
-	// 2) There won't be concurrent resource update to the same container.
-	if err := container.Status.Update(func(status containerstore.Status) (containerstore.Status, error) {
-		return status, nil
-	}); err != nil {
-		return nil, fmt.Errorf("failed to update resources: %w", err)
-	}
-	return &runtime.UpdateContainerResourcesResponse{}, nil
+	return nil, fmt.Errorf("container resource update not implemented: %w", errdefs.ErrNotImplemented)
 }
@@ -36,6 +36,7 @@ import (
 	criconfig "github.com/containerd/containerd/v2/internal/cri/config"
 	containerstore "github.com/containerd/containerd/v2/internal/cri/store/container"
 	imagestore "github.com/containerd/containerd/v2/internal/cri/store/image"
+	sandboxstore "github.com/containerd/containerd/v2/internal/cri/store/sandbox"
 	"github.com/containerd/errdefs"
 	"github.com/containerd/log"
 )
@@ -554,6 +555,47 @@ func parseUsernsIDs(userns *runtime.UserNamespace) (uids, gids []runtimespec.Lin
 	return uids, gids, nil
 }
 
+// resolveContainerUsernsIDs is like parseUsernsIDs, except that for
+// NamespaceMode_POD it tolerates empty UID/GID mappings on the container
+// config, falling back to the mapping the sandbox was actually created with.
+//
+// A pod whose sandbox picked up its range from usernsAllocator (see
+// podsandbox.resolveUsernsIDs) never had an explicit mapping to begin with,
+// so the kubelet has nothing to echo back on each container config either;
+// without this fallback every container in such a pod would fail here with
+// "without UID and GID mappings" even though the sandbox itself was created
+// successfully.
+func (c *criService) resolveContainerUsernsIDs(ctx context.Context, sb sandboxstore.Sandbox, userns *runtime.UserNamespace) (uids, gids []runtimespec.LinuxIDMapping, retErr error) {
+	if userns.GetMode() != runtime.NamespaceMode_POD {
+		return parseUsernsIDs(userns)
+	}
+
+	uids, err := parseUsernsIDMap(userns.GetUids())
+	if err != nil {
+		return nil, nil, fmt.Errorf("UID mapping: %w", err)
+	}
+	gids, err = parseUsernsIDMap(userns.GetGids())
+	if err != nil {
+		return nil, nil, fmt.Errorf("GID mapping: %w", err)
+	}
+	if len(uids) != 0 && len(gids) != 0 {
+		return uids, gids, nil
+	}
+	if sb.Container == nil {
+		return parseUsernsIDs(userns)
+	}
+
+	sandboxSpec, err := sb.Container.Spec(ctx)
+	if err != nil || sandboxSpec.Linux == nil || len(sandboxSpec.Linux.UIDMappings) == 0 || len(sandboxSpec.Linux.GIDMappings) == 0 {
+		// Fall back to the original error so the caller still gets a clear
+		// explanation when the sandbox has no user namespace either.
+		return parseUsernsIDs(userns)
+	}
+
+	log.G(ctx).Debugf("Container userns mapping is empty, reusing the user namespace mapping from sandbox %q", sb.ID)
+	return sandboxSpec.Linux.UIDMappings, sandboxSpec.Linux.GIDMappings, nil
+}
+
 // sameUsernsConfig checks if the userns configs are the same. If the mappings
 // on each config are the same but in different order, it returns false.
 // XXX: If the runtime.UserNamespace struct changes, we should update this
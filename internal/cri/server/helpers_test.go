@@ -18,6 +18,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"os"
 	goruntime "runtime"
 	"testing"
@@ -38,6 +39,8 @@ import (
 	"github.com/pelletier/go-toml/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	sandboxstore "github.com/containerd/containerd/v2/internal/cri/store/sandbox"
 )
 
 // TestGetUserFromImage tests the logic of getting image uid or user name of image user.
@@ -411,3 +414,82 @@ func TestHostNetwork(t *testing.T) {
 		})
 	}
 }
+
+// TestResolveContainerUsernsIDsSandboxFallback tests that a container with an
+// empty userns mapping in pod mode reuses the sandbox's own mapping instead
+// of erroring, so long as the sandbox was actually created with one (e.g. via
+// usernsAllocator).
+func TestResolveContainerUsernsIDsSandboxFallback(t *testing.T) {
+	sandboxMapping := []runtimespec.LinuxIDMapping{
+		{ContainerID: 0, HostID: 1000, Size: 65536},
+	}
+
+	c := newTestCRIService()
+
+	for _, test := range []struct {
+		desc      string
+		userns    *runtime.UserNamespace
+		sandbox   sandboxstore.Sandbox
+		expUIDs   []runtimespec.LinuxIDMapping
+		expGIDs   []runtimespec.LinuxIDMapping
+		expectErr bool
+	}{
+		{
+			desc: "explicit container mapping is used as is",
+			userns: &runtime.UserNamespace{
+				Mode: runtime.NamespaceMode_POD,
+				Uids: []*runtime.IDMapping{{HostId: 2000, ContainerId: 0, Length: 65536}},
+				Gids: []*runtime.IDMapping{{HostId: 2000, ContainerId: 0, Length: 65536}},
+			},
+			expUIDs: []runtimespec.LinuxIDMapping{{HostID: 2000, ContainerID: 0, Size: 65536}},
+			expGIDs: []runtimespec.LinuxIDMapping{{HostID: 2000, ContainerID: 0, Size: 65536}},
+		},
+		{
+			desc: "empty mapping falls back to sandbox's mapping",
+			userns: &runtime.UserNamespace{
+				Mode: runtime.NamespaceMode_POD,
+			},
+			sandbox: sandboxstore.Sandbox{
+				Container: &fakeSpecOnlyContainer{
+					t: t,
+					spec: &runtimespec.Spec{
+						Linux: &runtimespec.Linux{
+							UIDMappings: sandboxMapping,
+							GIDMappings: sandboxMapping,
+						},
+					},
+				},
+			},
+			expUIDs: sandboxMapping,
+			expGIDs: sandboxMapping,
+		},
+		{
+			desc: "empty mapping with no sandbox to fall back to errors",
+			userns: &runtime.UserNamespace{
+				Mode: runtime.NamespaceMode_POD,
+			},
+			expectErr: true,
+		},
+		{
+			desc: "empty mapping with an unrelated sandbox error still errors",
+			userns: &runtime.UserNamespace{
+				Mode: runtime.NamespaceMode_POD,
+			},
+			sandbox: sandboxstore.Sandbox{
+				Container: &fakeSpecOnlyContainer{t: t, errOnSpec: errors.New("not found")},
+			},
+			expectErr: true,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			uids, gids, err := c.resolveContainerUsernsIDs(context.Background(), test.sandbox, test.userns)
+			if test.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expUIDs, uids)
+			assert.Equal(t, test.expGIDs, gids)
+		})
+	}
+}
@@ -165,10 +165,13 @@ func (c *CRIImageService) PullImage(ctx context.Context, name string, credential
 		return "", fmt.Errorf("failed to parse image_pull_progress_timeout %q: %w", c.config.ImagePullProgressTimeout, err)
 	}
 
-	snapshotter, err := c.snapshotterFromPodSandboxConfig(ctx, ref, sandboxConfig)
+	snapshotter, err := c.snapshotterFromPodSandboxConfig(ctx, ref, sandboxConfig, runtimeHandler)
 	if err != nil {
 		return "", err
 	}
+	imagePlatform := c.imagePlatformFromPodSandboxConfig(ctx, ref, sandboxConfig, runtimeHandler)
+	platformFallbacks := c.platformFallbacksFromPodSandboxConfig(sandboxConfig, runtimeHandler)
+	platformMatcher := c.platformMatcherFromPodSandboxConfig(imagePlatform, platformFallbacks, sandboxConfig, runtimeHandler)
 
 	span.SetAttributes(
 		tracing.Attribute("image.ref", ref),
@@ -180,13 +183,14 @@ func (c *CRIImageService) PullImage(ctx context.Context, name string, credential
 	//
 	// Transfer service does not currently support all the CRI image config options.
 	// TODO: Add support for DisableSnapshotAnnotations, DiscardUnpackedLayers, ImagePullWithSyncFs and unpackDuplicationSuppressor
-	var image containerd.Image
-	if c.config.UseLocalImagePull {
-		image, err = c.pullImageWithLocalPull(ctx, ref, credentials, snapshotter, labels, imagePullProgressTimeout)
-	} else {
-		image, err = c.pullImageWithTransferService(ctx, ref, credentials, snapshotter, labels, imagePullProgressTimeout)
+	pull := func() (containerd.Image, error) {
+		if c.config.UseLocalImagePull {
+			return c.pullImageWithLocalPull(ctx, ref, credentials, snapshotter, platformMatcher, labels, imagePullProgressTimeout)
+		}
+		return c.pullImageWithTransferService(ctx, ref, credentials, snapshotter, imagePlatform, platformFallbacks, labels, imagePullProgressTimeout)
 	}
 
+	image, err := c.pullImageWithRetry(ctx, ref, pull)
 	if err != nil {
 		return "", err
 	}
@@ -237,6 +241,7 @@ func (c *CRIImageService) pullImageWithLocalPull(
 	ref string,
 	credentials func(string) (string, string, error),
 	snapshotter string,
+	platformMatcher platforms.MatchComparer,
 	labels map[string]string,
 	imagePullProgressTimeout time.Duration,
 ) (containerd.Image, error) {
@@ -254,9 +259,11 @@ func (c *CRIImageService) pullImageWithLocalPull(
 		containerd.WithPullSnapshotter(snapshotter),
 		containerd.WithPullUnpack,
 		containerd.WithPullLabels(labels),
+		containerd.WithPlatformMatcher(platformMatcher),
 		containerd.WithDownloadLimiter(c.downloadLimiter),
 		containerd.WithMaxConcurrentDownloads(c.config.MaxConcurrentDownloads),
 		containerd.WithConcurrentLayerFetchBuffer(c.config.ConcurrentLayerFetchBuffer),
+		containerd.WithImageVerifierPolicy(c.verifierPolicy),
 		containerd.WithUnpackOpts([]containerd.UnpackOpt{
 			containerd.WithUnpackDuplicationSuppressor(c.unpackDuplicationSuppressor),
 			containerd.WithUnpackApplyOpts(diff.WithSyncFs(c.config.ImagePullWithSyncFs)),
@@ -291,6 +298,8 @@ func (c *CRIImageService) pullImageWithTransferService(
 	ref string,
 	credentials func(string) (string, string, error),
 	snapshotter string,
+	imagePlatform imagespec.Platform,
+	platformFallbacks []imagespec.Platform,
 	labels map[string]string,
 	imagePullProgressTimeout time.Duration,
 ) (containerd.Image, error) {
@@ -300,9 +309,15 @@ func (c *CRIImageService) pullImageWithTransferService(
 	transferProgressReporter := newTransferProgressReporter(ref, rcancel, imagePullProgressTimeout)
 
 	// Set image store opts
+	//
+	// NOTE: unlike pullImageWithLocalPull, Strict has no observable effect
+	// here: transferimage.Store already matches its configured platforms
+	// exactly (via platforms.Ordered), without containerd's default
+	// sub-platform fallback (e.g. arm/v8 also matching arm/v7), so there is
+	// no "loose" mode to opt out of on this path.
 	var sopts []transferimage.StoreOpt
-	sopts = append(sopts, transferimage.WithPlatforms(platforms.DefaultSpec()))
-	sopts = append(sopts, transferimage.WithUnpack(platforms.DefaultSpec(), snapshotter))
+	sopts = append(sopts, transferimage.WithPlatforms(append([]imagespec.Platform{imagePlatform}, platformFallbacks...)...))
+	sopts = append(sopts, transferimage.WithUnpack(imagePlatform, snapshotter))
 	sopts = append(sopts, transferimage.WithImageLabels(labels))
 	is := transferimage.NewStore(ref, sopts...)
 	log.G(ctx).Debugf("Getting new CRI credentials")
@@ -781,6 +796,13 @@ type pullRequestReporter struct {
 	// totalBytesRead indicates that the total bytes has been read from
 	// remote registry.
 	totalBytesRead atomic.Uint64
+	// totalLayers indicates the number of layers seen for this pull so far.
+	// Only tracked by the transfer-service pull path, which can see each
+	// layer's content by name; it remains 0 for the local-pull path.
+	totalLayers atomic.Int32
+	// completeLayers indicates how many of totalLayers have finished
+	// downloading.
+	completeLayers atomic.Int32
 }
 
 func (reporter *pullRequestReporter) incRequest() {
@@ -795,12 +817,24 @@ func (reporter *pullRequestReporter) incByteRead(nr uint64) {
 	reporter.totalBytesRead.Add(nr)
 }
 
+func (reporter *pullRequestReporter) incTotalLayers() {
+	reporter.totalLayers.Add(1)
+}
+
+func (reporter *pullRequestReporter) incCompleteLayers() {
+	reporter.completeLayers.Add(1)
+}
+
 func (reporter *pullRequestReporter) status() (currentReqs int32, totalBytesRead uint64) {
 	currentReqs = reporter.activeReqs.Load()
 	totalBytesRead = reporter.totalBytesRead.Load()
 	return currentReqs, totalBytesRead
 }
 
+func (reporter *pullRequestReporter) layerStatus() (completeLayers, totalLayers int32) {
+	return reporter.completeLayers.Load(), reporter.totalLayers.Load()
+}
+
 // pullRequestReporterRoundTripper wraps http.RoundTripper with pull request
 // reporter which is used to track the progress of active http request with
 // counting readable http.Response.Body.
@@ -838,35 +872,133 @@ func (rt *pullRequestReporterRoundTripper) RoundTrip(req *http.Request) (*http.R
 	return resp, err
 }
 
-// Given that runtime information is not passed from PullImageRequest, we depend on an experimental annotation
-// passed from pod sandbox config to get the runtimeHandler. The annotation key is specified in configuration.
-// Once we know the runtime, try to override default snapshotter if it is set for this runtime.
+// runtimeHandlerFromPodSandboxConfig resolves the runtime handler to use for an
+// image pull or status check. It prefers the runtime_handler field added to
+// CRI's ImageSpec in v0.29.0, which is passed explicitly by callers that
+// already know the runtime class (e.g. PullImageRequest.Image.RuntimeHandler).
+// Callers that don't have access to it yet fall back to the older
+// experimental annotation read from the pod sandbox config.
 // See https://github.com/containerd/containerd/issues/6657
-func (c *CRIImageService) snapshotterFromPodSandboxConfig(ctx context.Context, imageRef string,
-	s *runtime.PodSandboxConfig) (string, error) {
-	snapshotter := c.config.Snapshotter
+func runtimeHandlerFromPodSandboxConfig(s *runtime.PodSandboxConfig, runtimeHandler string) string {
+	if runtimeHandler != "" {
+		return runtimeHandler
+	}
 	if s == nil || s.Annotations == nil {
-		return snapshotter, nil
+		return ""
 	}
+	return s.Annotations[annotations.RuntimeHandler]
+}
+
+// snapshotterFromPodSandboxConfig returns the per-runtime snapshotter
+// configured for runtimeHandler, falling back to the default snapshotter.
+func (c *CRIImageService) snapshotterFromPodSandboxConfig(ctx context.Context, imageRef string,
+	s *runtime.PodSandboxConfig, runtimeHandler string) (string, error) {
+	snapshotter := c.config.Snapshotter
 
-	// TODO(kiashok): honor the new CRI runtime handler field added to v0.29.0
-	// for image pull per runtime class support.
-	runtimeHandler, ok := s.Annotations[annotations.RuntimeHandler]
-	if !ok {
+	runtimeHandler = runtimeHandlerFromPodSandboxConfig(s, runtimeHandler)
+	if runtimeHandler == "" || c.runtimePlatforms == nil {
 		return snapshotter, nil
 	}
 
 	// TODO: Ensure error is returned if runtime not found?
-	if c.runtimePlatforms != nil {
-		if p, ok := c.runtimePlatforms[runtimeHandler]; ok && p.Snapshotter != snapshotter {
-			snapshotter = p.Snapshotter
-			log.G(ctx).Infof("experimental: PullImage %q for runtime %s, using snapshotter %s", imageRef, runtimeHandler, snapshotter)
-		}
+	if p, ok := c.runtimePlatforms[runtimeHandler]; ok && p.Snapshotter != snapshotter {
+		snapshotter = p.Snapshotter
+		log.G(ctx).Infof("PullImage %q for runtime %s, using snapshotter %s", imageRef, runtimeHandler, snapshotter)
 	}
 
 	return snapshotter, nil
 }
 
+// imagePlatformFromPodSandboxConfig resolves the pull/unpack platform to use for
+// imageRef, using the same runtime handler resolution as
+// snapshotterFromPodSandboxConfig. Runtimes handling non-native workloads, such
+// as wasm shims (e.g. runwasi), configure a platform other than the host's
+// through RuntimePlatforms, so that pulling an image built only for that
+// platform (e.g. wasip1/wasm) doesn't fail to match against the host platform.
+func (c *CRIImageService) imagePlatformFromPodSandboxConfig(ctx context.Context, imageRef string,
+	s *runtime.PodSandboxConfig, runtimeHandler string) imagespec.Platform {
+	platform := platforms.DefaultSpec()
+
+	runtimeHandler = runtimeHandlerFromPodSandboxConfig(s, runtimeHandler)
+	if runtimeHandler == "" || c.runtimePlatforms == nil {
+		return platform
+	}
+
+	if p, ok := c.runtimePlatforms[runtimeHandler]; ok {
+		platform = p.Platform
+		log.G(ctx).Infof("PullImage %q for runtime %s, using platform %s", imageRef, runtimeHandler, platforms.Format(platform))
+	}
+
+	return platform
+}
+
+// platformFallbacksFromPodSandboxConfig returns the ordered list of additional
+// platforms configured for runtimeHandler (see ImagePlatform.Fallbacks),
+// using the same runtime handler resolution as imagePlatformFromPodSandboxConfig.
+func (c *CRIImageService) platformFallbacksFromPodSandboxConfig(s *runtime.PodSandboxConfig, runtimeHandler string) []imagespec.Platform {
+	runtimeHandler = runtimeHandlerFromPodSandboxConfig(s, runtimeHandler)
+	if runtimeHandler == "" || c.runtimePlatforms == nil {
+		return nil
+	}
+
+	return c.runtimePlatforms[runtimeHandler].Fallbacks
+}
+
+// platformMatcherFromPodSandboxConfig returns the platforms.MatchComparer to use
+// for selecting a manifest for imageRef, combining the runtime's configured
+// platform with its Fallbacks (if any) in preference order, and honoring
+// Strict (see ImagePlatform.Strict). With neither configured this is
+// equivalent to platforms.Only(imagePlatform), the prior default behavior.
+func (c *CRIImageService) platformMatcherFromPodSandboxConfig(imagePlatform imagespec.Platform,
+	fallbacks []imagespec.Platform, s *runtime.PodSandboxConfig, runtimeHandler string) platforms.MatchComparer {
+	only := platforms.Only
+
+	runtimeHandler = runtimeHandlerFromPodSandboxConfig(s, runtimeHandler)
+	if runtimeHandler != "" && c.runtimePlatforms != nil {
+		if c.runtimePlatforms[runtimeHandler].Strict {
+			only = platforms.OnlyStrict
+		}
+	}
+
+	if len(fallbacks) == 0 {
+		return only(imagePlatform)
+	}
+
+	chain := make(chainedPlatformMatcher, 0, len(fallbacks)+1)
+	chain = append(chain, only(imagePlatform))
+	for _, f := range fallbacks {
+		chain = append(chain, only(f))
+	}
+	return chain
+}
+
+// chainedPlatformMatcher tries each MatchComparer in the given priority
+// order, so that earlier entries (e.g. a pull's primary platform) are always
+// preferred over later ones (its configured fallbacks).
+type chainedPlatformMatcher []platforms.MatchComparer
+
+func (c chainedPlatformMatcher) Match(platform imagespec.Platform) bool {
+	for _, m := range c {
+		if m.Match(platform) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c chainedPlatformMatcher) Less(p1, p2 imagespec.Platform) bool {
+	for _, m := range c {
+		p1m, p2m := m.Match(p1), m.Match(p2)
+		if p1m && !p2m {
+			return true
+		}
+		if p1m || p2m {
+			return false
+		}
+	}
+	return false
+}
+
 type criCredentials struct {
 	ref         string
 	credentials func(string) (string, string, error)
@@ -943,6 +1075,7 @@ func (reporter *transferProgressReporter) handleProgress(p transfer.Progress) {
 		if node, ok := reporter.statuses[p.Name]; !ok {
 			curProgress = p.Progress
 			reporter.reqReporter.incRequest()
+			reporter.reqReporter.incTotalLayers()
 		} else {
 			curProgress = p.Progress - node.Progress
 		}
@@ -956,6 +1089,7 @@ func (reporter *transferProgressReporter) handleProgress(p transfer.Progress) {
 		// as an active requests.
 		if p.Progress == p.Total {
 			reporter.reqReporter.decRequest()
+			reporter.reqReporter.incCompleteLayers()
 			delete(reporter.statuses, p.Name)
 		}
 
@@ -965,6 +1099,7 @@ func (reporter *transferProgressReporter) handleProgress(p transfer.Progress) {
 				reporter.IncBytesRead(curProgress)
 			}
 			reporter.reqReporter.decRequest()
+			reporter.reqReporter.incCompleteLayers()
 			delete(reporter.statuses, p.Name)
 		}
 	default:
@@ -1007,7 +1142,8 @@ func (reporter *transferProgressReporter) start(ctx context.Context) {
 				continue
 			case <-ctx.Done():
 				activeReqs, bytesRead := reporter.reqReporter.status()
-				log.G(ctx).Infof("stop pulling image %s: active requests=%v, bytes read=%v", reporter.ref, activeReqs, bytesRead)
+				completeLayers, totalLayers := reporter.reqReporter.layerStatus()
+				log.G(ctx).Infof("stop pulling image %s: active requests=%v, bytes read=%v, layers complete=%v/%v", reporter.ref, activeReqs, bytesRead, completeLayers, totalLayers)
 				return
 			}
 		}
@@ -1016,17 +1152,26 @@ func (reporter *transferProgressReporter) start(ctx context.Context) {
 
 func (reporter *transferProgressReporter) checkProgress(ctx context.Context, reportInterval time.Duration) {
 	activeReqs, bytesRead := reporter.reqReporter.status()
+	completeLayers, totalLayers := reporter.reqReporter.layerStatus()
 
 	lastSeenBytesRead := reporter.lastSeenBytesRead
 	lastSeenTimestamp := reporter.lastSeenTimestamp
 
+	// Logged at info level (rather than the debug level used before) so that
+	// operators pulling a large, slow image have something to look at in the
+	// containerd log beyond a silent multi-minute wait. CRI itself has no
+	// event type for reporting pull progress to kubelet (ContainerEventType
+	// only covers container lifecycle transitions), so this can't yet reach
+	// `kubectl describe pod` directly.
 	log.G(ctx).WithField("ref", reporter.ref).
 		WithField("activeReqs", activeReqs).
 		WithField("totalBytesRead", bytesRead).
+		WithField("layersComplete", completeLayers).
+		WithField("layersTotal", totalLayers).
 		WithField("lastSeenBytesRead", lastSeenBytesRead).
 		WithField("lastSeenTimestamp", lastSeenTimestamp.Format(time.RFC3339)).
 		WithField("reportInterval", reportInterval).
-		Debugf("progress for image pull")
+		Infof("progress for image pull")
 
 	if activeReqs == 0 || bytesRead > lastSeenBytesRead {
 		reporter.lastSeenBytesRead = bytesRead
@@ -0,0 +1,149 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package images
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"time"
+
+	"github.com/containerd/errdefs"
+	"github.com/containerd/log"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	remoteerrors "github.com/containerd/containerd/v2/core/remotes/errors"
+)
+
+// pullErrorClass is a coarse classification of why an image pull failed,
+// surfaced in the PullImage error message so operators (and kubelet's
+// ErrImagePull event) can tell an auth problem from a missing image from a
+// flaky registry without digging through logs.
+type pullErrorClass string
+
+const (
+	pullErrorAuth      pullErrorClass = "auth"
+	pullErrorNotFound  pullErrorClass = "not-found"
+	pullErrorTransient pullErrorClass = "transient"
+	pullErrorUnknown   pullErrorClass = "unknown"
+)
+
+// classifyPullError categorizes a failed pull attempt. It's used both to
+// annotate the final error returned to the kubelet and to decide whether
+// pullImageWithRetry should try again.
+func classifyPullError(err error) pullErrorClass {
+	switch {
+	case errdefs.IsNotFound(err):
+		return pullErrorNotFound
+	case errdefs.IsUnauthorized(err), errdefs.IsPermissionDenied(err):
+		return pullErrorAuth
+	case isTransientPullError(err):
+		return pullErrorTransient
+	default:
+		return pullErrorUnknown
+	}
+}
+
+// isTransientPullError reports whether err looks like a failure worth
+// retrying: DNS resolution failures, connection timeouts, and 5xx registry
+// responses. Auth failures, missing images, and other 4xx responses are
+// permanent - retrying them just wastes the remaining attempts.
+func isTransientPullError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errdefs.IsNotFound(err) || errdefs.IsUnauthorized(err) || errdefs.IsPermissionDenied(err) || errdefs.IsInvalidArgument(err) {
+		return false
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var unexpectedStatus remoteerrors.ErrUnexpectedStatus
+	if errors.As(err, &unexpectedStatus) {
+		return unexpectedStatus.StatusCode >= 500
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	return false
+}
+
+// pullImageWithRetry calls pull, retrying with jittered exponential backoff
+// while the failure classifies as transient, up to c.config.
+// ImagePullRetryMaxAttempts attempts in total. A maxAttempts of 1 or less
+// disables retrying.
+func (c *CRIImageService) pullImageWithRetry(ctx context.Context, ref string, pull func() (containerd.Image, error)) (containerd.Image, error) {
+	maxAttempts := c.config.ImagePullRetryMaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	baseDelay, err := time.ParseDuration(c.config.ImagePullRetryBaseDelay)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse image_pull_retry_base_delay %q: %w", c.config.ImagePullRetryBaseDelay, err)
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		image, err := pull()
+		if err == nil {
+			return image, nil
+		}
+		lastErr = err
+
+		class := classifyPullError(err)
+		if attempt == maxAttempts || class != pullErrorTransient {
+			return nil, fmt.Errorf("failed to pull image %q (%s): %w", ref, class, err)
+		}
+
+		delay := jitteredBackoff(baseDelay, attempt)
+		log.G(ctx).WithError(err).Warnf("pull attempt %d/%d for %q failed (%s), retrying in %s", attempt, maxAttempts, ref, class, delay)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
+	// Unreachable: the loop above always returns by the last attempt.
+	return nil, lastErr
+}
+
+// jitteredBackoff returns a randomized delay for the given attempt (1-indexed),
+// doubling base for each prior attempt and capped at one minute, with up to
+// 50% jitter to avoid synchronized retries across many pods pulling the same
+// image at once.
+func jitteredBackoff(base time.Duration, attempt int) time.Duration {
+	shift := attempt - 1
+	if shift > 10 {
+		shift = 10
+	}
+	backoff := base * time.Duration(int64(1)<<uint(shift))
+	if backoff <= 0 || backoff > time.Minute {
+		backoff = time.Minute
+	}
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
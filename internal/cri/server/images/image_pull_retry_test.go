@@ -0,0 +1,152 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package images
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/containerd/errdefs"
+	"github.com/stretchr/testify/assert"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	remoteerrors "github.com/containerd/containerd/v2/core/remotes/errors"
+	criconfig "github.com/containerd/containerd/v2/internal/cri/config"
+)
+
+func TestClassifyPullError(t *testing.T) {
+	for desc, test := range map[string]struct {
+		err      error
+		expected pullErrorClass
+	}{
+		"not found": {
+			err:      fmt.Errorf("wrap: %w", errdefs.ErrNotFound),
+			expected: pullErrorNotFound,
+		},
+		"unauthorized": {
+			err:      fmt.Errorf("wrap: %w", errdefs.ErrUnauthenticated),
+			expected: pullErrorAuth,
+		},
+		"permission denied": {
+			err:      fmt.Errorf("wrap: %w", errdefs.ErrPermissionDenied),
+			expected: pullErrorAuth,
+		},
+		"dns error": {
+			err:      &net.DNSError{Err: "no such host", Name: "example.invalid"},
+			expected: pullErrorTransient,
+		},
+		"5xx status": {
+			err:      remoteerrors.ErrUnexpectedStatus{StatusCode: 503},
+			expected: pullErrorTransient,
+		},
+		"4xx status": {
+			err:      remoteerrors.ErrUnexpectedStatus{StatusCode: 400},
+			expected: pullErrorUnknown,
+		},
+		"deadline exceeded": {
+			err:      fmt.Errorf("wrap: %w", context.DeadlineExceeded),
+			expected: pullErrorTransient,
+		},
+		"invalid argument is not transient": {
+			err:      fmt.Errorf("wrap: %w", errdefs.ErrInvalidArgument),
+			expected: pullErrorUnknown,
+		},
+		"generic error": {
+			err:      errors.New("something went wrong"),
+			expected: pullErrorUnknown,
+		},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, classifyPullError(test.err))
+		})
+	}
+}
+
+func TestJitteredBackoff(t *testing.T) {
+	base := 250 * time.Millisecond
+	for attempt := 1; attempt <= 12; attempt++ {
+		delay := jitteredBackoff(base, attempt)
+		assert.Greater(t, delay, time.Duration(0))
+		assert.LessOrEqual(t, delay, time.Minute)
+	}
+}
+
+func TestPullImageWithRetry(t *testing.T) {
+	t.Run("succeeds without retry", func(t *testing.T) {
+		c := &CRIImageService{config: criconfigWithRetry(3, time.Millisecond)}
+		calls := 0
+		pull := func() (containerd.Image, error) {
+			calls++
+			return nil, nil
+		}
+		_, err := c.pullImageWithRetry(context.Background(), "docker.io/library/busybox:latest", pull)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("retries transient errors then succeeds", func(t *testing.T) {
+		c := &CRIImageService{config: criconfigWithRetry(3, time.Millisecond)}
+		calls := 0
+		pull := func() (containerd.Image, error) {
+			calls++
+			if calls < 3 {
+				return nil, remoteerrors.ErrUnexpectedStatus{StatusCode: 503}
+			}
+			return nil, nil
+		}
+		_, err := c.pullImageWithRetry(context.Background(), "docker.io/library/busybox:latest", pull)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("does not retry auth errors", func(t *testing.T) {
+		c := &CRIImageService{config: criconfigWithRetry(3, time.Millisecond)}
+		calls := 0
+		pull := func() (containerd.Image, error) {
+			calls++
+			return nil, errdefs.ErrUnauthenticated
+		}
+		_, err := c.pullImageWithRetry(context.Background(), "docker.io/library/busybox:latest", pull)
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "auth")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		c := &CRIImageService{config: criconfigWithRetry(2, time.Millisecond)}
+		calls := 0
+		pull := func() (containerd.Image, error) {
+			calls++
+			return nil, remoteerrors.ErrUnexpectedStatus{StatusCode: 503}
+		}
+		_, err := c.pullImageWithRetry(context.Background(), "docker.io/library/busybox:latest", pull)
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "transient")
+		assert.Equal(t, 2, calls)
+	})
+}
+
+func criconfigWithRetry(maxAttempts int, baseDelay time.Duration) criconfig.ImageConfig {
+	cfg := criconfig.DefaultImageConfig()
+	cfg.ImagePullRetryMaxAttempts = maxAttempts
+	cfg.ImagePullRetryBaseDelay = baseDelay.String()
+	return cfg
+}
@@ -381,6 +381,7 @@ func TestSnapshotterFromPodSandboxConfig(t *testing.T) {
 	tests := []struct {
 		desc                string
 		podSandboxConfig    *runtime.PodSandboxConfig
+		runtimeHandler      string
 		expectedSnapshotter string
 		expectedErr         bool
 	}{
@@ -418,6 +419,21 @@ func TestSnapshotterFromPodSandboxConfig(t *testing.T) {
 			},
 			expectedSnapshotter: runtimeSnapshotter,
 		},
+		{
+			desc:                "should return snapshotter provided via the explicit runtime handler field",
+			runtimeHandler:      "existing-runtime",
+			expectedSnapshotter: runtimeSnapshotter,
+		},
+		{
+			desc: "should prefer the explicit runtime handler field over the legacy annotation",
+			podSandboxConfig: &runtime.PodSandboxConfig{
+				Annotations: map[string]string{
+					annotations.RuntimeHandler: "runtime-not-exists",
+				},
+			},
+			runtimeHandler:      "existing-runtime",
+			expectedSnapshotter: runtimeSnapshotter,
+		},
 	}
 
 	for _, tt := range tests {
@@ -428,7 +444,7 @@ func TestSnapshotterFromPodSandboxConfig(t *testing.T) {
 				Platform:    platforms.DefaultSpec(),
 				Snapshotter: runtimeSnapshotter,
 			}
-			snapshotter, err := cri.snapshotterFromPodSandboxConfig(context.Background(), "test-image", tt.podSandboxConfig)
+			snapshotter, err := cri.snapshotterFromPodSandboxConfig(context.Background(), "test-image", tt.podSandboxConfig, tt.runtimeHandler)
 			assert.Equal(t, tt.expectedSnapshotter, snapshotter)
 			if tt.expectedErr {
 				assert.Error(t, err)
@@ -437,6 +453,113 @@ func TestSnapshotterFromPodSandboxConfig(t *testing.T) {
 	}
 }
 
+func TestImagePlatformFromPodSandboxConfig(t *testing.T) {
+	wasmPlatform := ocispec.Platform{OS: "wasip1", Architecture: "wasm"}
+	tests := []struct {
+		desc             string
+		podSandboxConfig *runtime.PodSandboxConfig
+		runtimeHandler   string
+		expectedPlatform ocispec.Platform
+	}{
+		{
+			desc:             "should return host platform for nil podSandboxConfig",
+			expectedPlatform: platforms.DefaultSpec(),
+		},
+		{
+			desc: "should return host platform for runtime not found",
+			podSandboxConfig: &runtime.PodSandboxConfig{
+				Annotations: map[string]string{
+					annotations.RuntimeHandler: "runtime-not-exists",
+				},
+			},
+			expectedPlatform: platforms.DefaultSpec(),
+		},
+		{
+			desc: "should return platform configured for the runtime, e.g. a wasm shim's",
+			podSandboxConfig: &runtime.PodSandboxConfig{
+				Annotations: map[string]string{
+					annotations.RuntimeHandler: "wasm-runtime",
+				},
+			},
+			expectedPlatform: wasmPlatform,
+		},
+		{
+			desc:             "should return platform configured for the runtime via the explicit runtime handler field",
+			runtimeHandler:   "wasm-runtime",
+			expectedPlatform: wasmPlatform,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cri, _ := newTestCRIService()
+			cri.runtimePlatforms["wasm-runtime"] = ImagePlatform{
+				Platform:    wasmPlatform,
+				Snapshotter: "native",
+			}
+			platform := cri.imagePlatformFromPodSandboxConfig(context.Background(), "test-image", tt.podSandboxConfig, tt.runtimeHandler)
+			assert.Equal(t, tt.expectedPlatform, platform)
+		})
+	}
+}
+
+func TestPlatformMatcherFromPodSandboxConfig(t *testing.T) {
+	armV8 := ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v8"}
+	armV7 := ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}
+	armV6 := ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v6"}
+	armV5 := ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v5"}
+
+	tests := []struct {
+		desc            string
+		runtimePlatform ImagePlatform
+		matches         []ocispec.Platform
+		mismatches      []ocispec.Platform
+	}{
+		{
+			desc:            "default behavior still matches lower sub-platform variants",
+			runtimePlatform: ImagePlatform{Platform: armV8},
+			matches:         []ocispec.Platform{armV8, armV7, armV6, armV5},
+		},
+		{
+			desc:            "strict mode rejects lower sub-platform variants",
+			runtimePlatform: ImagePlatform{Platform: armV8, Strict: true},
+			matches:         []ocispec.Platform{armV8},
+			mismatches:      []ocispec.Platform{armV7, armV6, armV5},
+		},
+		{
+			desc:            "strict mode with fallbacks only matches the listed platforms exactly",
+			runtimePlatform: ImagePlatform{Platform: armV8, Strict: true, Fallbacks: []ocispec.Platform{armV7}},
+			matches:         []ocispec.Platform{armV8, armV7},
+			mismatches:      []ocispec.Platform{armV6, armV5},
+		},
+		{
+			desc:            "non-strict fallbacks extend matching beyond the primary platform's own vector",
+			runtimePlatform: ImagePlatform{Platform: ocispec.Platform{OS: "wasip1", Architecture: "wasm"}, Fallbacks: []ocispec.Platform{armV8}},
+			matches:         []ocispec.Platform{{OS: "wasip1", Architecture: "wasm"}, armV8, armV7},
+			mismatches:      []ocispec.Platform{{OS: "windows", Architecture: "amd64"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cri, _ := newTestCRIService()
+			cri.runtimePlatforms["test-runtime"] = tt.runtimePlatform
+
+			sandboxConfig := &runtime.PodSandboxConfig{
+				Annotations: map[string]string{annotations.RuntimeHandler: "test-runtime"},
+			}
+			m := cri.platformMatcherFromPodSandboxConfig(tt.runtimePlatform.Platform, tt.runtimePlatform.Fallbacks, sandboxConfig, "")
+
+			for _, p := range tt.matches {
+				assert.Truef(t, m.Match(p), "expected %s to match", platforms.Format(p))
+			}
+			for _, p := range tt.mismatches {
+				assert.Falsef(t, m.Match(p), "expected %s not to match", platforms.Format(p))
+			}
+		})
+	}
+}
+
 func TestImageGetLabels(t *testing.T) {
 
 	criService, _ := newTestCRIService()
@@ -532,6 +655,9 @@ func TestTransferProgressReporter(t *testing.T) {
 				activeReqs, totalBytesRead := r.reqReporter.status()
 				assert.Equal(t, int32(0), activeReqs, "Expected 0 active requests")
 				assert.Equal(t, uint64(1000), totalBytesRead, "Expected 1000 bytes read")
+				completeLayers, totalLayers := r.reqReporter.layerStatus()
+				assert.Equal(t, int32(1), completeLayers, "Expected 1 complete layer")
+				assert.Equal(t, int32(1), totalLayers, "Expected 1 total layer")
 			},
 		},
 		{
@@ -564,6 +690,9 @@ func TestTransferProgressReporter(t *testing.T) {
 				activeReqs, totalBytesRead := r.reqReporter.status()
 				assert.Equal(t, int32(0), activeReqs, "Expected 0 active requests")
 				assert.Equal(t, uint64(1000), totalBytesRead, "Expected 1000 bytes read")
+				completeLayers, totalLayers := r.reqReporter.layerStatus()
+				assert.Equal(t, int32(1), completeLayers, "Expected 1 complete layer")
+				assert.Equal(t, int32(1), totalLayers, "Expected 1 total layer")
 			},
 		},
 		{
@@ -44,8 +44,20 @@ func (c *CRIImageService) ImageStatus(ctx context.Context, r *runtime.ImageStatu
 		}
 		return nil, fmt.Errorf("can not resolve %q locally: %w", r.GetImage().GetImage(), err)
 	}
-	// TODO(random-liu): [P0] Make sure corresponding snapshot exists. What if snapshot
-	// doesn't exist?
+
+	ready, err := c.imageReadyForRuntimeHandler(ctx, &image, r.GetImage().GetRuntimeHandler())
+	if err != nil {
+		return nil, fmt.Errorf("failed to check whether image %q is unpacked for runtime %q: %w",
+			image.ID, r.GetImage().GetRuntimeHandler(), err)
+	}
+	if !ready {
+		// The image is known, but was never unpacked into the snapshotter this
+		// runtime handler uses (e.g. it was only ever pulled for a different
+		// runtime class). Report it as absent rather than ready, so kubelet
+		// pulls it again instead of going straight to CreateContainer against
+		// a snapshot that isn't there.
+		return &runtime.ImageStatusResponse{}, nil
+	}
 
 	runtimeImage := toCRIImage(image)
 	info, err := c.toCRIImageInfo(ctx, &image, r.GetVerbose())
@@ -59,6 +71,29 @@ func (c *CRIImageService) ImageStatus(ctx context.Context, r *runtime.ImageStatu
 	}, nil
 }
 
+// imageReadyForRuntimeHandler reports whether image has been unpacked into
+// the snapshotter configured for runtimeHandler. Most images are only ever
+// pulled for one snapshotter, so this is a no-op unless runtimeHandler names
+// a runtime class configured with a snapshotter other than the default one.
+func (c *CRIImageService) imageReadyForRuntimeHandler(ctx context.Context, image *imagestore.Image, runtimeHandler string) (bool, error) {
+	if runtimeHandler == "" || c.runtimePlatforms == nil || len(image.References) == 0 {
+		return true, nil
+	}
+	p, ok := c.runtimePlatforms[runtimeHandler]
+	if !ok || p.Snapshotter == "" || p.Snapshotter == c.config.Snapshotter {
+		return true, nil
+	}
+
+	img, err := c.client.GetImage(ctx, image.References[0])
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	return img.IsUnpacked(ctx, p.Snapshotter)
+}
+
 // toCRIImage converts internal image object to CRI runtime.Image.
 func toCRIImage(image imagestore.Image) *runtime.Image {
 	repoTags, repoDigests := util.ParseImageReferences(image.References)
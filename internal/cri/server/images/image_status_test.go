@@ -20,11 +20,14 @@ import (
 	"context"
 	"testing"
 
+	"github.com/containerd/errdefs"
+	"github.com/containerd/platforms"
 	imagespec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
 
+	containerd "github.com/containerd/containerd/v2/client"
 	imagestore "github.com/containerd/containerd/v2/internal/cri/store/image"
 )
 
@@ -73,6 +76,68 @@ func TestImageStatus(t *testing.T) {
 	assert.Equal(t, expected, resp.GetImage())
 }
 
+// fakeUnpackImage is a minimal containerd.Image stub used to test
+// imageReadyForRuntimeHandler without a real content/snapshot store. Only
+// IsUnpacked is exercised by that code path.
+type fakeUnpackImage struct {
+	containerd.Image
+	unpackedSnapshotters map[string]bool
+}
+
+func (i *fakeUnpackImage) IsUnpacked(_ context.Context, snapshotter string) (bool, error) {
+	return i.unpackedSnapshotters[snapshotter], nil
+}
+
+type fakeImageClient struct {
+	imageClient
+	images map[string]*fakeUnpackImage
+}
+
+func (f *fakeImageClient) GetImage(_ context.Context, ref string) (containerd.Image, error) {
+	img, ok := f.images[ref]
+	if !ok {
+		return nil, errdefs.ErrNotFound
+	}
+	return img, nil
+}
+
+func TestImageReadyForRuntimeHandler(t *testing.T) {
+	const ref = "gcr.io/library/busybox:latest"
+	image := imagestore.Image{
+		ID:         "sha256:d848ce12891bf78792cda4a23c58984033b0c397a55e93a1556202222ecc5ed4",
+		References: []string{ref},
+	}
+
+	c, _ := newTestCRIService()
+	c.config.Snapshotter = "overlayfs"
+	c.runtimePlatforms["devmapper-runtime"] = ImagePlatform{
+		Platform:    platforms.DefaultSpec(),
+		Snapshotter: "devmapper",
+	}
+	c.client = &fakeImageClient{
+		images: map[string]*fakeUnpackImage{
+			ref: {unpackedSnapshotters: map[string]bool{"overlayfs": true}},
+		},
+	}
+
+	ready, err := c.imageReadyForRuntimeHandler(context.Background(), &image, "")
+	assert.NoError(t, err)
+	assert.True(t, ready, "should be ready when no runtime handler is given")
+
+	ready, err = c.imageReadyForRuntimeHandler(context.Background(), &image, "runtime-not-exists")
+	assert.NoError(t, err)
+	assert.True(t, ready, "should be ready when the runtime handler isn't configured")
+
+	ready, err = c.imageReadyForRuntimeHandler(context.Background(), &image, "devmapper-runtime")
+	assert.NoError(t, err)
+	assert.False(t, ready, "should not be ready: image was never unpacked into devmapper")
+
+	c.client.(*fakeImageClient).images[ref].unpackedSnapshotters["devmapper"] = true
+	ready, err = c.imageReadyForRuntimeHandler(context.Background(), &image, "devmapper-runtime")
+	assert.NoError(t, err)
+	assert.True(t, ready, "should be ready once the image is unpacked into devmapper")
+}
+
 // TestGetUserFromImage tests the logic of getting image uid or user name of image user.
 func TestGetUserFromImage(t *testing.T) {
 	newI64 := func(i int64) *int64 { return &i }
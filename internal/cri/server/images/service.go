@@ -29,6 +29,7 @@ import (
 	imagestore "github.com/containerd/containerd/v2/internal/cri/store/image"
 	snapshotstore "github.com/containerd/containerd/v2/internal/cri/store/snapshot"
 	"github.com/containerd/containerd/v2/internal/kmutex"
+	"github.com/containerd/containerd/v2/pkg/imageverifier"
 	"github.com/containerd/log"
 	"github.com/containerd/platforms"
 	"golang.org/x/sync/semaphore"
@@ -48,6 +49,14 @@ type imageClient interface {
 type ImagePlatform struct {
 	Snapshotter string
 	Platform    imagespec.Platform
+
+	// Fallbacks are additional platforms accepted after Platform, in order.
+	// See config.ImagePlatform.Fallbacks.
+	Fallbacks []imagespec.Platform
+
+	// Strict disables the default sub-platform compatibility fallback when
+	// matching Platform and Fallbacks. See config.ImagePlatform.Strict.
+	Strict bool
 }
 
 type CRIImageService struct {
@@ -76,6 +85,11 @@ type CRIImageService struct {
 
 	// downloadLimiter is used to limit the number of concurrent downloads.
 	downloadLimiter *semaphore.Weighted
+
+	// verifierPolicy is applied to images pulled with client.Pull (i.e.
+	// when UseLocalImagePull is set). Pulls through the transfer service
+	// are verified by the transfer service's own policy instead.
+	verifierPolicy imageverifier.Policy
 }
 
 type GRPCCRIImageService struct {
@@ -96,6 +110,10 @@ type CRIImageServiceOptions struct {
 	Client imageClient
 
 	Transferrer transfer.Transferrer
+
+	// VerifierPolicy is applied to images pulled with client.Pull (i.e.
+	// when UseLocalImagePull is set).
+	VerifierPolicy imageverifier.Policy
 }
 
 // NewService creates a new CRI Image Service
@@ -124,6 +142,7 @@ func NewService(config criconfig.ImageConfig, options *CRIImageServiceOptions) (
 		transferrer:                 options.Transferrer,
 		unpackDuplicationSuppressor: kmutex.New(),
 		downloadLimiter:             downloadLimiter,
+		verifierPolicy:              options.VerifierPolicy,
 	}
 
 	log.L.Info("Start snapshots syncer")
@@ -197,6 +216,13 @@ func (c *CRIImageService) ImageFSPaths() map[string]string {
 	return c.imageFSPaths
 }
 
+// RegistryConfigPath returns the configured registry config_path, the
+// directory containing per-host hosts.toml mirror/auth configuration. It is
+// empty if config_path is not set.
+func (c *CRIImageService) RegistryConfigPath() string {
+	return c.config.Registry.ConfigPath
+}
+
 // PinnedImage is used to lookup a pinned image by name.
 // Most often used to get the "sandbox" image.
 func (c *CRIImageService) PinnedImage(name string) string {
@@ -19,11 +19,51 @@ package server
 import (
 	"context"
 
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
 )
 
+// Metric names reported through ListPodSandboxMetrics. These mirror the data
+// already exposed via PodSandboxStats/ContainerStats (see
+// internal/cri/server/sandbox_stats_linux.go and container_stats_list.go),
+// just flattened into the generic Metric format kubelet expects for this RPC.
+// Units match what the underlying cgroup stats actually provide (nanoseconds,
+// bytes), not necessarily the units used by any particular external exporter.
+const (
+	metricCPUUsageCoreNanoSeconds   = "container_cpu_usage_core_nanoseconds"
+	metricMemoryWorkingSetBytes     = "container_memory_working_set_bytes"
+	metricMemoryUsageBytes          = "container_memory_usage_bytes"
+	metricMemoryRSSBytes            = "container_memory_rss_bytes"
+	metricNetworkReceiveBytesTotal  = "pod_network_receive_bytes_total"
+	metricNetworkTransmitBytesTotal = "pod_network_transmit_bytes_total"
+	metricProcessCount              = "pod_process_count"
+	metricPodMemorySwapBytes        = "pod_memory_swap_bytes"
+	metricPodOverheadCPUNanoSeconds = "pod_overhead_cpu_usage_core_nanoseconds"
+	metricPodOverheadMemoryBytes    = "pod_overhead_memory_usage_bytes"
+	metricWritableLayerUsageBytes   = "container_writable_layer_usage_bytes"
+	metricWritableLayerInodesUsed   = "container_writable_layer_inodes_used"
+)
+
+// metricDescriptors is the fixed set of metrics this runtime can report via
+// ListPodSandboxMetrics. None of these carry per-metric label dimensions
+// beyond the pod/container identity already conveyed by PodSandboxMetrics and
+// ContainerMetrics, so label_keys is left empty for all of them.
+var metricDescriptors = []*runtime.MetricDescriptor{
+	{Name: metricCPUUsageCoreNanoSeconds, Help: "Cumulative CPU usage (sum across all cores) since object creation, in nanoseconds."},
+	{Name: metricMemoryWorkingSetBytes, Help: "Current working set memory usage in bytes."},
+	{Name: metricMemoryUsageBytes, Help: "Total memory usage in bytes, regardless of when it was accessed."},
+	{Name: metricMemoryRSSBytes, Help: "Anonymous and swap cache memory usage in bytes."},
+	{Name: metricNetworkReceiveBytesTotal, Help: "Cumulative bytes received on the pod's default network interface."},
+	{Name: metricNetworkTransmitBytesTotal, Help: "Cumulative bytes transmitted on the pod's default network interface."},
+	{Name: metricProcessCount, Help: "Number of processes currently running in the pod."},
+	{Name: metricPodMemorySwapBytes, Help: "Swap memory currently in use by the pod's cgroup, in bytes. Reported for the pod as a whole; CRI's MemoryUsage message has no per-container field for it."},
+	{Name: metricPodOverheadCPUNanoSeconds, Help: "Cumulative CPU usage (sum across all cores) by the pod sandbox container itself, isolated from its containers, in nanoseconds."},
+	{Name: metricPodOverheadMemoryBytes, Help: "Current memory usage by the pod sandbox container itself, isolated from its containers, in bytes."},
+	{Name: metricWritableLayerUsageBytes, Help: "Bytes used by the container's writable layer."},
+	{Name: metricWritableLayerInodesUsed, Help: "Inodes used by the container's writable layer."},
+}
+
+// ListMetricDescriptors gets the descriptors for the metrics that will be
+// returned in ListPodSandboxMetrics.
 func (c *criService) ListMetricDescriptors(context.Context, *runtime.ListMetricDescriptorsRequest) (*runtime.ListMetricDescriptorsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListMetricDescriptors not implemented")
+	return &runtime.ListMetricDescriptorsResponse{Descriptors: metricDescriptors}, nil
 }
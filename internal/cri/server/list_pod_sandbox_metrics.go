@@ -18,12 +18,156 @@ package server
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"time"
 
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"github.com/containerd/errdefs"
+	"github.com/containerd/log"
+	"github.com/containerd/ttrpc"
 	runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
+
+	sandboxstore "github.com/containerd/containerd/v2/internal/cri/store/sandbox"
 )
 
-func (c *criService) ListPodSandboxMetrics(context.Context, *runtime.ListPodSandboxMetricsRequest) (*runtime.ListPodSandboxMetricsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListPodSandboxMetrics not implemented")
+// ListPodSandboxMetrics gets pod sandbox metrics from CRI Runtime.
+//
+// It reuses the same cgroup/network stats collection as PodSandboxStats, just
+// flattened into the generic Metric/ContainerMetrics format described by
+// ListMetricDescriptors, so kubelet can source pod-level metrics directly
+// from the runtime instead of scraping cAdvisor.
+func (c *criService) ListPodSandboxMetrics(
+	ctx context.Context,
+	r *runtime.ListPodSandboxMetricsRequest,
+) (*runtime.ListPodSandboxMetricsResponse, error) {
+	var sandboxes []sandboxstore.Sandbox
+	for _, sandbox := range c.sandboxStore.List() {
+		if sandbox.Status.Get().State != sandboxstore.StateReady {
+			continue
+		}
+		sandboxes = append(sandboxes, sandbox)
+	}
+
+	podMetrics := make([]*runtime.PodSandboxMetrics, len(sandboxes))
+	errs := make([]error, len(sandboxes))
+
+	var wg sync.WaitGroup
+	for i, sandbox := range sandboxes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stats, err := c.podSandboxStats(ctx, sandbox)
+			switch {
+			case errdefs.IsUnavailable(err), errdefs.IsNotFound(err):
+				log.G(ctx).WithField("podsandboxid", sandbox.ID).WithError(err).Debug("failed to get pod sandbox metrics, this is likely a transient error")
+			case errors.Is(err, ttrpc.ErrClosed):
+				log.G(ctx).WithField("podsandboxid", sandbox.ID).WithError(err).Debug("failed to get pod sandbox metrics, connection closed")
+			case err != nil:
+				errs[i] = err
+			default:
+				pm := toPodSandboxMetrics(sandbox.ID, stats)
+				if swapBytes, ok, err := c.sandboxMemorySwapBytes(sandbox); err != nil {
+					log.G(ctx).WithField("podsandboxid", sandbox.ID).WithError(err).Debug("failed to get pod sandbox swap usage")
+				} else if ok {
+					pm.Metrics = append(pm.Metrics, gaugeMetric(metricPodMemorySwapBytes, &runtime.UInt64Value{Value: swapBytes}, time.Now().UnixNano()))
+				}
+				if overheadCPU, overheadMemory, ok, err := c.sandboxOverheadUsage(sandbox); err != nil {
+					log.G(ctx).WithField("podsandboxid", sandbox.ID).WithError(err).Debug("failed to get pod sandbox overhead usage")
+				} else if ok {
+					pm.Metrics = append(pm.Metrics,
+						counterMetric(metricPodOverheadCPUNanoSeconds, overheadCPU.GetUsageCoreNanoSeconds(), overheadCPU.GetTimestamp()),
+						gaugeMetric(metricPodOverheadMemoryBytes, overheadMemory.GetUsageBytes(), overheadMemory.GetTimestamp()),
+					)
+				}
+				podMetrics[i] = pm
+			}
+		}()
+	}
+	wg.Wait()
+
+	resp := new(runtime.ListPodSandboxMetricsResponse)
+	for _, m := range podMetrics {
+		if m != nil {
+			resp.PodMetrics = append(resp.PodMetrics, m)
+		}
+	}
+
+	return resp, errors.Join(errs...)
+}
+
+// toPodSandboxMetrics flattens a PodSandboxStats into the generic
+// Metric/ContainerMetrics format. Any field left unset by the stats
+// collection (e.g. because the platform doesn't support it) is simply
+// omitted rather than reported as zero.
+func toPodSandboxMetrics(podSandboxID string, stats *runtime.PodSandboxStats) *runtime.PodSandboxMetrics {
+	linux := stats.GetLinux()
+	if linux == nil {
+		return &runtime.PodSandboxMetrics{PodSandboxId: podSandboxID}
+	}
+
+	m := &runtime.PodSandboxMetrics{PodSandboxId: podSandboxID}
+	m.Metrics = append(m.Metrics, cpuMemoryMetrics(linux.GetCpu(), linux.GetMemory())...)
+	if network := linux.GetNetwork().GetDefaultInterface(); network != nil {
+		networkTimestamp := linux.GetNetwork().GetTimestamp()
+		m.Metrics = append(m.Metrics,
+			counterMetric(metricNetworkReceiveBytesTotal, network.GetRxBytes(), networkTimestamp),
+			counterMetric(metricNetworkTransmitBytesTotal, network.GetTxBytes(), networkTimestamp),
+		)
+	}
+	if process := linux.GetProcess(); process != nil {
+		m.Metrics = append(m.Metrics, gaugeMetric(metricProcessCount, process.GetProcessCount(), process.Timestamp))
+	}
+
+	for _, cs := range linux.GetContainers() {
+		m.ContainerMetrics = append(m.ContainerMetrics, toContainerMetrics(cs))
+	}
+
+	return m
+}
+
+func toContainerMetrics(cs *runtime.ContainerStats) *runtime.ContainerMetrics {
+	cm := &runtime.ContainerMetrics{ContainerId: cs.GetAttributes().GetId()}
+	cm.Metrics = append(cm.Metrics, cpuMemoryMetrics(cs.GetCpu(), cs.GetMemory())...)
+	if wl := cs.GetWritableLayer(); wl != nil {
+		cm.Metrics = append(cm.Metrics,
+			gaugeMetric(metricWritableLayerUsageBytes, wl.GetUsedBytes(), wl.Timestamp),
+			gaugeMetric(metricWritableLayerInodesUsed, wl.GetInodesUsed(), wl.Timestamp),
+		)
+	}
+	return cm
+}
+
+func cpuMemoryMetrics(cpu *runtime.CpuUsage, memory *runtime.MemoryUsage) []*runtime.Metric {
+	var metrics []*runtime.Metric
+	if cpu != nil {
+		metrics = append(metrics, counterMetric(metricCPUUsageCoreNanoSeconds, cpu.GetUsageCoreNanoSeconds(), cpu.Timestamp))
+	}
+	if memory != nil {
+		metrics = append(metrics,
+			gaugeMetric(metricMemoryWorkingSetBytes, memory.GetWorkingSetBytes(), memory.Timestamp),
+			gaugeMetric(metricMemoryUsageBytes, memory.GetUsageBytes(), memory.Timestamp),
+			gaugeMetric(metricMemoryRSSBytes, memory.GetRssBytes(), memory.Timestamp),
+		)
+	}
+	return metrics
+}
+
+func gaugeMetric(name string, value *runtime.UInt64Value, timestamp int64) *runtime.Metric {
+	return newMetric(name, runtime.MetricType_GAUGE, value, timestamp)
+}
+
+func counterMetric(name string, value *runtime.UInt64Value, timestamp int64) *runtime.Metric {
+	return newMetric(name, runtime.MetricType_COUNTER, value, timestamp)
+}
+
+func newMetric(name string, metricType runtime.MetricType, value *runtime.UInt64Value, timestamp int64) *runtime.Metric {
+	if value == nil {
+		value = &runtime.UInt64Value{}
+	}
+	return &runtime.Metric{
+		Name:       name,
+		Timestamp:  timestamp,
+		MetricType: metricType,
+		Value:      value,
+	}
 }
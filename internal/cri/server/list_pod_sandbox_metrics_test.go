@@ -0,0 +1,124 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+func TestListMetricDescriptors(t *testing.T) {
+	c := newTestCRIService()
+	resp, err := c.ListMetricDescriptors(context.Background(), &runtime.ListMetricDescriptorsRequest{})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.GetDescriptors())
+	for _, d := range resp.GetDescriptors() {
+		assert.NotEmpty(t, d.GetName())
+		assert.NotEmpty(t, d.GetHelp())
+	}
+}
+
+func findMetric(metrics []*runtime.Metric, name string) *runtime.Metric {
+	for _, m := range metrics {
+		if m.GetName() == name {
+			return m
+		}
+	}
+	return nil
+}
+
+func TestToPodSandboxMetrics(t *testing.T) {
+	stats := &runtime.PodSandboxStats{
+		Linux: &runtime.LinuxPodSandboxStats{
+			Cpu: &runtime.CpuUsage{
+				Timestamp:            100,
+				UsageCoreNanoSeconds: &runtime.UInt64Value{Value: 1000},
+			},
+			Memory: &runtime.MemoryUsage{
+				Timestamp:       100,
+				WorkingSetBytes: &runtime.UInt64Value{Value: 2048},
+				UsageBytes:      &runtime.UInt64Value{Value: 4096},
+				RssBytes:        &runtime.UInt64Value{Value: 1024},
+			},
+			Network: &runtime.NetworkUsage{
+				Timestamp: 100,
+				DefaultInterface: &runtime.NetworkInterfaceUsage{
+					Name:    "eth0",
+					RxBytes: &runtime.UInt64Value{Value: 111},
+					TxBytes: &runtime.UInt64Value{Value: 222},
+				},
+			},
+			Process: &runtime.ProcessUsage{
+				Timestamp:    100,
+				ProcessCount: &runtime.UInt64Value{Value: 3},
+			},
+			Containers: []*runtime.ContainerStats{
+				{
+					Attributes: &runtime.ContainerAttributes{Id: "c1"},
+					Cpu: &runtime.CpuUsage{
+						Timestamp:            100,
+						UsageCoreNanoSeconds: &runtime.UInt64Value{Value: 500},
+					},
+					WritableLayer: &runtime.FilesystemUsage{
+						Timestamp:  100,
+						UsedBytes:  &runtime.UInt64Value{Value: 5000},
+						InodesUsed: &runtime.UInt64Value{Value: 10},
+					},
+				},
+			},
+		},
+	}
+
+	podMetrics := toPodSandboxMetrics("sandbox1", stats)
+	assert.Equal(t, "sandbox1", podMetrics.GetPodSandboxId())
+
+	cpu := findMetric(podMetrics.GetMetrics(), metricCPUUsageCoreNanoSeconds)
+	require.NotNil(t, cpu)
+	assert.Equal(t, uint64(1000), cpu.GetValue().GetValue())
+	assert.Equal(t, runtime.MetricType_COUNTER, cpu.GetMetricType())
+
+	rx := findMetric(podMetrics.GetMetrics(), metricNetworkReceiveBytesTotal)
+	require.NotNil(t, rx)
+	assert.Equal(t, uint64(111), rx.GetValue().GetValue())
+
+	processCount := findMetric(podMetrics.GetMetrics(), metricProcessCount)
+	require.NotNil(t, processCount)
+	assert.Equal(t, uint64(3), processCount.GetValue().GetValue())
+
+	require.Len(t, podMetrics.GetContainerMetrics(), 1)
+	cm := podMetrics.GetContainerMetrics()[0]
+	assert.Equal(t, "c1", cm.GetContainerId())
+
+	usage := findMetric(cm.GetMetrics(), metricWritableLayerUsageBytes)
+	require.NotNil(t, usage)
+	assert.Equal(t, uint64(5000), usage.GetValue().GetValue())
+
+	inodes := findMetric(cm.GetMetrics(), metricWritableLayerInodesUsed)
+	require.NotNil(t, inodes)
+	assert.Equal(t, uint64(10), inodes.GetValue().GetValue())
+}
+
+func TestToPodSandboxMetricsNilLinux(t *testing.T) {
+	podMetrics := toPodSandboxMetrics("sandbox1", &runtime.PodSandboxStats{})
+	assert.Equal(t, "sandbox1", podMetrics.GetPodSandboxId())
+	assert.Empty(t, podMetrics.GetMetrics())
+	assert.Empty(t, podMetrics.GetContainerMetrics())
+}
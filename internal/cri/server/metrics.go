@@ -40,6 +40,9 @@ var (
 	networkPluginOperations        metrics.LabeledCounter
 	networkPluginOperationsErrors  metrics.LabeledCounter
 	networkPluginOperationsLatency metrics.LabeledTimer
+
+	streamingSessionsActive   metrics.Gauge
+	streamingSessionsRejected metrics.LabeledCounter
 )
 
 func init() {
@@ -66,6 +69,9 @@ func init() {
 	networkPluginOperationsErrors = ns.NewLabeledCounter("network_plugin_operations_errors_total", "cumulative number of network plugin operations by operation type", "operation_type")
 	networkPluginOperationsLatency = ns.NewLabeledTimer("network_plugin_operations_duration_seconds", "latency in seconds of network plugin operations. Broken down by operation type", "operation_type")
 
+	streamingSessionsActive = ns.NewGauge("streaming_sessions_active", "number of active exec/attach streaming sessions", metrics.Total)
+	streamingSessionsRejected = ns.NewLabeledCounter("streaming_sessions_rejected", "count of exec/attach streaming sessions rejected for exceeding the per-container limit", "session_type")
+
 	metrics.Register(ns)
 }
 
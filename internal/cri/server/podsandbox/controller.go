@@ -19,6 +19,7 @@ package podsandbox
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"time"
 
 	"github.com/containerd/log"
@@ -36,6 +37,7 @@ import (
 	"github.com/containerd/containerd/v2/internal/cri/server/podsandbox/types"
 	imagestore "github.com/containerd/containerd/v2/internal/cri/store/image"
 	ctrdutil "github.com/containerd/containerd/v2/internal/cri/util"
+	"github.com/containerd/containerd/v2/internal/userns"
 	"github.com/containerd/containerd/v2/pkg/oci"
 	osinterface "github.com/containerd/containerd/v2/pkg/os"
 	"github.com/containerd/containerd/v2/pkg/protobuf"
@@ -88,6 +90,19 @@ func init() {
 				store:          NewStore(),
 			}
 
+			if cfg := c.config.UsernsIDRangeLength; cfg > 0 {
+				allocator, err := userns.NewRangeAllocator(
+					filepath.Join(c.config.StateDir, "userns-id-ranges"),
+					uint32(c.config.UsernsIDRangeStart),
+					uint32(c.config.UsernsIDRangeLength),
+					uint32(c.config.UsernsIDRangeSize),
+				)
+				if err != nil {
+					return nil, fmt.Errorf("unable to init user namespace ID range allocator: %w", err)
+				}
+				c.usernsAllocator = allocator
+			}
+
 			eventMonitor := events.NewEventMonitor(&podSandboxEventHandler{
 				controller: &c,
 			})
@@ -131,6 +146,11 @@ type Controller struct {
 	eventMonitor *events.EventMonitor
 
 	store *Store
+
+	// usernsAllocator allocates non-overlapping host UID/GID ranges for pods
+	// using user namespaces without an explicit mapping. Nil unless
+	// UsernsIDRangeLength is configured.
+	usernsAllocator *userns.RangeAllocator
 }
 
 var _ sandbox.Controller = (*Controller)(nil)
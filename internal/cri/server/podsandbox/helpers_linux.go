@@ -340,7 +340,13 @@ func parseUsernsIDMap(runtimeIDMap []*runtime.IDMapping) ([]runtimespec.LinuxIDM
 	return m, nil
 }
 
-func parseUsernsIDs(userns *runtime.UserNamespace) (uids, gids []runtimespec.LinuxIDMapping, retErr error) {
+// resolveUsernsIDs parses the UID/GID mappings the kubelet supplied for a
+// pod's user namespace. If the kubelet requests namespace mode POD but
+// leaves the mappings empty, the pod is assigned a host ID range out of
+// usernsAllocator instead of erroring, so long as one is configured; this is
+// what lets a cluster opt in to user namespaces without every kubelet also
+// running its own host ID range allocator.
+func (c *Controller) resolveUsernsIDs(id string, userns *runtime.UserNamespace) (uids, gids []runtimespec.LinuxIDMapping, retErr error) {
 	if userns == nil {
 		// If userns is not set, the kubelet doesn't support this option
 		// and we should just fallback to no userns. This is completely
@@ -366,7 +372,15 @@ func parseUsernsIDs(userns *runtime.UserNamespace) (uids, gids []runtimespec.Lin
 	case runtime.NamespaceMode_POD:
 		// This is valid, we will handle it in WithPodNamespaces().
 		if len(uids) == 0 || len(gids) == 0 {
-			return nil, nil, fmt.Errorf("can't use user namespace mode %q without UID and GID mappings", mode)
+			if c.usernsAllocator == nil {
+				return nil, nil, fmt.Errorf("can't use user namespace mode %q without UID and GID mappings", mode)
+			}
+			r, err := c.usernsAllocator.Allocate(id)
+			if err != nil {
+				return nil, nil, fmt.Errorf("allocating user namespace ID range: %w", err)
+			}
+			uids = []runtimespec.LinuxIDMapping{{ContainerID: 0, HostID: r.HostID, Size: r.Size}}
+			gids = []runtimespec.LinuxIDMapping{{ContainerID: 0, HostID: r.HostID, Size: r.Size}}
 		}
 	default:
 		return nil, nil, fmt.Errorf("unsupported user namespace mode: %q", mode)
@@ -375,14 +389,14 @@ func parseUsernsIDs(userns *runtime.UserNamespace) (uids, gids []runtimespec.Lin
 	return uids, gids, nil
 }
 
-func snapshotterRemapOpts(nsOpts *runtime.NamespaceOption) ([]snapshots.Opt, error) {
+func (c *Controller) snapshotterRemapOpts(id string, nsOpts *runtime.NamespaceOption) ([]snapshots.Opt, error) {
 	snapshotOpt := []snapshots.Opt{}
 	usernsOpts := nsOpts.GetUsernsOptions()
 	if usernsOpts == nil {
 		return snapshotOpt, nil
 	}
 
-	uids, gids, err := parseUsernsIDs(usernsOpts)
+	uids, gids, err := c.resolveUsernsIDs(id, usernsOpts)
 	if err != nil {
 		return nil, fmt.Errorf("user namespace configuration: %w", err)
 	}
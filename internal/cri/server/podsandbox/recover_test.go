@@ -119,6 +119,14 @@ func (f *fakeTask) Resume(ctx context.Context) error {
 	return errdefs.ErrNotImplemented
 }
 
+func (f *fakeTask) PauseWithTimeout(ctx context.Context, timeout time.Duration) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (f *fakeTask) Freeze(ctx context.Context, timeout, duration time.Duration) error {
+	return errdefs.ErrNotImplemented
+}
+
 func (f *fakeTask) Exec(ctx context.Context, s string, process *specs.Process, creator cio.Creator) (containerd.Process, error) {
 	return nil, errdefs.ErrNotImplemented
 }
@@ -63,6 +63,12 @@ func (c *Controller) Shutdown(ctx context.Context, sandboxID string) error {
 
 	c.store.Remove(sandboxID)
 
+	if c.usernsAllocator != nil {
+		if err := c.usernsAllocator.Release(sandboxID); err != nil {
+			return fmt.Errorf("failed to release user namespace ID range for sandbox %q: %w", sandboxID, err)
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,94 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package podsandbox
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
+
+	criconfig "github.com/containerd/containerd/v2/internal/cri/config"
+	imagestore "github.com/containerd/containerd/v2/internal/cri/store/image"
+)
+
+// fakePinnedImageService is a minimal ImageService stub that only backs
+// PinnedImage, for exercising getSandboxImageName's lookup order.
+type fakePinnedImageService struct {
+	pinned map[string]string
+}
+
+func (f *fakePinnedImageService) LocalResolve(string) (imagestore.Image, error) {
+	return imagestore.Image{}, nil
+}
+func (f *fakePinnedImageService) GetImage(string) (imagestore.Image, error) {
+	return imagestore.Image{}, nil
+}
+func (f *fakePinnedImageService) PullImage(context.Context, string, func(string) (string, string, error), *runtime.PodSandboxConfig, string) (string, error) {
+	return "", nil
+}
+func (f *fakePinnedImageService) RuntimeSnapshotter(context.Context, criconfig.Runtime) string {
+	return ""
+}
+func (f *fakePinnedImageService) PinnedImage(name string) string { return f.pinned[name] }
+
+func TestGetSandboxImageName(t *testing.T) {
+	for _, test := range []struct {
+		desc            string
+		pinned          map[string]string
+		runtimeHandler  string
+		expectImageName string
+	}{
+		{
+			desc:            "no pinned images falls back to the default",
+			pinned:          map[string]string{},
+			runtimeHandler:  "runc",
+			expectImageName: criconfig.DefaultSandboxImage,
+		},
+		{
+			desc:            "shared sandbox pin applies to every runtime handler",
+			pinned:          map[string]string{"sandbox": "registry.example.com/pause:1.0"},
+			runtimeHandler:  "runc",
+			expectImageName: "registry.example.com/pause:1.0",
+		},
+		{
+			desc: "per-runtime-handler pin takes priority over the shared pin",
+			pinned: map[string]string{
+				"sandbox":      "registry.example.com/pause:1.0",
+				"sandbox/kata": "registry.example.com/pause-kata:1.0",
+			},
+			runtimeHandler:  "kata",
+			expectImageName: "registry.example.com/pause-kata:1.0",
+		},
+		{
+			desc: "a runtime handler without its own pin uses the shared pin",
+			pinned: map[string]string{
+				"sandbox":      "registry.example.com/pause:1.0",
+				"sandbox/kata": "registry.example.com/pause-kata:1.0",
+			},
+			runtimeHandler:  "runc",
+			expectImageName: "registry.example.com/pause:1.0",
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			c := newControllerService()
+			c.imageService = &fakePinnedImageService{pinned: test.pinned}
+			assert.Equal(t, test.expectImageName, c.getSandboxImageName(test.runtimeHandler))
+		})
+	}
+}
@@ -76,7 +76,7 @@ func (c *Controller) Start(ctx context.Context, id string) (cin sandbox.Controll
 		labels = map[string]string{}
 	)
 
-	sandboxImage := c.getSandboxImageName()
+	sandboxImage := c.getSandboxImageName(metadata.RuntimeHandler)
 	// Ensure sandbox container image snapshot.
 	image, err := c.ensureImageExists(ctx, sandboxImage, config, metadata.RuntimeHandler)
 	if err != nil {
@@ -133,7 +133,7 @@ func (c *Controller) Start(ctx context.Context, id string) (cin sandbox.Controll
 	// NOTE: sandboxContainerSpec SHOULD NOT have side
 	// effect, e.g. accessing/creating files, so that we can test
 	// it safely.
-	spec, err := c.sandboxContainerSpec(id, config, &image.ImageSpec.Config, metadata.NetNSPath, ociRuntime.PodAnnotations)
+	spec, err := c.sandboxContainerSpec(id, config, &image.ImageSpec.Config, metadata.NetNSPath, ociRuntime.PodAnnotations, metadata.RuntimeHandler)
 	if err != nil {
 		return cin, fmt.Errorf("failed to generate sandbox container spec: %w", err)
 	}
@@ -180,7 +180,7 @@ func (c *Controller) Start(ctx context.Context, id string) (cin sandbox.Controll
 	sandboxLabels := ctrdutil.BuildLabels(config.Labels, image.ImageSpec.Config.Labels, crilabels.ContainerKindSandbox)
 
 	snapshotterOpt := []snapshots.Opt{snapshots.WithLabels(snapshots.FilterInheritedLabels(config.Annotations))}
-	extraSOpts, err := sandboxSnapshotterOpts(config)
+	extraSOpts, err := c.sandboxSnapshotterOpts(id, config)
 	if err != nil {
 		return cin, err
 	}
@@ -335,12 +335,20 @@ func (c *Controller) ensureImageExists(ctx context.Context, ref string, config *
 	return &newImage, nil
 }
 
-func (c *Controller) getSandboxImageName() string {
-	// returns the name of the sandbox image used to scope pod shared resources used by the pod's containers,
-	// if empty return the default sandbox image.
+// getSandboxImageName returns the name of the sandbox image used to scope pod
+// shared resources used by the pod's containers. Operators can pin a
+// different sandbox image per runtime handler by configuring
+// `pinned_images` on the images plugin with a "sandbox/<runtime-handler>"
+// key; that takes priority over the shared "sandbox" entry, which in turn
+// takes priority over the built-in default.
+func (c *Controller) getSandboxImageName(runtimeHandler string) string {
 	if c.imageService != nil {
-		sandboxImage := c.imageService.PinnedImage("sandbox")
-		if sandboxImage != "" {
+		if runtimeHandler != "" {
+			if sandboxImage := c.imageService.PinnedImage("sandbox/" + runtimeHandler); sandboxImage != "" {
+				return sandboxImage
+			}
+		}
+		if sandboxImage := c.imageService.PinnedImage("sandbox"); sandboxImage != "" {
 			return sandboxImage
 		}
 	}
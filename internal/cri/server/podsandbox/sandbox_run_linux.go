@@ -38,7 +38,7 @@ import (
 )
 
 func (c *Controller) sandboxContainerSpec(id string, config *runtime.PodSandboxConfig,
-	imageConfig *imagespec.ImageConfig, nsPath string, runtimePodAnnotations []string) (_ *runtimespec.Spec, retErr error) {
+	imageConfig *imagespec.ImageConfig, nsPath string, runtimePodAnnotations []string, runtimeHandler string) (_ *runtimespec.Spec, retErr error) {
 	// Creates a spec Generator with the default spec.
 	// TODO(random-liu): [P1] Compare the default settings with docker and containerd default.
 	specOpts := []oci.SpecOpts{
@@ -92,7 +92,7 @@ func (c *Controller) sandboxContainerSpec(id string, config *runtime.PodSandboxC
 	}
 
 	usernsOpts := nsOptions.GetUsernsOptions()
-	uids, gids, err := parseUsernsIDs(usernsOpts)
+	uids, gids, err := c.resolveUsernsIDs(id, usernsOpts)
 	var usernsEnabled bool
 	if err != nil {
 		return nil, fmt.Errorf("user namespace configuration: %w", err)
@@ -194,7 +194,7 @@ func (c *Controller) sandboxContainerSpec(id string, config *runtime.PodSandboxC
 		specOpts = append(specOpts, customopts.WithAnnotation(pKey, pValue))
 	}
 
-	specOpts = append(specOpts, annotations.DefaultCRIAnnotations(id, "", c.getSandboxImageName(), config, true)...)
+	specOpts = append(specOpts, annotations.DefaultCRIAnnotations(id, "", c.getSandboxImageName(runtimeHandler), config, true)...)
 
 	return c.runtimeSpec(id, "", specOpts...)
 }
@@ -343,7 +343,7 @@ func (c *Controller) cleanupSandboxFiles(id string, config *runtime.PodSandboxCo
 
 // sandboxSnapshotterOpts generates any platform specific snapshotter options
 // for a sandbox container.
-func sandboxSnapshotterOpts(config *runtime.PodSandboxConfig) ([]snapshots.Opt, error) {
+func (c *Controller) sandboxSnapshotterOpts(id string, config *runtime.PodSandboxConfig) ([]snapshots.Opt, error) {
 	nsOpts := config.GetLinux().GetSecurityContext().GetNamespaceOptions()
-	return snapshotterRemapOpts(nsOpts)
+	return c.snapshotterRemapOpts(id, nsOpts)
 }
@@ -28,8 +28,8 @@ import (
 )
 
 func (c *Controller) sandboxContainerSpec(id string, config *runtime.PodSandboxConfig,
-	imageConfig *imagespec.ImageConfig, nsPath string, runtimePodAnnotations []string) (_ *runtimespec.Spec, retErr error) {
-	return c.runtimeSpec(id, "", annotations.DefaultCRIAnnotations(id, "", c.getSandboxImageName(), config, true)...)
+	imageConfig *imagespec.ImageConfig, nsPath string, runtimePodAnnotations []string, runtimeHandler string) (_ *runtimespec.Spec, retErr error) {
+	return c.runtimeSpec(id, "", annotations.DefaultCRIAnnotations(id, "", c.getSandboxImageName(runtimeHandler), config, true)...)
 }
 
 // sandboxContainerSpecOpts generates OCI spec options for
@@ -52,6 +52,6 @@ func (c *Controller) cleanupSandboxFiles(id string, config *runtime.PodSandboxCo
 
 // sandboxSnapshotterOpts generates any platform specific snapshotter options
 // for a sandbox container.
-func sandboxSnapshotterOpts(config *runtime.PodSandboxConfig) ([]snapshots.Opt, error) {
+func (c *Controller) sandboxSnapshotterOpts(id string, config *runtime.PodSandboxConfig) ([]snapshots.Opt, error) {
 	return []snapshots.Opt{}, nil
 }
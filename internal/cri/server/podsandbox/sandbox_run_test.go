@@ -111,7 +111,7 @@ func TestSandboxContainerSpec(t *testing.T) {
 				test.imageConfigChange(imageConfig)
 			}
 			spec, err := c.sandboxContainerSpec(testID, config, imageConfig, nsPath,
-				test.podAnnotations)
+				test.podAnnotations, "")
 			if test.expectErr {
 				assert.Error(t, err)
 				assert.Nil(t, spec)
@@ -32,7 +32,7 @@ import (
 )
 
 func (c *Controller) sandboxContainerSpec(id string, config *runtime.PodSandboxConfig,
-	imageConfig *imagespec.ImageConfig, nsPath string, runtimePodAnnotations []string) (*runtimespec.Spec, error) {
+	imageConfig *imagespec.ImageConfig, nsPath string, runtimePodAnnotations []string, runtimeHandler string) (*runtimespec.Spec, error) {
 	// Creates a spec Generator with the default spec.
 	specOpts := []oci.SpecOpts{
 		oci.WithEnv(imageConfig.Env),
@@ -84,7 +84,7 @@ func (c *Controller) sandboxContainerSpec(id string, config *runtime.PodSandboxC
 	specOpts = append(specOpts, customopts.WithAnnotation(annotations.WindowsHostProcess, strconv.FormatBool(config.GetWindows().GetSecurityContext().GetHostProcess())))
 
 	specOpts = append(specOpts,
-		annotations.DefaultCRIAnnotations(id, "", c.getSandboxImageName(), config, true)...,
+		annotations.DefaultCRIAnnotations(id, "", c.getSandboxImageName(runtimeHandler), config, true)...,
 	)
 
 	return c.runtimeSpec(id, "", specOpts...)
@@ -106,6 +106,6 @@ func (c *Controller) cleanupSandboxFiles(id string, config *runtime.PodSandboxCo
 }
 
 // No sandbox snapshotter options needed for windows.
-func sandboxSnapshotterOpts(config *runtime.PodSandboxConfig) ([]snapshots.Opt, error) {
+func (c *Controller) sandboxSnapshotterOpts(id string, config *runtime.PodSandboxConfig) ([]snapshots.Opt, error) {
 	return []snapshots.Opt{}, nil
 }
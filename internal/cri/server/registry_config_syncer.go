@@ -0,0 +1,180 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/containerd/log"
+	"github.com/fsnotify/fsnotify"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// registryConfigSyncer watches the registry config_path directory tree for
+// fs change events so that a mirror/auth config rollout which breaks the
+// hosts.toml layout is surfaced before it is hit by a real pull.
+//
+// Unlike CNI config, registry hosts config is not cached anywhere: every
+// image pull reads config_path fresh (see CRIImageService.registryHosts), so
+// new mirrors already take effect without a restart and there is nothing to
+// reload here. What is missing is any signal that a just-dropped config is
+// well formed: core/remotes/docker/config.loadHostDir silently falls back to
+// the certificate-file layout on a hosts.toml parse error, only logging it,
+// so a broken rollout does not fail a pull at all, it just quietly drops the
+// configured mirrors. This re-validates hosts.toml syntax directly on every
+// change and records the result for CRIRuntimeStatus, the same way
+// cniNetConfSyncer reports lastCNILoadStatus.
+//
+// fsnotify watches are not recursive, so config_path and every existing
+// per-host directory under it are watched individually; a host directory
+// created after startup is picked up and added to the watch the first time
+// it is observed.
+type registryConfigSyncer struct {
+	sync.RWMutex
+	lastSyncStatus error
+
+	watcher    *fsnotify.Watcher
+	configPath string
+}
+
+// newRegistryConfigSyncer creates a registry config syncer watching configPath
+// and its existing per-host subdirectories.
+func newRegistryConfigSyncer(ctx context.Context, configPath string) (*registryConfigSyncer, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fsnotify watcher: %w", err)
+	}
+
+	if err := watcher.Add(configPath); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to watch registry config dir %s: %w", configPath, err)
+	}
+
+	syncer := &registryConfigSyncer{
+		watcher:    watcher,
+		configPath: configPath,
+	}
+	syncer.watchHostDirs(ctx)
+	syncer.updateLastStatus(syncer.validate(ctx))
+	return syncer, nil
+}
+
+// watchHostDirs adds any not-yet-watched per-host subdirectory of configPath
+// to the watcher. Errors are logged rather than returned since a host
+// directory may come and go between the readdir and the Add call.
+func (syncer *registryConfigSyncer) watchHostDirs(ctx context.Context) {
+	entries, err := os.ReadDir(syncer.configPath)
+	if err != nil {
+		log.G(ctx).WithError(err).Warnf("failed to list registry config dir %s for watching", syncer.configPath)
+		return
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		hostDir := filepath.Join(syncer.configPath, entry.Name())
+		if err := syncer.watcher.Add(hostDir); err != nil {
+			log.G(ctx).WithError(err).Warnf("failed to watch registry host config dir %s", hostDir)
+		}
+	}
+}
+
+// validate checks that the hosts.toml file in every host directory under
+// configPath is syntactically valid TOML. It does not otherwise interpret
+// the file; the resolver does that on every pull.
+func (syncer *registryConfigSyncer) validate(ctx context.Context) error {
+	entries, err := os.ReadDir(syncer.configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read registry config dir %s: %w", syncer.configPath, err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		hostsFile := filepath.Join(syncer.configPath, entry.Name(), "hosts.toml")
+		b, err := os.ReadFile(hostsFile)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("failed to read %s: %w", hostsFile, err)
+		}
+		var out map[string]interface{}
+		if err := toml.Unmarshal(b, &out); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", hostsFile, err)
+		}
+	}
+	return nil
+}
+
+// syncLoop monitors fs change events from the registry config dir tree and
+// revalidates the layout on every change, picking up newly created per-host
+// directories as it goes.
+func (syncer *registryConfigSyncer) syncLoop(ctx context.Context) error {
+	for {
+		select {
+		case event, ok := <-syncer.watcher.Events:
+			if !ok {
+				log.G(ctx).Debug("registry config watcher channel is closed")
+				return nil
+			}
+			if event.Has(fsnotify.Chmod) {
+				continue
+			}
+			log.G(ctx).Debugf("receiving change event from registry config dir: %s", event)
+
+			if event.Has(fsnotify.Create) {
+				syncer.watchHostDirs(ctx)
+			}
+
+			verr := syncer.validate(ctx)
+			if verr != nil {
+				log.G(ctx).WithError(verr).Errorf("registry config in %s failed validation after change event(%s)", syncer.configPath, event)
+			}
+			syncer.updateLastStatus(verr)
+		case err := <-syncer.watcher.Errors:
+			if err != nil {
+				log.G(ctx).WithError(err).Error("failed to continue watching registry config dir")
+				return err
+			}
+		}
+	}
+}
+
+// lastStatus retrieves the status of the last validation.
+func (syncer *registryConfigSyncer) lastStatus() error {
+	syncer.RLock()
+	defer syncer.RUnlock()
+	return syncer.lastSyncStatus
+}
+
+// updateLastStatus will be called after every single validation.
+func (syncer *registryConfigSyncer) updateLastStatus(err error) {
+	syncer.Lock()
+	defer syncer.Unlock()
+	syncer.lastSyncStatus = err
+}
+
+// stop stops the watcher in the syncLoop.
+func (syncer *registryConfigSyncer) stop() error {
+	return syncer.watcher.Close()
+}
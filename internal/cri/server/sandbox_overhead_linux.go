@@ -0,0 +1,53 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"time"
+
+	sandboxstore "github.com/containerd/containerd/v2/internal/cri/store/sandbox"
+	runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+// sandboxOverheadUsage returns the pod sandbox container's own (pause/shim)
+// CPU and memory usage, isolated from the containers running inside the pod,
+// so RuntimeClass overhead settings can be tuned from real data instead of
+// guesswork. ok is false when the sandbox has no cgroup parent configured, in
+// which case the sandbox and its containers can't be told apart by cgroup.
+func (c *criService) sandboxOverheadUsage(sandbox sandboxstore.Sandbox) (cpu *runtime.CpuUsage, memory *runtime.MemoryUsage, ok bool, err error) {
+	cgroupParent := sandbox.Config.GetLinux().GetCgroupParent()
+	if cgroupParent == "" {
+		return nil, nil, false, nil
+	}
+
+	stats, err := metricsForCgroup(getCgroupsPath(cgroupParent, sandbox.ID))
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed getting overhead metrics for sandbox %s: %w", sandbox.ID, err)
+	}
+
+	timestamp := time.Now()
+	cpu, err = c.cpuContainerStats(sandbox.ID, true /* isSandbox */, stats, timestamp)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to obtain overhead cpu stats: %w", err)
+	}
+	memory, err = c.memoryContainerStats(sandbox.ID, stats, timestamp)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to obtain overhead memory stats: %w", err)
+	}
+	return cpu, memory, true, nil
+}
@@ -0,0 +1,32 @@
+//go:build !linux
+
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	sandboxstore "github.com/containerd/containerd/v2/internal/cri/store/sandbox"
+	runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+// sandboxOverheadUsage always reports ok == false on non-Linux platforms:
+// isolating pause/shim overhead this way relies on the sandbox container
+// having its own cgroup separate from the containers it hosts, which this
+// code doesn't track outside of Linux's cgroup-parent convention.
+func (c *criService) sandboxOverheadUsage(sandbox sandboxstore.Sandbox) (cpu *runtime.CpuUsage, memory *runtime.MemoryUsage, ok bool, err error) {
+	return nil, nil, false, nil
+}
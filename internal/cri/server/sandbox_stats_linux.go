@@ -71,6 +71,12 @@ func (c *criService) podSandboxStats(
 		}
 		podSandboxStats.Linux.Memory = memoryStats
 
+		ioStats, err := c.ioContainerStats(stats, timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to obtain io stats: %w", err)
+		}
+		podSandboxStats.Linux.Io = ioStats
+
 		if sandbox.NetNSPath != "" {
 			rxBytes, rxErrors, txBytes, txErrors := getContainerNetIO(ctx, sandbox.NetNSPath)
 			podSandboxStats.Linux.Network = &runtime.NetworkUsage{
@@ -131,6 +137,15 @@ func metricsForSandbox(sandbox sandboxstore.Sandbox) (interface{}, error) {
 		return nil, fmt.Errorf("failed to get cgroup metrics for sandbox %v because cgroupPath is empty", sandbox.ID)
 	}
 
+	return metricsForCgroup(cgroupPath)
+}
+
+// metricsForCgroup reads raw cgroup v1/v2 metrics for an arbitrary cgroup
+// path. metricsForSandbox uses this for the pod-wide cgroup (sandbox
+// container's cgroup parent, which contains every container in the pod);
+// sandboxOverheadUsage uses it for the sandbox container's own cgroup, to
+// isolate shim/pause overhead from the containers running inside it.
+func metricsForCgroup(cgroupPath string) (interface{}, error) {
 	var statsx interface{}
 	if cgroups.Mode() == cgroups.Unified {
 		cg, err := cgroupsv2.Load(cgroupPath)
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"time"
 
+	cni "github.com/containerd/go-cni"
 	runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
 
 	sandboxstore "github.com/containerd/containerd/v2/internal/cri/store/sandbox"
@@ -82,12 +83,50 @@ func (c *criService) PodSandboxStatus(ctx context.Context, r *runtime.PodSandbox
 		status.CreatedAt = sandboxInfo.CreatedAt.UnixNano()
 	}
 
+	// Always surface the cached network details under their own "network" key,
+	// regardless of sandboxer backend: unlike the rest of the verbose info,
+	// which is assembled by the sandbox controller and may not know about CNI
+	// at all (e.g. a remote shim-based sandboxer), the CNI result is cached on
+	// the CRI-level sandbox itself and is available the same way for every
+	// sandboxer.
+	if r.GetVerbose() {
+		networkInfo, nerr := sandboxNetworkInfoJSON(ip, additionalIPs, sandbox.CNIResult)
+		if nerr != nil {
+			return nil, fmt.Errorf("failed to marshal sandbox network info: %w", nerr)
+		}
+		if info == nil {
+			info = make(map[string]string)
+		}
+		info["network"] = networkInfo
+	}
+
 	return &runtime.PodSandboxStatusResponse{
 		Status: status,
 		Info:   info,
 	}, nil
 }
 
+// sandboxNetworkInfo is the cached CNI network state for a sandbox, reported
+// under the "network" key of a verbose PodSandboxStatus response so network
+// debugging doesn't require digging into CNI plugin state files.
+type sandboxNetworkInfo struct {
+	IP            string      `json:"ip"`
+	AdditionalIPs []string    `json:"additionalIps"`
+	CNIResult     *cni.Result `json:"cniResult"`
+}
+
+func sandboxNetworkInfoJSON(ip string, additionalIPs []string, result *cni.Result) (string, error) {
+	b, err := json.Marshal(&sandboxNetworkInfo{
+		IP:            ip,
+		AdditionalIPs: additionalIPs,
+		CNIResult:     result,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
 func (c *criService) getIPs(sandbox sandboxstore.Sandbox) (string, []string, error) {
 	config := sandbox.Config
 
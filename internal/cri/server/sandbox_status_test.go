@@ -17,10 +17,13 @@
 package server
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
+	cni "github.com/containerd/go-cni"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
 
 	sandboxstore "github.com/containerd/containerd/v2/internal/cri/store/sandbox"
@@ -132,3 +135,50 @@ func TestPodSandboxStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestSandboxNetworkInfoJSON(t *testing.T) {
+	for _, test := range []struct {
+		desc          string
+		ip            string
+		additionalIPs []string
+		result        *cni.Result
+	}{
+		{
+			desc: "host network sandbox has no ip or cni result",
+		},
+		{
+			desc:          "pod network sandbox without cni result",
+			ip:            "10.10.10.10",
+			additionalIPs: []string{"8.8.8.8"},
+		},
+		{
+			desc:          "pod network sandbox with multi-interface cni result",
+			ip:            "10.10.10.10",
+			additionalIPs: []string{"8.8.8.8"},
+			result: &cni.Result{
+				Interfaces: map[string]*cni.Config{
+					"eth0": {
+						IPConfigs: []*cni.IPConfig{
+							{IP: nil},
+						},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := sandboxNetworkInfoJSON(test.ip, test.additionalIPs, test.result)
+			require.NoError(t, err)
+
+			var info sandboxNetworkInfo
+			require.NoError(t, json.Unmarshal([]byte(got), &info))
+			assert.Equal(t, test.ip, info.IP)
+			assert.Equal(t, test.additionalIPs, info.AdditionalIPs)
+			if test.result == nil {
+				assert.Nil(t, info.CNIResult)
+			} else {
+				assert.NotNil(t, info.CNIResult)
+			}
+		})
+	}
+}
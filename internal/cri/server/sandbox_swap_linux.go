@@ -0,0 +1,37 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+
+	sandboxstore "github.com/containerd/containerd/v2/internal/cri/store/sandbox"
+)
+
+// sandboxMemorySwapBytes returns the pod sandbox's current swap memory usage
+// read from its cgroup. ok is false if the sandbox's cgroup has no swap
+// accounting available (e.g. the swap controller isn't present), in which
+// case the caller should omit the metric rather than report a misleading
+// zero.
+func (c *criService) sandboxMemorySwapBytes(sandbox sandboxstore.Sandbox) (bytes uint64, ok bool, err error) {
+	stats, err := metricsForSandbox(sandbox)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed getting metrics for sandbox %s: %w", sandbox.ID, err)
+	}
+	bytes, ok = memorySwapUsageBytes(stats)
+	return bytes, ok, nil
+}
@@ -107,6 +107,10 @@ type ImageService interface {
 	LocalResolve(refOrID string) (imagestore.Image, error)
 
 	ImageFSPaths() map[string]string
+
+	// RegistryConfigPath returns the configured registry config_path, or
+	// empty if config_path is not set.
+	RegistryConfigPath() string
 }
 
 // criService implements CRIService.
@@ -135,6 +139,12 @@ type criService struct {
 	client *containerd.Client
 	// streamServer is the streaming server serves container streaming request.
 	streamServer streaming.Server
+	// maxStreamingConnectionsPerContainer is the configured limit on concurrent
+	// exec/attach sessions per container. 0 means no limit.
+	maxStreamingConnectionsPerContainer int
+	// streamingSessions tracks the number of active exec/attach sessions per
+	// container ID, to enforce maxStreamingConnectionsPerContainer.
+	streamingSessions streamingSessionTracker
 	// eventMonitor is the monitor monitors containerd events.
 	eventMonitor *events.EventMonitor
 	// initialized indicates whether the server is initialized. All GRPC services
@@ -143,6 +153,9 @@ type criService struct {
 	// cniNetConfMonitor is used to reload cni network conf if there is
 	// any valid fs change events from cni network conf dir.
 	cniNetConfMonitor map[string]*cniNetConfSyncer
+	// registryConfigSyncer watches the registry config_path directory, if
+	// configured, and revalidates its hosts.toml layout on change.
+	registryConfigSyncer *registryConfigSyncer
 	// allCaps is the list of the capabilities.
 	// When nil, parsed from CapEff of /proc/self/status.
 	allCaps []string //nolint:nolintlint,unused // Ignore on non-Linux
@@ -157,6 +170,18 @@ type criService struct {
 	runtimeHandlers map[string]*runtime.RuntimeHandler
 	// runtimeFeatures container runtime features info
 	runtimeFeatures *runtime.RuntimeFeatures
+	// seccompAgentBrokers tracks the running seccomp notify brokers (see
+	// internal/cri/seccomp), keyed by container ID, so they can be
+	// cancelled when the container they were started for is removed.
+	seccompAgentBrokers sync.Map
+}
+
+// stopSeccompAgentBroker cancels the seccomp notify broker started for id,
+// if any. It is a no-op on platforms and containers that never started one.
+func (c *criService) stopSeccompAgentBroker(id string) {
+	if cancel, ok := c.seccompAgentBrokers.LoadAndDelete(id); ok {
+		cancel.(context.CancelFunc)()
+	}
 }
 
 type CRIServiceOptions struct {
@@ -198,7 +223,10 @@ func NewCRIService(options *CRIServiceOptions) (CRIService, runtime.RuntimeServi
 		netPlugin:          make(map[string]cni.CNI),
 		sandboxService:     newCriSandboxService(&config, options.SandboxControllers),
 		runtimeHandlers:    make(map[string]*runtime.RuntimeHandler),
+
+		maxStreamingConnectionsPerContainer: options.StreamingConfig.MaxConnectionsPerContainer,
 	}
+	c.streamingSessions.counts = make(map[string]int)
 
 	// TODO: Make discard time configurable
 	c.containerEventsQ = eventq.New[runtime.ContainerEventResponse](5*time.Minute, func(event runtime.ContainerEventResponse) {
@@ -239,6 +267,14 @@ func NewCRIService(options *CRIServiceOptions) (CRIService, runtime.RuntimeServi
 		}
 	}
 
+	if configPath := c.ImageService.RegistryConfigPath(); configPath != "" {
+		s, err := newRegistryConfigSyncer(ctx, configPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create registry config syncer: %w", err)
+		}
+		c.registryConfigSyncer = s
+	}
+
 	c.nri = nri.NewAPI(options.NRI, &criImplementation{c})
 
 	intro := c.client.IntrospectionService()
@@ -294,6 +330,19 @@ func (c *criService) Run(ready func()) error {
 		}()
 	}
 
+	// Start registry config syncer. Unlike the CNI conf syncer, a failure
+	// here is only a loss of early-warning visibility, not of function (see
+	// registryConfigSyncer), so it does not participate in the critical
+	// select below.
+	if c.registryConfigSyncer != nil {
+		log.L.Info("Start registry config syncer")
+		go func() {
+			if err := c.registryConfigSyncer.syncLoop(ctrdutil.NamespacedContext()); err != nil {
+				log.L.WithError(err).Error("registry config syncer stopped")
+			}
+		}()
+	}
+
 	// Start streaming server.
 	log.L.Info("Start streaming server")
 	streamServerErrCh := make(chan error)
@@ -355,6 +404,11 @@ func (c *criService) Close() error {
 			log.L.WithError(err).Errorf("failed to stop cni network conf monitor for %s", name)
 		}
 	}
+	if c.registryConfigSyncer != nil {
+		if err := c.registryConfigSyncer.stop(); err != nil {
+			log.L.WithError(err).Error("failed to stop registry config syncer")
+		}
+	}
 	c.eventMonitor.Stop()
 	if err := c.streamServer.Stop(); err != nil {
 		return fmt.Errorf("failed to stop stream server: %w", err)
@@ -105,6 +105,20 @@ func (c *criService) Status(ctx context.Context, r *runtime.StatusRequest) (*run
 			}
 		}
 		resp.Info["lastCNILoadStatus"] = defaultStatus
+
+		if c.registryConfigSyncer != nil {
+			s := "OK"
+			if lerr := c.registryConfigSyncer.lastStatus(); lerr != nil {
+				s = lerr.Error()
+			}
+			resp.Info["lastRegistryConfigLoadStatus"] = s
+		}
+
+		nriStatus := "OK"
+		if nerr := c.nri.Status(); nerr != nil {
+			nriStatus = nerr.Error()
+		}
+		resp.Info["nriStatus"] = nriStatus
 	}
 	intro, err := c.client.IntrospectionService().Server(ctx)
 	if err != nil {
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"sync"
 
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/remotecommand"
@@ -30,6 +31,37 @@ import (
 	ctrdutil "github.com/containerd/containerd/v2/internal/cri/util"
 )
 
+// streamingSessionTracker counts active exec/attach streaming sessions per
+// container, so the configured per-container limit can be enforced.
+type streamingSessionTracker struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// acquire reserves a streaming session slot for containerID, rejecting it if
+// max has already been reached. max <= 0 means no limit.
+func (t *streamingSessionTracker) acquire(containerID string, max int) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if max > 0 && t.counts[containerID] >= max {
+		return false
+	}
+	t.counts[containerID]++
+	streamingSessionsActive.Inc()
+	return true
+}
+
+// release frees the streaming session slot reserved by a prior successful acquire.
+func (t *streamingSessionTracker) release(containerID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.counts[containerID]--
+	if t.counts[containerID] <= 0 {
+		delete(t.counts, containerID)
+	}
+	streamingSessionsActive.Dec()
+}
+
 type streamRuntime struct {
 	c *criService
 }
@@ -42,6 +74,12 @@ func newStreamRuntime(c *criService) streaming.Runtime {
 // returns non-zero exit code.
 func (s *streamRuntime) Exec(ctx context.Context, containerID string, cmd []string, stdin io.Reader, stdout, stderr io.WriteCloser,
 	tty bool, resize <-chan remotecommand.TerminalSize) error {
+	if !s.c.streamingSessions.acquire(containerID, s.c.maxStreamingConnectionsPerContainer) {
+		streamingSessionsRejected.WithValues("exec").Inc()
+		return fmt.Errorf("max streaming connections per container (%d) reached for container %q", s.c.maxStreamingConnectionsPerContainer, containerID)
+	}
+	defer s.c.streamingSessions.release(containerID)
+
 	exitCode, err := s.c.execInContainer(ctrdutil.WithNamespace(ctx), containerID, execOptions{
 		cmd:    cmd,
 		stdin:  stdin,
@@ -64,6 +102,12 @@ func (s *streamRuntime) Exec(ctx context.Context, containerID string, cmd []stri
 
 func (s *streamRuntime) Attach(ctx context.Context, containerID string, in io.Reader, out, err io.WriteCloser, tty bool,
 	resize <-chan remotecommand.TerminalSize) error {
+	if !s.c.streamingSessions.acquire(containerID, s.c.maxStreamingConnectionsPerContainer) {
+		streamingSessionsRejected.WithValues("attach").Inc()
+		return fmt.Errorf("max streaming connections per container (%d) reached for container %q", s.c.maxStreamingConnectionsPerContainer, containerID)
+	}
+	defer s.c.streamingSessions.release(containerID)
+
 	return s.c.attachContainer(ctrdutil.WithNamespace(ctx), containerID, in, out, err, tty, resize)
 }
 
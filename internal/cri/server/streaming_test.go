@@ -0,0 +1,62 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamingSessionTrackerAcquireRelease(t *testing.T) {
+	var tracker streamingSessionTracker
+	tracker.counts = make(map[string]int)
+
+	const containerID = "test-container"
+
+	assert.True(t, tracker.acquire(containerID, 2), "first session should be allowed")
+	assert.True(t, tracker.acquire(containerID, 2), "second session should be allowed")
+	assert.False(t, tracker.acquire(containerID, 2), "third session should exceed the limit")
+
+	tracker.release(containerID)
+	assert.True(t, tracker.acquire(containerID, 2), "session should be allowed again after a release")
+
+	tracker.release(containerID)
+	tracker.release(containerID)
+	assert.Empty(t, tracker.counts, "count map should not leak entries once all sessions are released")
+}
+
+func TestStreamingSessionTrackerUnlimited(t *testing.T) {
+	var tracker streamingSessionTracker
+	tracker.counts = make(map[string]int)
+
+	const containerID = "test-container"
+	for i := 0; i < 10; i++ {
+		assert.True(t, tracker.acquire(containerID, 0), "max <= 0 should mean unlimited")
+	}
+}
+
+func TestStreamingSessionTrackerReleaseCleansUpEntry(t *testing.T) {
+	var tracker streamingSessionTracker
+	tracker.counts = make(map[string]int)
+
+	const containerID = "test-container"
+	assert.True(t, tracker.acquire(containerID, 1))
+	tracker.release(containerID)
+	_, ok := tracker.counts[containerID]
+	assert.False(t, ok, "released container should not linger in the count map")
+}
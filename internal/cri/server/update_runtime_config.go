@@ -52,7 +52,14 @@ const (
 	zeroCIDRv4 = "0.0.0.0/0"
 )
 
-// UpdateRuntimeConfig updates the runtime config. Currently only handles podCIDR updates.
+// UpdateRuntimeConfig updates the runtime config. Currently only handles
+// podCIDR updates, since that is the only field RuntimeConfig carries in the
+// CRI API; there is no request field for registry mirror/auth config.
+// Dynamic registry config rollout is handled independently of this RPC, by
+// watching Registry.ConfigPath (see registryConfigSyncer) -- that config is
+// also re-read fresh on every pull, so it applies without waiting on this
+// call at all.
+
 func (c *criService) UpdateRuntimeConfig(ctx context.Context, r *runtime.UpdateRuntimeConfigRequest) (*runtime.UpdateRuntimeConfigResponse, error) {
 	podCIDRs := r.GetRuntimeConfig().GetNetworkConfig().GetPodCidr()
 	if podCIDRs == "" {
@@ -109,6 +109,12 @@ type Config struct {
 
 	// The config for serving over TLS. If nil, TLS will not be used.
 	TLSConfig *tls.Config
+
+	// MaxConnectionsPerContainer is the maximum number of concurrent
+	// exec/attach sessions the Runtime will be asked to serve for a single
+	// container. 0 means no limit. Enforced by the Runtime implementation,
+	// not by this package.
+	MaxConnectionsPerContainer int
 }
 
 // DefaultConfig provides default values for server Config. The DefaultConfig is partial, so
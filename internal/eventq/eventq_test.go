@@ -123,6 +123,26 @@ func TestDiscardedAtShutdown(t *testing.T) {
 	assert.Equal(t, expected, discarded)
 }
 
+// TestLargeBacklogReplay verifies that a backlog larger than a subscriber's
+// channel buffer is replayed in full and in order. The internal dispatch
+// loop publishes backlog events to a new subscriber synchronously, so this
+// also guards against a regression where a slow-draining subscriber could
+// stall delivery to the queue.
+func TestLargeBacklogReplay(t *testing.T) {
+	eq := New[int](3600*time.Second, func(int) {})
+	const n = 500
+	expected := make([]int, n)
+	for i := range expected {
+		expected[i] = i
+	}
+	for _, i := range expected {
+		eq.Send(i)
+	}
+	c := newCollector(eq)
+	eq.Shutdown()
+	assert.Equal(t, expected, c.Collected())
+}
+
 type collector struct {
 	collected []int
 	c         <-chan int
@@ -0,0 +1,71 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package nri
+
+import (
+	"fmt"
+	"runtime"
+
+	nri "github.com/containerd/nri/pkg/adaptation"
+)
+
+// NodeCapabilities describes the resources available on this node, used to
+// validate NRI-requested resource adjustments before they are applied.
+type NodeCapabilities struct {
+	// CPUs is the number of logical CPUs available on this node.
+	CPUs int
+	// MemoryLimit is the total physical memory available on this node, in
+	// bytes. Zero means unknown and disables the memory limit check.
+	MemoryLimit int64
+}
+
+// currentNodeCapabilities returns the capabilities of the node containerd
+// is running on.
+func currentNodeCapabilities() NodeCapabilities {
+	return NodeCapabilities{
+		CPUs:        runtime.NumCPU(),
+		MemoryLimit: totalMemory(),
+	}
+}
+
+// validate returns a human-readable reason the requested resources exceed
+// this node's capabilities, or "" if the request is within them. It only
+// catches requests that can never be honored on this node (e.g. more CPUs
+// than exist); it is not a general admission or overcommit policy.
+func (n NodeCapabilities) validate(res *nri.LinuxResources) string {
+	if res == nil {
+		return ""
+	}
+
+	if cpu := res.GetCpu(); cpu != nil && n.CPUs > 0 {
+		quota, period := cpu.GetQuota(), cpu.GetPeriod()
+		if quota != nil && quota.GetValue() > 0 && period != nil && period.GetValue() > 0 {
+			requested := float64(quota.GetValue()) / float64(period.GetValue())
+			if requested > float64(n.CPUs) {
+				return fmt.Sprintf("requested %.2f CPUs exceeds the %d available on this node", requested, n.CPUs)
+			}
+		}
+	}
+
+	if mem := res.GetMemory(); mem != nil && n.MemoryLimit > 0 {
+		if limit := mem.GetLimit(); limit != nil && limit.GetValue() > n.MemoryLimit {
+			return fmt.Sprintf("requested memory limit of %d bytes exceeds the %d bytes available on this node", limit.GetValue(), n.MemoryLimit)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,80 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package nri
+
+import (
+	"testing"
+
+	nri "github.com/containerd/nri/pkg/adaptation"
+)
+
+func TestNodeCapabilitiesValidate(t *testing.T) {
+	caps := NodeCapabilities{CPUs: 2, MemoryLimit: 1024 * 1024 * 1024}
+
+	for _, tc := range []struct {
+		name      string
+		resources *nri.LinuxResources
+		rejected  bool
+	}{
+		{
+			name:      "nil resources",
+			resources: nil,
+		},
+		{
+			name:      "cpu within capacity",
+			resources: &nri.LinuxResources{Cpu: &nri.LinuxCPU{Quota: nri.Int64(150000), Period: nri.UInt64(100000)}},
+		},
+		{
+			name:      "cpu exceeds capacity",
+			resources: &nri.LinuxResources{Cpu: &nri.LinuxCPU{Quota: nri.Int64(250000), Period: nri.UInt64(100000)}},
+			rejected:  true,
+		},
+		{
+			name:      "memory within capacity",
+			resources: &nri.LinuxResources{Memory: &nri.LinuxMemory{Limit: nri.Int64(512 * 1024 * 1024)}},
+		},
+		{
+			name:      "memory exceeds capacity",
+			resources: &nri.LinuxResources{Memory: &nri.LinuxMemory{Limit: nri.Int64(2 * 1024 * 1024 * 1024)}},
+			rejected:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			reason := caps.validate(tc.resources)
+			if tc.rejected && reason == "" {
+				t.Fatal("expected resources to be rejected, got no reason")
+			}
+			if !tc.rejected && reason != "" {
+				t.Fatalf("expected resources to be accepted, got reason: %s", reason)
+			}
+		})
+	}
+}
+
+func TestNodeCapabilitiesValidateUnknownLimits(t *testing.T) {
+	// A zero CPUs/MemoryLimit means the corresponding check is disabled,
+	// e.g. because it couldn't be determined on this platform.
+	caps := NodeCapabilities{}
+
+	resources := &nri.LinuxResources{
+		Cpu:    &nri.LinuxCPU{Quota: nri.Int64(1000000000), Period: nri.UInt64(100000)},
+		Memory: &nri.LinuxMemory{Limit: nri.Int64(1 << 40)},
+	}
+	if reason := caps.validate(resources); reason != "" {
+		t.Fatalf("expected no rejection with unknown node capabilities, got: %s", reason)
+	}
+}
@@ -21,6 +21,22 @@ import (
 	nri "github.com/containerd/nri/pkg/adaptation"
 )
 
+// FailurePolicy determines how containerd reacts when an NRI hook call
+// fails, for instance because a plugin hung past its request timeout.
+type FailurePolicy string
+
+const (
+	// FailurePolicyFail fails the CRI request that triggered the hook, the
+	// same way containerd has always behaved. For pod creation this
+	// rejects the pod.
+	FailurePolicyFail FailurePolicy = "fail"
+	// FailurePolicyIgnore logs the failure and lets the CRI request
+	// proceed as if the hook had not been called, so a single wedged or
+	// misbehaving plugin can't block pod or container lifecycle requests
+	// cluster-wide.
+	FailurePolicyIgnore FailurePolicy = "ignore"
+)
+
 // Config data for NRI.
 type Config struct {
 	// Disable this NRI plugin and containerd NRI functionality altogether.
@@ -37,6 +53,19 @@ type Config struct {
 	PluginRequestTimeout tomlext.Duration `toml:"plugin_request_timeout" json:"pluginRequestTimeout"`
 	// DisableConnections disables connections from externally launched plugins.
 	DisableConnections bool `toml:"disable_connections" json:"disableConnections"`
+	// FailurePolicy controls whether a failing NRI hook call (most
+	// commonly a plugin that times out) fails the triggering CRI request
+	// or is ignored. It applies to every connected plugin; the upstream
+	// NRI adaptation does not expose per-plugin failure handling.
+	FailurePolicy FailurePolicy `toml:"failure_policy" json:"failurePolicy"`
+	// DryRun puts NRI in audit-only mode: container creation is still
+	// gated by plugins as usual, but resource updates and evictions that
+	// plugins request afterwards (from UpdateContainer, StopContainer,
+	// unsolicited updates and synchronization) are validated and logged
+	// as they would be applied, without actually being applied. This
+	// allows a new NRI-based policy to be rolled out and observed safely
+	// before it is allowed to change running containers.
+	DryRun bool `toml:"dry_run" json:"dryRun"`
 }
 
 // DefaultConfig returns the default configuration.
@@ -49,6 +78,8 @@ func DefaultConfig() *Config {
 
 		PluginRegistrationTimeout: tomlext.FromStdTime(nri.DefaultPluginRegistrationTimeout),
 		PluginRequestTimeout:      tomlext.FromStdTime(nri.DefaultPluginRequestTimeout),
+
+		FailurePolicy: FailurePolicyFail,
 	}
 }
 
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/containerd/log"
 
@@ -83,6 +84,21 @@ type API interface {
 
 	// BlockPluginSync blocks plugin synchronization until it is Unblock()ed.
 	BlockPluginSync() *PluginSyncBlock
+
+	// Status returns an error describing the most recently failed NRI
+	// hook call, or nil if the most recent call for each hook succeeded.
+	// It is reset to nil by the next successful call of that hook, so it
+	// reflects current health rather than permanently latching the first
+	// failure seen.
+	Status() error
+
+	// Metrics returns, per hook, the number of container resource updates
+	// and evictions applied (or, in DryRun mode, that would have been
+	// applied) and the number rejected for exceeding this node's
+	// capabilities. The upstream NRI adaptation doesn't identify which
+	// plugin produced a given adjustment, so these are aggregated per
+	// hook rather than per plugin.
+	Metrics() map[string]HookMetrics
 }
 
 type State int
@@ -99,7 +115,82 @@ type local struct {
 	cfg *Config
 	nri *nri.Adaptation
 
-	state map[string]State
+	state        map[string]State
+	health       health
+	metrics      adjustmentMetrics
+	capabilities NodeCapabilities
+}
+
+// health tracks the outcome of the most recent call of each NRI hook, so
+// it can be surfaced through Status() without requiring per-plugin
+// visibility that the upstream NRI adaptation doesn't provide.
+type health struct {
+	sync.Mutex
+	hook string
+	err  error
+	at   time.Time
+}
+
+func (h *health) record(hook string, err error) {
+	h.Lock()
+	defer h.Unlock()
+	if err == nil {
+		h.hook, h.err = "", nil
+		return
+	}
+	h.hook, h.err, h.at = hook, err, time.Now()
+}
+
+func (h *health) status() (string, error, time.Time) {
+	h.Lock()
+	defer h.Unlock()
+	return h.hook, h.err, h.at
+}
+
+// HookMetrics counts the container adjustments a given NRI hook has
+// produced: how many were applied (or, in DryRun mode, would have been
+// applied) and how many were rejected for exceeding this node's
+// capabilities.
+type HookMetrics struct {
+	Adjustments int64
+	Rejected    int64
+}
+
+// adjustmentMetrics tracks HookMetrics per hook name.
+type adjustmentMetrics struct {
+	sync.Mutex
+	hooks map[string]*HookMetrics
+}
+
+func (m *adjustmentMetrics) record(hook string, applied, rejected int) {
+	if applied == 0 && rejected == 0 {
+		return
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	if m.hooks == nil {
+		m.hooks = make(map[string]*HookMetrics)
+	}
+	hm, ok := m.hooks[hook]
+	if !ok {
+		hm = &HookMetrics{}
+		m.hooks[hook] = hm
+	}
+	hm.Adjustments += int64(applied)
+	hm.Rejected += int64(rejected)
+}
+
+func (m *adjustmentMetrics) snapshot() map[string]HookMetrics {
+	m.Lock()
+	defer m.Unlock()
+
+	out := make(map[string]HookMetrics, len(m.hooks))
+	for hook, hm := range m.hooks {
+		out[hook] = *hm
+	}
+	return out
 }
 
 var _ API = &local{}
@@ -107,7 +198,8 @@ var _ API = &local{}
 // New creates an instance of the NRI interface with the given configuration.
 func New(cfg *Config) (API, error) {
 	l := &local{
-		cfg: cfg,
+		cfg:          cfg,
+		capabilities: currentNodeCapabilities(),
 	}
 
 	if cfg.Disable {
@@ -115,6 +207,10 @@ func New(cfg *Config) (API, error) {
 		return l, nil
 	}
 
+	if cfg.DryRun {
+		log.L.Info("NRI interface is running in dry-run (audit-only) mode")
+	}
+
 	var (
 		name     = version.Name
 		version  = version.Version
@@ -181,7 +277,7 @@ func (l *local) RunPodSandbox(ctx context.Context, pod PodSandbox) error {
 
 	err := l.nri.RunPodSandbox(ctx, request)
 	l.setState(pod.GetID(), Running)
-	return err
+	return l.applyFailurePolicy(ctx, "RunPodSandbox", err)
 }
 
 func (l *local) StopPodSandbox(ctx context.Context, pod PodSandbox) error {
@@ -202,7 +298,7 @@ func (l *local) StopPodSandbox(ctx context.Context, pod PodSandbox) error {
 
 	err := l.nri.StopPodSandbox(ctx, request)
 	l.setState(pod.GetID(), Stopped)
-	return err
+	return l.applyFailurePolicy(ctx, "StopPodSandbox", err)
 }
 
 func (l *local) RemovePodSandbox(ctx context.Context, pod PodSandbox) error {
@@ -223,7 +319,7 @@ func (l *local) RemovePodSandbox(ctx context.Context, pod PodSandbox) error {
 
 	err := l.nri.RemovePodSandbox(ctx, request)
 	l.setState(pod.GetID(), Removed)
-	return err
+	return l.applyFailurePolicy(ctx, "RemovePodSandbox", err)
 }
 
 func (l *local) CreateContainer(ctx context.Context, pod PodSandbox, ctr Container) (*nri.ContainerAdjustment, error) {
@@ -241,17 +337,22 @@ func (l *local) CreateContainer(ctx context.Context, pod PodSandbox, ctr Contain
 
 	response, err := l.nri.CreateContainer(ctx, request)
 	l.setState(request.Container.Id, Created)
-	if err != nil {
+	if err = l.applyFailurePolicy(ctx, "CreateContainer", err); err != nil {
 		return nil, err
 	}
+	if response == nil {
+		// The hook failed and the configured failure policy ignored it;
+		// proceed with container creation without any adjustment.
+		return nil, nil
+	}
 
-	_, err = l.evictContainers(ctx, response.Evict)
+	_, err = l.evictContainers(ctx, "CreateContainer", response.Evict)
 	if err != nil {
 		// TODO(klihub): we ignore pre-create eviction failures for now
 		log.G(ctx).WithError(err).Warnf("pre-create eviction failed")
 	}
 
-	if _, err := l.applyUpdates(ctx, response.Update); err != nil {
+	if _, err := l.applyUpdates(ctx, "CreateContainer", response.Update); err != nil {
 		// TODO(klihub): we ignore pre-create update failures for now
 		log.G(ctx).WithError(err).Warnf("pre-create update failed")
 	}
@@ -272,7 +373,7 @@ func (l *local) PostCreateContainer(ctx context.Context, pod PodSandbox, ctr Con
 		Container: containerToNRI(ctr),
 	}
 
-	return l.nri.PostCreateContainer(ctx, request)
+	return l.applyFailurePolicy(ctx, "PostCreateContainer", l.nri.PostCreateContainer(ctx, request))
 }
 
 func (l *local) StartContainer(ctx context.Context, pod PodSandbox, ctr Container) error {
@@ -291,7 +392,7 @@ func (l *local) StartContainer(ctx context.Context, pod PodSandbox, ctr Containe
 	err := l.nri.StartContainer(ctx, request)
 	l.setState(request.Container.Id, Running)
 
-	return err
+	return l.applyFailurePolicy(ctx, "StartContainer", err)
 }
 
 func (l *local) PostStartContainer(ctx context.Context, pod PodSandbox, ctr Container) error {
@@ -307,7 +408,7 @@ func (l *local) PostStartContainer(ctx context.Context, pod PodSandbox, ctr Cont
 		Container: containerToNRI(ctr),
 	}
 
-	return l.nri.PostStartContainer(ctx, request)
+	return l.applyFailurePolicy(ctx, "PostStartContainer", l.nri.PostStartContainer(ctx, request))
 }
 
 func (l *local) UpdateContainer(ctx context.Context, pod PodSandbox, ctr Container, req *nri.LinuxResources) (*nri.LinuxResources, error) {
@@ -325,11 +426,16 @@ func (l *local) UpdateContainer(ctx context.Context, pod PodSandbox, ctr Contain
 	}
 
 	response, err := l.nri.UpdateContainer(ctx, request)
-	if err != nil {
+	if err = l.applyFailurePolicy(ctx, "UpdateContainer", err); err != nil {
 		return nil, err
 	}
+	if response == nil {
+		// The hook failed and the configured failure policy ignored it;
+		// proceed with the originally requested resources unchanged.
+		return nil, nil
+	}
 
-	_, err = l.evictContainers(ctx, response.Evict)
+	_, err = l.evictContainers(ctx, "UpdateContainer", response.Evict)
 	if err != nil {
 		// TODO(klihub): we ignore pre-update eviction failures for now
 		log.G(ctx).WithError(err).Warnf("pre-update eviction failed")
@@ -341,7 +447,7 @@ func (l *local) UpdateContainer(ctx context.Context, pod PodSandbox, ctr Contain
 	}
 
 	if cnt > 1 {
-		_, err = l.applyUpdates(ctx, response.Update[0:cnt-1])
+		_, err = l.applyUpdates(ctx, "UpdateContainer", response.Update[0:cnt-1])
 		if err != nil {
 			// TODO(klihub): we ignore pre-update update failures for now
 			log.G(ctx).WithError(err).Warnf("pre-update update failed")
@@ -364,7 +470,7 @@ func (l *local) PostUpdateContainer(ctx context.Context, pod PodSandbox, ctr Con
 		Container: containerToNRI(ctr),
 	}
 
-	return l.nri.PostUpdateContainer(ctx, request)
+	return l.applyFailurePolicy(ctx, "PostUpdateContainer", l.nri.PostUpdateContainer(ctx, request))
 }
 
 func (l *local) StopContainer(ctx context.Context, pod PodSandbox, ctr Container) error {
@@ -400,11 +506,14 @@ func (l *local) stopContainer(ctx context.Context, pod PodSandbox, ctr Container
 
 	response, err := l.nri.StopContainer(ctx, request)
 	l.setState(request.Container.Id, Stopped)
-	if err != nil {
+	if err = l.applyFailurePolicy(ctx, "StopContainer", err); err != nil {
 		return err
 	}
+	if response == nil {
+		return nil
+	}
 
-	_, err = l.applyUpdates(ctx, response.Update)
+	_, err = l.applyUpdates(ctx, "StopContainer", response.Update)
 	if err != nil {
 		// TODO(klihub): we ignore post-stop update failures for now
 		log.G(ctx).WithError(err).Warnf("post-stop update failed")
@@ -434,7 +543,7 @@ func (l *local) RemoveContainer(ctx context.Context, pod PodSandbox, ctr Contain
 	err := l.nri.RemoveContainer(ctx, request)
 	l.setState(request.Container.Id, Removed)
 
-	return err
+	return l.applyFailurePolicy(ctx, "RemoveContainer", err)
 }
 
 type PluginSyncBlock = nri.PluginSyncBlock
@@ -446,6 +555,43 @@ func (l *local) BlockPluginSync() *PluginSyncBlock {
 	return l.nri.BlockPluginSync()
 }
 
+// applyFailurePolicy records the outcome of the named hook call and, if
+// the configured failure policy is FailurePolicyIgnore, logs a failed err
+// and swallows it so the caller can proceed as if the hook had not run.
+// A hung plugin can still delay the call up to PluginRequestTimeout, but
+// it can no longer fail the triggering pod or container request.
+func (l *local) applyFailurePolicy(ctx context.Context, hook string, err error) error {
+	l.health.record(hook, err)
+	if err == nil {
+		return nil
+	}
+	if l.cfg.FailurePolicy == FailurePolicyIgnore {
+		log.G(ctx).WithError(err).Warnf("ignoring failed NRI %s call per configured failure policy", hook)
+		return nil
+	}
+	return err
+}
+
+// Status reports the most recently failed NRI hook call, if any.
+func (l *local) Status() error {
+	if !l.IsEnabled() {
+		return nil
+	}
+	hook, err, at := l.health.status()
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("NRI %s failed at %s: %w", hook, at.Format(time.RFC3339), err)
+}
+
+// Metrics reports the per-hook adjustment counters collected so far.
+func (l *local) Metrics() map[string]HookMetrics {
+	if !l.IsEnabled() {
+		return nil
+	}
+	return l.metrics.snapshot()
+}
+
 func (l *local) syncPlugin(ctx context.Context, syncFn nri.SyncCB) error {
 	l.Lock()
 	defer l.Unlock()
@@ -473,7 +619,7 @@ func (l *local) syncPlugin(ctx context.Context, syncFn nri.SyncCB) error {
 		return err
 	}
 
-	_, err = l.applyUpdates(ctx, updates)
+	_, err = l.applyUpdates(ctx, "Synchronize", updates)
 	if err != nil {
 		// TODO(klihub): we ignore post-sync update failures for now
 		log.G(ctx).WithError(err).Warnf("post-sync update failed")
@@ -488,21 +634,61 @@ func (l *local) updateFromPlugin(ctx context.Context, req []*nri.ContainerUpdate
 
 	log.G(ctx).Trace("Unsolicited NRI container updates")
 
-	failed, err := l.applyUpdates(ctx, req)
+	failed, err := l.applyUpdates(ctx, "UnsolicitedUpdate", req)
 	return failed, err
 }
 
-func (l *local) applyUpdates(ctx context.Context, updates []*nri.ContainerUpdate) ([]*nri.ContainerUpdate, error) {
+func (l *local) applyUpdates(ctx context.Context, hook string, updates []*nri.ContainerUpdate) ([]*nri.ContainerUpdate, error) {
+	updates, rejected := l.rejectOverCapacity(ctx, hook, updates)
+
+	if l.cfg.DryRun {
+		for _, u := range updates {
+			log.G(ctx).Infof("NRI dry-run (%s): would update container %s with %+v",
+				hook, u.ContainerId, u.GetLinux().GetResources())
+		}
+		l.metrics.record(hook, len(updates), rejected)
+		return nil, nil
+	}
+
 	// TODO(klihub): should we pre-save state and attempt a rollback on failure ?
 	failed, err := domains.updateContainers(ctx, updates)
+	l.metrics.record(hook, len(updates)-len(failed), rejected)
 	return failed, err
 }
 
-func (l *local) evictContainers(ctx context.Context, evict []*nri.ContainerEviction) ([]*nri.ContainerEviction, error) {
+func (l *local) evictContainers(ctx context.Context, hook string, evict []*nri.ContainerEviction) ([]*nri.ContainerEviction, error) {
+	if l.cfg.DryRun {
+		for _, e := range evict {
+			log.G(ctx).Infof("NRI dry-run (%s): would evict container %s: %s", hook, e.ContainerId, e.Reason)
+		}
+		l.metrics.record(hook, len(evict), 0)
+		return nil, nil
+	}
+
 	failed, err := domains.evictContainers(ctx, evict)
+	l.metrics.record(hook, len(evict)-len(failed), 0)
 	return failed, err
 }
 
+// rejectOverCapacity drops the updates whose requested resources exceed
+// this node's capabilities, logging the reason for each, and returns the
+// remaining updates along with how many were rejected.
+func (l *local) rejectOverCapacity(ctx context.Context, hook string, updates []*nri.ContainerUpdate) ([]*nri.ContainerUpdate, int) {
+	var (
+		valid    []*nri.ContainerUpdate
+		rejected int
+	)
+	for _, u := range updates {
+		if reason := l.capabilities.validate(u.GetLinux().GetResources()); reason != "" {
+			log.G(ctx).Warnf("NRI %s: rejecting update of container %s: %s", hook, u.ContainerId, reason)
+			rejected++
+			continue
+		}
+		valid = append(valid, u)
+	}
+	return valid, rejected
+}
+
 func (l *local) setState(id string, state State) {
 	if state != Removed {
 		l.state[id] = state
@@ -0,0 +1,80 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package nri
+
+import (
+	"context"
+	"testing"
+
+	nri "github.com/containerd/nri/pkg/adaptation"
+)
+
+func TestAdjustmentMetrics(t *testing.T) {
+	var m adjustmentMetrics
+
+	m.record("CreateContainer", 2, 1)
+	m.record("CreateContainer", 1, 0)
+	m.record("UpdateContainer", 0, 3)
+
+	snap := m.snapshot()
+	if got := snap["CreateContainer"]; got.Adjustments != 3 || got.Rejected != 1 {
+		t.Fatalf("unexpected CreateContainer metrics: %+v", got)
+	}
+	if got := snap["UpdateContainer"]; got.Adjustments != 0 || got.Rejected != 3 {
+		t.Fatalf("unexpected UpdateContainer metrics: %+v", got)
+	}
+	if _, ok := snap["StopContainer"]; ok {
+		t.Fatal("expected no entry for a hook that never recorded anything")
+	}
+}
+
+// TestApplyUpdatesDryRun verifies that with DryRun enabled, applyUpdates
+// validates requested resources against node capabilities, never calls into
+// the domain table, and still records metrics as if the valid updates had
+// been applied.
+func TestApplyUpdatesDryRun(t *testing.T) {
+	l := &local{
+		cfg:          &Config{DryRun: true},
+		capabilities: NodeCapabilities{CPUs: 1},
+	}
+
+	updates := []*nri.ContainerUpdate{
+		{ContainerId: "within-capacity", Linux: &nri.LinuxContainerUpdate{
+			Resources: &nri.LinuxResources{Cpu: &nri.LinuxCPU{Quota: nri.Int64(50000), Period: nri.UInt64(100000)}},
+		}},
+		{ContainerId: "over-capacity", Linux: &nri.LinuxContainerUpdate{
+			Resources: &nri.LinuxResources{Cpu: &nri.LinuxCPU{Quota: nri.Int64(250000), Period: nri.UInt64(100000)}},
+		}},
+	}
+
+	// No domain is registered, so a non-dry-run call would apply nothing
+	// for "within-capacity" either; what this asserts is that dry-run
+	// never reaches domains.updateContainers and that metrics reflect one
+	// accepted and one rejected update.
+	failed, err := l.applyUpdates(context.Background(), "UpdateContainer", updates)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if failed != nil {
+		t.Fatalf("dry-run should never report failed updates, got: %v", failed)
+	}
+
+	got := l.metrics.snapshot()["UpdateContainer"]
+	if got.Adjustments != 1 || got.Rejected != 1 {
+		t.Fatalf("unexpected metrics after dry-run: %+v", got)
+	}
+}
@@ -0,0 +1,177 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package userns
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/containerd/continuity"
+)
+
+// ErrRangesExhausted is returned by RangeAllocator.Allocate when every
+// configured ID range is already allocated to another owner.
+var ErrRangesExhausted = errors.New("no free user namespace ID range")
+
+// IDRange is a contiguous block of host UIDs or GIDs handed out to a single
+// owner (typically a pod) for use as the base of a user namespace mapping.
+type IDRange struct {
+	HostID uint32 `json:"hostID"`
+	Size   uint32 `json:"size"`
+}
+
+// rangeAllocatorState is the on-disk, checkpointed form of a RangeAllocator.
+type rangeAllocatorState struct {
+	Version     string             `json:"version"`
+	Allocations map[string]IDRange `json:"allocations"`
+}
+
+const rangeAllocatorStateVersion = "v1"
+
+// RangeAllocator hands out non-overlapping host UID/GID ranges of a fixed
+// size out of [Start, Start+Length), keyed by an owner ID (e.g. a pod
+// sandbox ID), so that pods and containers using Linux user namespaces never
+// get mappings that collide with one another on the same host. Allocations
+// are checkpointed to disk so that they survive a containerd restart and
+// aren't handed out a second time while their owner is still alive.
+type RangeAllocator struct {
+	mu    sync.Mutex
+	path  string
+	start uint32
+	slots uint32 // number of rangeSize-sized slots between start and start+length
+	size  uint32 // size of a single allocated range
+
+	allocations map[string]IDRange
+	used        []bool // used[i] is true if slot i is allocated to some owner
+}
+
+// NewRangeAllocator creates a RangeAllocator that allocates ranges of size
+// rangeSize out of [start, start+length), checkpointing its state to path.
+// If path already contains a checkpoint, e.g. from before a containerd
+// restart, existing allocations are loaded back in.
+func NewRangeAllocator(path string, start, length, rangeSize uint32) (*RangeAllocator, error) {
+	if rangeSize == 0 {
+		return nil, errors.New("userns: range size must be non-zero")
+	}
+	a := &RangeAllocator{
+		path:        path,
+		start:       start,
+		slots:       length / rangeSize,
+		size:        rangeSize,
+		allocations: make(map[string]IDRange),
+	}
+	a.used = make([]bool, a.slots)
+
+	state, err := loadRangeAllocatorState(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading user namespace ID range allocations from %q: %w", path, err)
+	}
+	for owner, r := range state.Allocations {
+		slot := (r.HostID - start) / rangeSize
+		if r.Size != rangeSize || slot >= a.slots {
+			// The range no longer fits the configured layout, e.g. because
+			// the allocator was reconfigured; drop it rather than risk
+			// double-allocating or panicking on an out of range index.
+			continue
+		}
+		a.allocations[owner] = r
+		a.used[slot] = true
+	}
+	return a, nil
+}
+
+func loadRangeAllocatorState(path string) (rangeAllocatorState, error) {
+	state := rangeAllocatorState{Allocations: make(map[string]IDRange)}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return state, err
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, err
+	}
+	if state.Allocations == nil {
+		state.Allocations = make(map[string]IDRange)
+	}
+	return state, nil
+}
+
+// Allocate returns the host ID range assigned to owner, allocating a new one
+// out of the configured ranges if owner doesn't already have one. Allocating
+// the same owner twice returns its existing range rather than a new one, so
+// that retrying a failed pod create doesn't leak a range.
+func (a *RangeAllocator) Allocate(owner string) (IDRange, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if r, ok := a.allocations[owner]; ok {
+		return r, nil
+	}
+
+	for slot := uint32(0); slot < a.slots; slot++ {
+		if a.used[slot] {
+			continue
+		}
+		r := IDRange{HostID: a.start + slot*a.size, Size: a.size}
+		a.allocations[owner] = r
+		a.used[slot] = true
+		if err := a.checkpoint(); err != nil {
+			delete(a.allocations, owner)
+			a.used[slot] = false
+			return IDRange{}, err
+		}
+		return r, nil
+	}
+	return IDRange{}, ErrRangesExhausted
+}
+
+// Release returns owner's range to the pool, if it has one. It is a no-op if
+// owner has no allocation, so callers can call it unconditionally on
+// teardown.
+func (a *RangeAllocator) Release(owner string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	r, ok := a.allocations[owner]
+	if !ok {
+		return nil
+	}
+	slot := (r.HostID - a.start) / a.size
+	delete(a.allocations, owner)
+	a.used[slot] = false
+	return a.checkpoint()
+}
+
+// checkpoint must be called with a.mu held.
+func (a *RangeAllocator) checkpoint() error {
+	data, err := json.Marshal(rangeAllocatorState{
+		Version:     rangeAllocatorStateVersion,
+		Allocations: a.allocations,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding user namespace ID range allocations: %w", err)
+	}
+	if err := continuity.AtomicWriteFile(a.path, data, 0600); err != nil {
+		return fmt.Errorf("checkpointing user namespace ID range allocations to %q: %w", a.path, err)
+	}
+	return nil
+}
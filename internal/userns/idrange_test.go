@@ -0,0 +1,79 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package userns
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRangeAllocatorAllocateIsStableAndNonOverlapping(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "userns-id-ranges")
+	// 131072 = 2 * 65536, so exactly 2 ranges fit.
+	a, err := NewRangeAllocator(path, 100000, 131072, 65536)
+	require.NoError(t, err)
+
+	r1, err := a.Allocate("pod-1")
+	require.NoError(t, err)
+	assert.Equal(t, IDRange{HostID: 100000, Size: 65536}, r1)
+
+	// Allocating the same owner again must return the same range rather
+	// than consuming a new one.
+	r1Again, err := a.Allocate("pod-1")
+	require.NoError(t, err)
+	assert.Equal(t, r1, r1Again)
+
+	r2, err := a.Allocate("pod-2")
+	require.NoError(t, err)
+	assert.NotEqual(t, r1.HostID, r2.HostID)
+
+	// Only 2 slots fit in a 131072-length range starting at 100000.
+	_, err = a.Allocate("pod-3")
+	assert.ErrorIs(t, err, ErrRangesExhausted)
+
+	require.NoError(t, a.Release("pod-1"))
+	r3, err := a.Allocate("pod-3")
+	require.NoError(t, err)
+	assert.Equal(t, r1.HostID, r3.HostID)
+}
+
+func TestRangeAllocatorPersistsAcrossRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "userns-id-ranges")
+	a, err := NewRangeAllocator(path, 100000, 131072, 65536)
+	require.NoError(t, err)
+
+	r1, err := a.Allocate("pod-1")
+	require.NoError(t, err)
+
+	// Simulate a containerd restart by creating a fresh allocator against
+	// the same checkpoint path.
+	b, err := NewRangeAllocator(path, 100000, 131072, 65536)
+	require.NoError(t, err)
+
+	r1Reloaded, err := b.Allocate("pod-1")
+	require.NoError(t, err)
+	assert.Equal(t, r1, r1Reloaded)
+
+	// The slot taken by pod-1 must not be handed out again.
+	_, err = b.Allocate("pod-2")
+	require.NoError(t, err)
+	_, err = b.Allocate("pod-3")
+	assert.ErrorIs(t, err, ErrRangesExhausted)
+}
@@ -240,15 +240,61 @@ func DecompressStream(archive io.Reader) (DecompressReadCloser, error) {
 	}
 }
 
+// CompressOpts holds optional parameters for CompressStream.
+type CompressOpts struct {
+	// ZstdLevel sets the target zstd compression level, using the same
+	// scale as zstd.EncoderLevel (1 - fastest, 4 - best compression). It
+	// is only used when compressing with Zstd. Zero uses the
+	// klauspost/compress default (zstd.SpeedDefault).
+	ZstdLevel int
+
+	// ZstdConcurrency sets the number of goroutines used to compress
+	// concurrently. It is only used when compressing with Zstd. Zero
+	// uses the klauspost/compress default (runtime.GOMAXPROCS(0)).
+	ZstdConcurrency int
+}
+
+// CompressOpt is used to configure a CompressStream call.
+type CompressOpt func(*CompressOpts)
+
+// WithZstdLevel sets the target zstd compression level. It has no effect
+// unless the stream is being compressed with Zstd.
+func WithZstdLevel(level int) CompressOpt {
+	return func(o *CompressOpts) {
+		o.ZstdLevel = level
+	}
+}
+
+// WithZstdConcurrency sets the number of goroutines zstd uses to compress
+// concurrently. It has no effect unless the stream is being compressed
+// with Zstd.
+func WithZstdConcurrency(n int) CompressOpt {
+	return func(o *CompressOpts) {
+		o.ZstdConcurrency = n
+	}
+}
+
 // CompressStream compresses the dest with specified compression algorithm.
-func CompressStream(dest io.Writer, compression Compression) (io.WriteCloser, error) {
+func CompressStream(dest io.Writer, compression Compression, opts ...CompressOpt) (io.WriteCloser, error) {
+	var o CompressOpts
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	switch compression {
 	case Uncompressed:
 		return &writeCloserWrapper{dest, nil}, nil
 	case Gzip:
 		return gzip.NewWriter(dest), nil
 	case Zstd:
-		return zstd.NewWriter(dest)
+		var zopts []zstd.EOption
+		if o.ZstdLevel != 0 {
+			zopts = append(zopts, zstd.WithEncoderLevel(zstd.EncoderLevel(o.ZstdLevel)))
+		}
+		if o.ZstdConcurrency != 0 {
+			zopts = append(zopts, zstd.WithEncoderConcurrency(o.ZstdConcurrency))
+		}
+		return zstd.NewWriter(dest, zopts...)
 	default:
 		return nil, fmt.Errorf("unsupported compression format %s", (&compression).Extension())
 	}
@@ -191,6 +191,39 @@ func TestCmdStreamBad(t *testing.T) {
 	}
 }
 
+func TestCompressZstdOptions(t *testing.T) {
+	orig := generateData(t, 1024*1024)
+
+	compress := func(opts ...CompressOpt) []byte {
+		var b bytes.Buffer
+		compressor, err := CompressStream(&b, Zstd, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := compressor.Write(orig); err != nil {
+			t.Fatal(err)
+		}
+		if err := compressor.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		decompressed, _ := testDecompress(t, b.Bytes())
+		if !bytes.Equal(orig, decompressed) {
+			t.Fatal("strange decompressed data")
+		}
+		return b.Bytes()
+	}
+
+	fastest := compress(WithZstdLevel(1))
+	best := compress(WithZstdLevel(4))
+	if len(best) >= len(fastest) {
+		t.Fatalf("expected best compression (%d bytes) to be smaller than fastest (%d bytes)", len(best), len(fastest))
+	}
+
+	// WithZstdConcurrency must not affect correctness of the output.
+	compress(WithZstdConcurrency(2))
+}
+
 func TestDetectCompressionZstd(t *testing.T) {
 	for _, tc := range []struct {
 		source   []byte
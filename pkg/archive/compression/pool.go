@@ -0,0 +1,129 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compression
+
+import (
+	"io"
+	"runtime"
+	"sync/atomic"
+)
+
+// DecompressionPoolStats reports cumulative counters for a DecompressionPool.
+// All fields are safe to read concurrently with the pool's use.
+type DecompressionPoolStats struct {
+	// Active is the number of decompressions currently in progress, i.e.
+	// holding a pool slot.
+	Active uint64
+	// Started is the total number of decompressions the pool has admitted.
+	Started uint64
+	// Completed is the total number of decompressions that have finished,
+	// successfully or not.
+	Completed uint64
+	// Errors is the number of DecompressStream calls that failed outright
+	// (before a reader could be returned).
+	Errors uint64
+	// BytesOut is the total number of decompressed bytes read out of all
+	// readers the pool has handed out.
+	BytesOut uint64
+}
+
+// DecompressionPool bounds the number of decompressions (gzip, zstd, ...)
+// that may run concurrently, so that, for example, unpacking many layers of
+// an image in parallel doesn't turn decompression into an unbounded fleet of
+// CPU-bound goroutines competing with the rest of the system. Detection of
+// the compression format itself is unaffected and still happens per layer
+// via DetectCompression/DecompressStream; the pool only gates how many of
+// those decompressions run at once and tracks basic usage metrics.
+//
+// A zero-value DecompressionPool is not usable; use NewDecompressionPool.
+type DecompressionPool struct {
+	slots chan struct{}
+
+	active    atomic.Uint64
+	started   atomic.Uint64
+	completed atomic.Uint64
+	errors    atomic.Uint64
+	bytesOut  atomic.Uint64
+}
+
+// NewDecompressionPool creates a DecompressionPool that allows at most
+// parallelism decompressions to run at the same time. A parallelism of 0 or
+// less uses runtime.GOMAXPROCS(0), matching the default klauspost/compress
+// zstd encoder concurrency used elsewhere in this package.
+func NewDecompressionPool(parallelism int) *DecompressionPool {
+	if parallelism <= 0 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+	return &DecompressionPool{
+		slots: make(chan struct{}, parallelism),
+	}
+}
+
+// Stats returns a snapshot of the pool's cumulative counters.
+func (p *DecompressionPool) Stats() DecompressionPoolStats {
+	return DecompressionPoolStats{
+		Active:    p.active.Load(),
+		Started:   p.started.Load(),
+		Completed: p.completed.Load(),
+		Errors:    p.errors.Load(),
+		BytesOut:  p.bytesOut.Load(),
+	}
+}
+
+// DecompressStream behaves like the package-level DecompressStream, except it
+// blocks until a pool slot is available before doing the (CPU-bound) format
+// detection and decompression work, and releases the slot when the returned
+// reader is closed.
+func (p *DecompressionPool) DecompressStream(archive io.Reader) (DecompressReadCloser, error) {
+	p.slots <- struct{}{}
+	p.started.Add(1)
+	p.active.Add(1)
+
+	release := func() {
+		p.active.Add(^uint64(0))
+		p.completed.Add(1)
+		<-p.slots
+	}
+
+	rc, err := DecompressStream(archive)
+	if err != nil {
+		p.errors.Add(1)
+		release()
+		return nil, err
+	}
+
+	return &pooledDecompressReadCloser{DecompressReadCloser: rc, pool: p, release: release}, nil
+}
+
+type pooledDecompressReadCloser struct {
+	DecompressReadCloser
+	pool    *DecompressionPool
+	release func()
+}
+
+func (p *pooledDecompressReadCloser) Read(b []byte) (int, error) {
+	n, err := p.DecompressReadCloser.Read(b)
+	if n > 0 {
+		p.pool.bytesOut.Add(uint64(n))
+	}
+	return n, err
+}
+
+func (p *pooledDecompressReadCloser) Close() error {
+	defer p.release()
+	return p.DecompressReadCloser.Close()
+}
@@ -0,0 +1,128 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// TestDecompressionPoolBoundsParallelism verifies that a DecompressionPool
+// never admits more concurrent decompressions than its configured
+// parallelism, even when many more are requested at once.
+func TestDecompressionPoolBoundsParallelism(t *testing.T) {
+	const parallelism = 2
+	const requests = 8
+
+	pool := NewDecompressionPool(parallelism)
+
+	var active, maxActive atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			rc, err := pool.DecompressStream(bytes.NewReader(gzipBytes(t, []byte("hello world"))))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			// Only count this decompression as "active" once its pool slot
+			// has actually been acquired (i.e. DecompressStream returned),
+			// not from whenever the goroutine happened to get scheduled.
+			n := active.Add(1)
+			for {
+				m := maxActive.Load()
+				if n <= m || maxActive.CompareAndSwap(m, n) {
+					break
+				}
+			}
+
+			if _, err := io.ReadAll(rc); err != nil {
+				t.Error(err)
+			}
+			active.Add(-1)
+			if err := rc.Close(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := maxActive.Load(); got > parallelism {
+		t.Fatalf("expected at most %d concurrent decompressions, observed %d", parallelism, got)
+	}
+
+	stats := pool.Stats()
+	if stats.Started != requests {
+		t.Fatalf("expected %d started, got %d", requests, stats.Started)
+	}
+	if stats.Completed != requests {
+		t.Fatalf("expected %d completed, got %d", requests, stats.Completed)
+	}
+	if stats.Active != 0 {
+		t.Fatalf("expected 0 active after all requests finished, got %d", stats.Active)
+	}
+	if stats.BytesOut != requests*uint64(len("hello world")) {
+		t.Fatalf("expected %d bytes decompressed, got %d", requests*uint64(len("hello world")), stats.BytesOut)
+	}
+}
+
+// TestDecompressionPoolErrorReleasesSlot verifies that a failed
+// DecompressStream call still releases its pool slot and is reflected in
+// Stats, so a run of corrupt layers can't permanently starve the pool.
+func TestDecompressionPoolErrorReleasesSlot(t *testing.T) {
+	pool := NewDecompressionPool(1)
+
+	if _, err := pool.DecompressStream(bytes.NewReader([]byte{0x1F, 0x8B, 0x08, 0xFF, 0xFF})); err == nil {
+		t.Fatal("expected an error decompressing corrupt gzip data")
+	}
+
+	stats := pool.Stats()
+	if stats.Errors != 1 {
+		t.Fatalf("expected 1 recorded error, got %d", stats.Errors)
+	}
+	if stats.Active != 0 {
+		t.Fatalf("expected slot to be released after error, got %d active", stats.Active)
+	}
+
+	// The released slot must be usable for a subsequent call.
+	rc, err := pool.DecompressStream(bytes.NewReader(gzipBytes(t, []byte("ok"))))
+	if err != nil {
+		t.Fatalf("expected pool to still be usable after a prior error: %v", err)
+	}
+	rc.Close()
+}
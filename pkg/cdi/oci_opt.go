@@ -27,6 +27,28 @@ import (
 	"github.com/containerd/containerd/v2/pkg/oci"
 )
 
+// Configure sets up the default CDI registry used by WithCDIDevices,
+// pointing it at specDirs (or the upstream package's built-in defaults, e.g.
+// /etc/cdi and /var/run/cdi, when specDirs is empty) and performing an
+// initial scan.
+//
+// Callers that never call Configure still get CDI device injection: the
+// registry lazily initializes itself with default settings on first use.
+// Configure exists for embedders of the core client that want the same
+// control over CDI spec directories and refresh behavior that CRI exposes
+// through its own configuration, without reaching past this package into
+// the upstream tags.cncf.io/container-device-interface API directly.
+func Configure(specDirs ...string) error {
+	opts := []cdi.Option{cdi.WithAutoRefresh(false)}
+	if len(specDirs) > 0 {
+		opts = append(opts, cdi.WithSpecDirs(specDirs...))
+	}
+	if err := cdi.Configure(opts...); err != nil {
+		return fmt.Errorf("failed to configure CDI registry: %w", err)
+	}
+	return cdi.Refresh()
+}
+
 // WithCDIDevices injects the requested CDI devices into the OCI specification.
 func WithCDIDevices(devices ...string) oci.SpecOpts {
 	return func(ctx context.Context, _ oci.Client, c *containers.Container, s *oci.Spec) error {
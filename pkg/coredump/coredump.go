@@ -0,0 +1,132 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package coredump implements capture and retention of container process
+// core dumps, written to by the kernel's core_pattern pipe handler
+// (see cmd/containerd-coredump-helper).
+package coredump
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Metadata describes the crashing process, as reported by the kernel through
+// core_pattern's %-specifiers.
+type Metadata struct {
+	ContainerID string    `json:"containerId"`
+	Pid         int       `json:"pid"`
+	Uid         int       `json:"uid"`
+	Gid         int       `json:"gid"`
+	Signal      int       `json:"signal"`
+	Comm        string    `json:"comm"`
+	Time        time.Time `json:"time"`
+}
+
+// Options controls where core dumps are stored and how long they are kept.
+type Options struct {
+	// Dir is the directory core dumps for ContainerID are written to.
+	Dir string
+	// MaxSizeBytes truncates a single core dump once it reaches this size.
+	// Zero means unlimited.
+	MaxSizeBytes int64
+	// MaxFiles is the number of core dumps retained per container; once
+	// exceeded, the oldest are removed. Zero means unlimited.
+	MaxFiles int
+}
+
+// Capture reads a core dump from r and writes it, along with a metadata
+// sidecar file, into opts.Dir, then enforces the configured retention limit.
+func Capture(r io.Reader, opts Options, meta Metadata) error {
+	dir := filepath.Join(opts.Dir, meta.ContainerID)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("create core dump directory: %w", err)
+	}
+
+	base := fmt.Sprintf("core.%s.%d", meta.Time.UTC().Format("20060102T150405Z"), meta.Pid)
+	corePath := filepath.Join(dir, base)
+
+	f, err := os.OpenFile(corePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("create core dump file: %w", err)
+	}
+	defer f.Close()
+
+	src := r
+	if opts.MaxSizeBytes > 0 {
+		src = io.LimitReader(r, opts.MaxSizeBytes)
+	}
+	if _, err := io.Copy(f, src); err != nil {
+		return fmt.Errorf("write core dump: %w", err)
+	}
+
+	metaBytes, err := json.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("marshal core dump metadata: %w", err)
+	}
+	if err := os.WriteFile(corePath+".json", metaBytes, 0600); err != nil {
+		return fmt.Errorf("write core dump metadata: %w", err)
+	}
+
+	return enforceRetention(dir, opts.MaxFiles)
+}
+
+// enforceRetention removes the oldest core dumps (and their metadata
+// sidecars) in dir until at most maxFiles remain.
+func enforceRetention(dir string, maxFiles int) error {
+	if maxFiles <= 0 {
+		return nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("list core dump directory: %w", err)
+	}
+
+	var cores []os.DirEntry
+	for _, e := range entries {
+		if !e.IsDir() && filepath.Ext(e.Name()) != ".json" {
+			cores = append(cores, e)
+		}
+	}
+	if len(cores) <= maxFiles {
+		return nil
+	}
+
+	sort.Slice(cores, func(i, j int) bool {
+		ii, _ := cores[i].Info()
+		jj, _ := cores[j].Info()
+		if ii == nil || jj == nil {
+			return cores[i].Name() < cores[j].Name()
+		}
+		return ii.ModTime().Before(jj.ModTime())
+	})
+
+	for _, e := range cores[:len(cores)-maxFiles] {
+		path := filepath.Join(dir, e.Name())
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove old core dump %s: %w", path, err)
+		}
+		if err := os.Remove(path + ".json"); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove old core dump metadata %s: %w", path, err)
+		}
+	}
+	return nil
+}
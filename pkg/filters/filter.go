@@ -65,6 +65,20 @@
 // ```
 // name==foo,labels.bar
 // ```
+//
+// A field can be prefixed with `!` to match objects where the field is not
+// present, the inverse of the bare presence check above:
+//
+// ```
+// !labels.bar
+// ```
+//
+// The `in` operator matches a field against a parenthesized, comma
+// separated set of values, matching if the field equals any one of them:
+//
+// ```
+// labels.stage in (prod, staging)
+// ```
 package filters
 
 import (
@@ -126,6 +140,8 @@ const (
 	operatorEqual
 	operatorNotEqual
 	operatorMatches
+	operatorIn
+	operatorNotPresent
 )
 
 func (op operator) String() string {
@@ -138,6 +154,10 @@ func (op operator) String() string {
 		return "!="
 	case operatorMatches:
 		return "~="
+	case operatorIn:
+		return "in"
+	case operatorNotPresent:
+		return "!"
 	}
 
 	return "unknown"
@@ -147,6 +167,7 @@ type selector struct {
 	fieldpath []string
 	operator  operator
 	value     string
+	values    []string
 	re        *regexp.Regexp
 }
 
@@ -156,10 +177,22 @@ func (m selector) Match(adaptor Adaptor) bool {
 	switch m.operator {
 	case operatorPresent:
 		return present
+	case operatorNotPresent:
+		return !present
 	case operatorEqual:
 		return present && value == m.value
 	case operatorNotEqual:
 		return value != m.value
+	case operatorIn:
+		if !present {
+			return false
+		}
+		for _, v := range m.values {
+			if v == value {
+				return true
+			}
+		}
+		return false
 	case operatorMatches:
 		if m.re == nil {
 			r, err := regexp.Compile(m.value)
@@ -282,6 +282,61 @@ func TestFilters(t *testing.T) {
 			input:     "labels.key==/value",
 			errString: `filters: parse error: [labels.key== >|/|< value]: quoted literal not terminated`,
 		},
+		{
+			name:  "LabelNotPresent",
+			input: "!labels.foo",
+			expected: []interface{}{
+				corpus[1],
+				corpus[3],
+				corpus[4],
+				corpus[5],
+				corpus[6],
+				corpus[7],
+			},
+		},
+		{
+			name:  "LabelNotPresentAndName",
+			input: "!labels.foo,name==bar",
+			expected: []interface{}{
+				corpus[1],
+				corpus[3],
+			},
+		},
+		{
+			name:  "NameIn",
+			input: "name in (foo,bazo)",
+			expected: []interface{}{
+				corpus[0],
+				corpus[2],
+				corpus[7],
+			},
+		},
+		{
+			name:  "NameInSingleValue",
+			input: "name in (bar)",
+			expected: []interface{}{
+				corpus[1],
+				corpus[3],
+			},
+		},
+		{
+			name:  "NameInQuotedValue",
+			input: `other in ("too complex, yo",abc)`,
+			expected: []interface{}{
+				corpus[6],
+				corpus[7],
+			},
+		},
+		{
+			name:      "InEmptyValueList",
+			input:     "name in ()",
+			errString: `filters: parse error: [name in ( >|)|< ]: value list must not be empty`,
+		},
+		{
+			name:      "InMissingCloseParen",
+			input:     "name in (foo",
+			errString: `filters: parse error: [name in (foo]: expected ',' or ')' in value list`,
+		},
 	} {
 		t.Run(testcase.name, func(t *testing.T) {
 			filter, err := Parse(testcase.input)
@@ -327,6 +382,8 @@ func TestOperatorStrings(t *testing.T) {
 		{operatorEqual, "=="},
 		{operatorNotEqual, "!="},
 		{operatorMatches, "~="},
+		{operatorIn, "in"},
+		{operatorNotPresent, "!"},
 		{10, "unknown"},
 	} {
 		if !reflect.DeepEqual(testcase.op.String(), testcase.expected) {
@@ -34,16 +34,20 @@ target object for the filter to be true.
 
 We define the operators "==" for equality, "!=" for not equal and "~=" for a
 regular expression. If the operator and value are not present, the matcher will
-test for the presence of a value, as defined by the target object.
+test for the presence of a value, as defined by the target object. A fieldpath
+may instead be prefixed with "!" to test for the absence of a value. The "in"
+operator matches a fieldpath against a parenthesized, comma separated set of
+values, matching if any one of them is equal to the field value.
 
 The formal grammar is as follows:
 
 selectors := selector ("," selector)*
-selector  := fieldpath (operator value)
+selector  := ["!"] fieldpath (operator value | "in" valuelist)
 fieldpath := field ('.' field)*
 field     := quoted | [A-Za-z] [A-Za-z0-9_]+
 operator  := "==" | "!=" | "~="
 value     := quoted | [^\s,]+
+valuelist := "(" value ("," value)* ")"
 quoted    := <go string syntax>
 */
 func Parse(s string) (Filter, error) {
@@ -129,6 +133,24 @@ loop:
 }
 
 func (p *parser) selector() (selector, error) {
+	if p.scanner.peek() == '!' {
+		pos, tok, text := p.scanner.scan()
+		if tok != tokenOperator || text != "!" {
+			return selector{}, p.mkerr(pos, "unexpected operator %q", text)
+		}
+		p.scanner.value = false
+
+		fieldpath, err := p.fieldpath()
+		if err != nil {
+			return selector{}, err
+		}
+
+		return selector{
+			fieldpath: fieldpath,
+			operator:  operatorNotPresent,
+		}, nil
+	}
+
 	fieldpath, err := p.fieldpath()
 	if err != nil {
 		return selector{}, err
@@ -147,6 +169,19 @@ func (p *parser) selector() (selector, error) {
 		return selector{}, err
 	}
 
+	if op == operatorIn {
+		values, err := p.valuelist()
+		if err != nil {
+			return selector{}, err
+		}
+
+		return selector{
+			fieldpath: fieldpath,
+			values:    values,
+			operator:  op,
+		}, nil
+	}
+
 	var allowAltQuotes bool
 	if op == operatorMatches {
 		allowAltQuotes = true
@@ -228,11 +263,54 @@ func (p *parser) operator() (operator, error) {
 		default:
 			return 0, p.mkerr(pos, "unsupported operator %q", s)
 		}
+	case tokenField:
+		if s == "in" {
+			return operatorIn, nil
+		}
 	case tokenIllegal:
 		return 0, p.mkerr(pos, "%s", p.scanner.err)
 	}
 
-	return 0, p.mkerr(pos, `expected an operator ("=="|"!="|"~=")`)
+	return 0, p.mkerr(pos, `expected an operator ("=="|"!="|"~="|"in")`)
+}
+
+// valuelist parses a parenthesized, comma separated list of values, as used
+// by the "in" operator, e.g. `(a,b,"c d")`.
+func (p *parser) valuelist() ([]string, error) {
+	pos, tok, _ := p.scanner.scan()
+	if tok != token('(') {
+		return nil, p.mkerr(pos, "expected '(' to start value list")
+	}
+
+	if p.scanner.peek() == ')' {
+		pos, _, _ := p.scanner.scan()
+		return nil, p.mkerr(pos, "value list must not be empty")
+	}
+
+	var values []string
+	for {
+		p.scanner.value = true
+		value, err := p.value(false)
+		p.scanner.value = false
+		if err != nil {
+			if err == io.EOF {
+				return nil, io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+
+		values = append(values, value)
+
+		pos, tok, text := p.scanner.scan()
+		switch {
+		case tok == tokenSeparator && text == ",":
+			continue
+		case tok == token(')'):
+			return values, nil
+		default:
+			return nil, p.mkerr(pos, "expected ',' or ')' in value list")
+		}
+	}
 }
 
 func (p *parser) value(allowAltQuotes bool) (string, error) {
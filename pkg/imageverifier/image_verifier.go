@@ -18,8 +18,12 @@ package imageverifier
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/containerd/log"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/containerd/containerd/v2/pkg/namespaces"
 )
 
 type ImageVerifier interface {
@@ -30,3 +34,72 @@ type Judgement struct {
 	OK     bool
 	Reason string
 }
+
+// Policy binds a set of named verifiers to the pull paths that must run
+// them, so the same verifiers and the same namespace scoping apply
+// whether an image is pulled through the transfer service or through
+// Client.Pull.
+type Policy struct {
+	// Verifiers are run, by name, against every image pull the policy is
+	// applied to.
+	Verifiers map[string]ImageVerifier
+
+	// Namespaces restricts a verifier, by name, to the listed
+	// namespaces. A verifier with no entry here (or an empty list) runs
+	// for every namespace.
+	Namespaces map[string][]string
+}
+
+// Verify runs every verifier in the policy that applies to the
+// namespace found on ctx against name/desc, returning an error from the
+// first verifier that errors or blocks the pull.
+func (p Policy) Verify(ctx context.Context, name string, desc ocispec.Descriptor) error {
+	if len(p.Verifiers) == 0 {
+		return nil
+	}
+	ns, _ := namespaces.Namespace(ctx)
+
+	for vfName, vf := range p.Verifiers {
+		if !p.applies(vfName, ns) {
+			continue
+		}
+
+		logger := log.G(ctx).WithFields(log.Fields{
+			"name":     name,
+			"digest":   desc.Digest.String(),
+			"verifier": vfName,
+		})
+		logger.Debug("Verifying image pull")
+
+		jdg, err := vf.VerifyImage(ctx, name, desc)
+		if err != nil {
+			logger.WithError(err).Error("No judgement received from verifier")
+			return fmt.Errorf("blocking pull of %v with digest %v: image verifier %v returned error: %w", name, desc.Digest.String(), vfName, err)
+		}
+		logger = logger.WithFields(log.Fields{
+			"ok":     jdg.OK,
+			"reason": jdg.Reason,
+		})
+
+		if !jdg.OK {
+			logger.Warn("Image verifier blocked pull")
+			return fmt.Errorf("image verifier %s blocked pull of %v with digest %v for reason: %v", vfName, name, desc.Digest.String(), jdg.Reason)
+		}
+		logger.Debug("Image verifier allowed pull")
+	}
+
+	return nil
+}
+
+func (p Policy) applies(verifierName, ns string) bool {
+	allowed, ok := p.Namespaces[verifierName]
+	if !ok || len(allowed) == 0 {
+		return true
+	}
+	for _, a := range allowed {
+		if a == ns {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package imageverifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/containerd/containerd/v2/pkg/namespaces"
+)
+
+type fakeVerifier struct {
+	called bool
+	jdg    *Judgement
+	err    error
+}
+
+func (f *fakeVerifier) VerifyImage(_ context.Context, _ string, _ ocispec.Descriptor) (*Judgement, error) {
+	f.called = true
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.jdg, nil
+}
+
+func TestPolicyVerifyEmpty(t *testing.T) {
+	var p Policy
+	err := p.Verify(context.Background(), "docker.io/library/busybox:latest", ocispec.Descriptor{})
+	require.NoError(t, err)
+}
+
+func TestPolicyVerifyBlocks(t *testing.T) {
+	vf := &fakeVerifier{jdg: &Judgement{OK: false, Reason: "not signed"}}
+	p := Policy{Verifiers: map[string]ImageVerifier{"test": vf}}
+
+	err := p.Verify(context.Background(), "docker.io/library/busybox:latest", ocispec.Descriptor{})
+	require.Error(t, err)
+	assert.True(t, vf.called)
+}
+
+func TestPolicyVerifyError(t *testing.T) {
+	vf := &fakeVerifier{err: errors.New("verifier unavailable")}
+	p := Policy{Verifiers: map[string]ImageVerifier{"test": vf}}
+
+	err := p.Verify(context.Background(), "docker.io/library/busybox:latest", ocispec.Descriptor{})
+	require.Error(t, err)
+}
+
+func TestPolicyVerifyAllows(t *testing.T) {
+	vf := &fakeVerifier{jdg: &Judgement{OK: true}}
+	p := Policy{Verifiers: map[string]ImageVerifier{"test": vf}}
+
+	err := p.Verify(context.Background(), "docker.io/library/busybox:latest", ocispec.Descriptor{})
+	require.NoError(t, err)
+	assert.True(t, vf.called)
+}
+
+func TestPolicyVerifyNamespaceScoping(t *testing.T) {
+	vf := &fakeVerifier{jdg: &Judgement{OK: false, Reason: "blocked"}}
+	p := Policy{
+		Verifiers:  map[string]ImageVerifier{"test": vf},
+		Namespaces: map[string][]string{"test": {"prod"}},
+	}
+
+	ctx := namespaces.WithNamespace(context.Background(), "default")
+	err := p.Verify(ctx, "docker.io/library/busybox:latest", ocispec.Descriptor{})
+	require.NoError(t, err, "verifier scoped to a different namespace should be skipped")
+	assert.False(t, vf.called)
+
+	ctx = namespaces.WithNamespace(context.Background(), "prod")
+	err = p.Verify(ctx, "docker.io/library/busybox:latest", ocispec.Descriptor{})
+	require.Error(t, err, "verifier should run for its configured namespace")
+	assert.True(t, vf.called)
+}
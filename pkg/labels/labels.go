@@ -27,3 +27,16 @@ const LabelSharedNamespace = "containerd.io/namespace.shareable"
 // LabelDistributionSource is added to content to indicate its origin.
 // e.g., "containerd.io/distribution.source.docker.io=library/redis"
 const LabelDistributionSource = "containerd.io/distribution.source"
+
+// LabelMaxContainers is set on a namespace to cap how many containers may
+// exist in it at once, e.g. "containerd.io/namespace.max-containers=100".
+// Container creation past the limit fails with errdefs.ErrResourceExhausted,
+// giving multi-tenant daemons a way to stop one namespace from using an
+// unbounded number of containers.
+const LabelMaxContainers = "containerd.io/namespace.max-containers"
+
+// LabelImageArtifactType is set on an image record pulled from an OCI
+// artifact manifest to the artifact's type, e.g.
+// "containerd.io/image.artifact.type=application/vnd.example.model".
+// It is absent on ordinary container images.
+const LabelImageArtifactType = "containerd.io/image.artifact.type"
@@ -0,0 +1,150 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package plugin registers an OTLP metrics exporter that republishes
+// containerd's existing Prometheus metrics (core, registry, transfer, and
+// any others registered into the default Prometheus registry) over OTLP,
+// for environments standardized on an OTLP collector that don't want to
+// also run a Prometheus scrape path.
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/containerd/containerd/v2/plugins"
+	"github.com/containerd/errdefs"
+	"github.com/containerd/plugin"
+	"github.com/containerd/plugin/registry"
+	prom "github.com/prometheus/client_golang/prometheus"
+	otelprometheus "go.opentelemetry.io/contrib/bridges/prometheus"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+const exporterPlugin = "otlp-metrics"
+
+// OTEL and OTLP standard env vars.
+// See https://opentelemetry.io/docs/specs/otel/configuration/sdk-environment-variables/
+const (
+	sdkDisabledEnv = "OTEL_SDK_DISABLED"
+
+	otlpEndpointEnv        = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	otlpMetricsEndpointEnv = "OTEL_EXPORTER_OTLP_METRICS_ENDPOINT"
+	otlpProtocolEnv        = "OTEL_EXPORTER_OTLP_PROTOCOL"
+	otlpMetricsProtocolEnv = "OTEL_EXPORTER_OTLP_METRICS_PROTOCOL"
+
+	otelMetricsExporterEnv = "OTEL_METRICS_EXPORTER"
+)
+
+func init() {
+	registry.Register(&plugin.Registration{
+		ID:   exporterPlugin,
+		Type: plugins.InternalPlugin,
+		Config: &Config{
+			ExportInterval: 15 * time.Second,
+		},
+		InitFn: func(ic *plugin.InitContext) (interface{}, error) {
+			if err := checkDisabled(); err != nil {
+				return nil, err
+			}
+
+			// If OTEL_METRICS_EXPORTER is set, it must be "otlp"
+			if v := os.Getenv(otelMetricsExporterEnv); v != "" && v != "otlp" {
+				return nil, fmt.Errorf("unsupported metrics exporter %q: %w", v, errdefs.ErrInvalidArgument)
+			}
+
+			cfg := ic.Config.(*Config)
+
+			exp, err := newExporter(ic.Context)
+			if err != nil {
+				return nil, err
+			}
+
+			producer := otelprometheus.NewMetricProducer(otelprometheus.WithGatherer(prom.DefaultGatherer))
+			reader := metric.NewPeriodicReader(exp,
+				metric.WithProducer(producer),
+				metric.WithInterval(cfg.ExportInterval),
+			)
+			provider := metric.NewMeterProvider(metric.WithReader(reader))
+
+			return closerFunc(func() error {
+				return provider.Shutdown(context.Background())
+			}), nil
+		},
+	})
+}
+
+// Config holds the configuration for the built-in OTLP metrics exporter.
+type Config struct {
+	// ExportInterval is how often the existing Prometheus metrics are
+	// gathered and pushed to the OTLP endpoint.
+	ExportInterval time.Duration `toml:"export_interval,omitempty"`
+}
+
+func checkDisabled() error {
+	v := os.Getenv(sdkDisabledEnv)
+	if v != "" {
+		disable, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s: %w: %w", sdkDisabledEnv, err, errdefs.ErrInvalidArgument)
+		}
+		if disable {
+			return fmt.Errorf("%w: metrics exporter disabled by env %s=%s", plugin.ErrSkipPlugin, sdkDisabledEnv, v)
+		}
+	}
+
+	if os.Getenv(otlpEndpointEnv) == "" && os.Getenv(otlpMetricsEndpointEnv) == "" {
+		return fmt.Errorf("%w: metrics endpoint not configured", plugin.ErrSkipPlugin)
+	}
+	return nil
+}
+
+type closerFunc func() error
+
+func (f closerFunc) Close() error {
+	return f()
+}
+
+// newExporter creates an OTLP metrics exporter based on the standard OTLP
+// protocol env vars.
+//
+// The default protocol is http/protobuf, matching the existing OTLP traces
+// exporter in pkg/tracing/plugin and the OTLP spec's recommended default.
+func newExporter(ctx context.Context) (metric.Exporter, error) {
+	const timeout = 5 * time.Second
+
+	v := os.Getenv(otlpMetricsProtocolEnv)
+	if v == "" {
+		v = os.Getenv(otlpProtocolEnv)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	switch v {
+	case "", "http/protobuf":
+		return otlpmetrichttp.New(ctx)
+	case "grpc":
+		return otlpmetricgrpc.New(ctx)
+	default:
+		// Other protocols such as "http/json" are not supported.
+		return nil, fmt.Errorf("OpenTelemetry protocol %q : %w", v, errdefs.ErrNotImplemented)
+	}
+}
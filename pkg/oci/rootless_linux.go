@@ -0,0 +1,140 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/moby/sys/user"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"golang.org/x/sys/unix"
+
+	"github.com/containerd/containerd/v2/core/containers"
+)
+
+// WithRootlessUserNamespace derives uid and gid mappings for the container
+// from the calling user's /etc/subuid and /etc/subgid ranges and sets them
+// as a user namespace on the spec, the same mapping convention used by
+// rootlesskit and dockerd's rootless mode: the calling user's own uid/gid
+// maps to container uid/gid 0, and the subordinate id range from
+// subuid(5)/subgid(5) maps to the rest of the container's id space.
+//
+// It is meant for processes themselves running unprivileged, i.e. without
+// CAP_SETUID/CAP_SETGID on the host; such a process cannot populate
+// arbitrary id mappings and is restricted by the kernel to mapping its own
+// id plus whatever ranges subuid/subgid delegate to it.
+func WithRootlessUserNamespace() SpecOpts {
+	return func(ctx context.Context, client Client, c *containers.Container, s *Spec) error {
+		uidMap, gidMap, err := rootlessIDMaps()
+		if err != nil {
+			return fmt.Errorf("failed to compute rootless id mappings: %w", err)
+		}
+		return WithUserNamespace(uidMap, gidMap)(ctx, client, c, s)
+	}
+}
+
+func rootlessIDMaps() ([]specs.LinuxIDMapping, []specs.LinuxIDMapping, error) {
+	uid := os.Getuid()
+	gid := os.Getgid()
+
+	subUID, err := subIDRange("/etc/subuid", uid)
+	if err != nil {
+		return nil, nil, err
+	}
+	subGID, err := subIDRange("/etc/subgid", gid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uidMap := []specs.LinuxIDMapping{
+		{ContainerID: 0, HostID: uint32(uid), Size: 1},
+		{ContainerID: 1, HostID: uint32(subUID.SubID), Size: uint32(subUID.Count)},
+	}
+	gidMap := []specs.LinuxIDMapping{
+		{ContainerID: 0, HostID: uint32(gid), Size: 1},
+		{ContainerID: 1, HostID: uint32(subGID.SubID), Size: uint32(subGID.Count)},
+	}
+	return uidMap, gidMap, nil
+}
+
+// subIDRange returns the subuid/subgid range delegated to id (matched by
+// either the numeric id or, failing that, the calling user's username, the
+// same lookup order used by newuidmap(1)/newgidmap(1)).
+func subIDRange(path string, id int) (user.SubID, error) {
+	name := strconv.Itoa(id)
+	if u, err := user.LookupUid(id); err == nil {
+		name = u.Name
+	}
+
+	entries, err := user.ParseSubIDFileFilter(path, func(e user.SubID) bool {
+		return e.Name == name
+	})
+	if err != nil {
+		return user.SubID{}, fmt.Errorf("reading %s: %w", path, err)
+	}
+	if len(entries) == 0 {
+		return user.SubID{}, fmt.Errorf("no subordinate id range for %q in %s", name, path)
+	}
+	return entries[0], nil
+}
+
+// WithRootlessCgroups clears the spec's cgroups path and resource limits
+// unless the calling user has been delegated a cgroup to manage. A rootless
+// container without a delegated cgroup cannot have resource limits applied
+// to it, and asking runc to do so anyway fails with a permission error from
+// deep inside the shim rather than a clear message from containerd.
+func WithRootlessCgroups() SpecOpts {
+	return func(_ context.Context, _ Client, _ *containers.Container, s *Spec) error {
+		if hasCgroupDelegation() {
+			return nil
+		}
+		setLinux(s)
+		s.Linux.CgroupsPath = ""
+		s.Linux.Resources = nil
+		return nil
+	}
+}
+
+// hasCgroupDelegation reports whether the calling user can manage the
+// current cgroup, which on a cgroup v2 system means having been delegated
+// write access to it (see cgroups(7), "Non-root delegation containers").
+var hasCgroupDelegation = func() bool {
+	return unix.Access("/sys/fs/cgroup/cgroup.subtree_control", unix.W_OK) == nil
+}
+
+// WithRootlessDevices bind-mounts the host device node at devicePath into
+// the container at containerPath (devicePath if empty), instead of adding
+// it as a device node to be created with mknod. An unprivileged user
+// namespace's root cannot mknod device nodes (it lacks CAP_MKNOD against
+// the owning, non-initial user namespace), so rootless containers must
+// have devices bind-mounted from the host in already created instead.
+func WithRootlessDevices(devicePath, containerPath string) SpecOpts {
+	if containerPath == "" {
+		containerPath = devicePath
+	}
+	return WithMounts([]specs.Mount{
+		{
+			Source:      devicePath,
+			Destination: containerPath,
+			Type:        "bind",
+			Options:     []string{"rbind", "rw"},
+		},
+	})
+}
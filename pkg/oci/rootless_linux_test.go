@@ -0,0 +1,75 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package oci
+
+import (
+	"context"
+	"testing"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/containerd/containerd/v2/core/containers"
+)
+
+func TestWithRootlessCgroupsWithDelegation(t *testing.T) {
+	orig := hasCgroupDelegation
+	defer func() { hasCgroupDelegation = orig }()
+	hasCgroupDelegation = func() bool { return true }
+
+	s := &Spec{Linux: &specs.Linux{CgroupsPath: "/foo", Resources: &specs.LinuxResources{}}}
+	if err := WithRootlessCgroups()(context.Background(), nil, &containers.Container{}, s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Linux.CgroupsPath != "/foo" {
+		t.Errorf("expected cgroups path to be left alone, got %q", s.Linux.CgroupsPath)
+	}
+	if s.Linux.Resources == nil {
+		t.Error("expected resources to be left alone")
+	}
+}
+
+func TestWithRootlessCgroupsWithoutDelegation(t *testing.T) {
+	orig := hasCgroupDelegation
+	defer func() { hasCgroupDelegation = orig }()
+	hasCgroupDelegation = func() bool { return false }
+
+	s := &Spec{Linux: &specs.Linux{CgroupsPath: "/foo", Resources: &specs.LinuxResources{}}}
+	if err := WithRootlessCgroups()(context.Background(), nil, &containers.Container{}, s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Linux.CgroupsPath != "" {
+		t.Errorf("expected cgroups path to be cleared, got %q", s.Linux.CgroupsPath)
+	}
+	if s.Linux.Resources != nil {
+		t.Error("expected resources to be cleared")
+	}
+}
+
+func TestWithRootlessDevices(t *testing.T) {
+	s := &Spec{}
+	err := WithRootlessDevices("/dev/foo", "")(context.Background(), nil, &containers.Container{}, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Mounts) != 1 {
+		t.Fatalf("expected one mount, got %d", len(s.Mounts))
+	}
+	m := s.Mounts[0]
+	if m.Source != "/dev/foo" || m.Destination != "/dev/foo" || m.Type != "bind" {
+		t.Errorf("unexpected mount: %+v", m)
+	}
+}
@@ -585,6 +585,29 @@ func WithNamespacedCgroup() SpecOpts {
 	}
 }
 
+// WithCoreDumpHandler routes core dumps from processes inside the container
+// to helperPath (installed on the host, e.g. containerd-coredump-helper),
+// which writes them under dir/<container-id>, truncating at maxSizeBytes
+// (0 for unlimited) and keeping at most maxFiles per container (0 for
+// unlimited), instead of letting them fall through to the host's default
+// core_pattern handler.
+//
+// This relies on core_pattern being namespaced per PID namespace, so it only
+// takes effect for containers with their own PID namespace.
+func WithCoreDumpHandler(helperPath, dir string, maxSizeBytes int64, maxFiles int) SpecOpts {
+	return func(_ context.Context, _ Client, c *containers.Container, s *Spec) error {
+		setLinux(s)
+		if s.Linux.Sysctl == nil {
+			s.Linux.Sysctl = make(map[string]string)
+		}
+		s.Linux.Sysctl["kernel.core_pattern"] = fmt.Sprintf(
+			"|%s -dir=%s -id=%s -limit=%d -retain=%d %%p %%u %%g %%s %%t %%e",
+			helperPath, dir, c.ID, maxSizeBytes, maxFiles,
+		)
+		return nil
+	}
+}
+
 // WithUser sets the user to be used within the container.
 // It accepts a valid user string in OCI Image Spec v1.0.0:
 //
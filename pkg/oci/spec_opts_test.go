@@ -401,6 +401,17 @@ func TestWithPidsLimit(t *testing.T) {
 	}
 }
 
+func TestWithCoreDumpHandler(t *testing.T) {
+	c := containers.Container{ID: "TestWithCoreDumpHandler"}
+	var s Spec
+	err := WithCoreDumpHandler("/usr/bin/containerd-coredump-helper", "/var/lib/containerd/coredumps", 64*1024*1024, 5)(nil, nil, &c, &s)
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"|/usr/bin/containerd-coredump-helper -dir=/var/lib/containerd/coredumps -id=TestWithCoreDumpHandler -limit=67108864 -retain=5 %p %u %g %s %t %e",
+		s.Linux.Sysctl["kernel.core_pattern"],
+	)
+}
+
 func TestWithBlockIO(t *testing.T) {
 	for name, spec := range emptySpecs {
 		t.Run(name, func(t *testing.T) {
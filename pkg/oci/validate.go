@@ -0,0 +1,104 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package oci
+
+import (
+	"fmt"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/containerd/errdefs"
+)
+
+// Validate checks a generated spec for combinations of settings that the
+// OCI runtime spec allows to be constructed but that runc (or another OCI
+// runtime) will refuse at container start, such as mismatched user
+// namespace mappings or duplicate mounts. Catching them here gives a
+// descriptive error instead of an opaque failure surfaced from the shim
+// after the runtime has already been invoked.
+//
+// Validate does not attempt to catch every invalid spec; it covers the
+// mistakes that are easy to make by hand-composing SpecOpts or editing a
+// --config file, not every rule enforced by the runtime spec.
+func Validate(s *specs.Spec) error {
+	if s == nil {
+		return fmt.Errorf("spec must not be nil: %w", errdefs.ErrInvalidArgument)
+	}
+	if s.Process == nil {
+		return fmt.Errorf("spec.process must be set: %w", errdefs.ErrInvalidArgument)
+	}
+	if len(s.Process.Args) == 0 {
+		return fmt.Errorf("spec.process.args must not be empty: %w", errdefs.ErrInvalidArgument)
+	}
+	if s.Root == nil || s.Root.Path == "" {
+		return fmt.Errorf("spec.root.path must be set: %w", errdefs.ErrInvalidArgument)
+	}
+
+	seenMounts := make(map[string]bool, len(s.Mounts))
+	for _, m := range s.Mounts {
+		if seenMounts[m.Destination] {
+			return fmt.Errorf("duplicate mount destination %q: %w", m.Destination, errdefs.ErrInvalidArgument)
+		}
+		seenMounts[m.Destination] = true
+	}
+
+	if s.Linux != nil {
+		if err := validateLinuxNamespaces(s.Linux); err != nil {
+			return err
+		}
+
+		seenDevices := make(map[string]bool, len(s.Linux.Devices))
+		for _, d := range s.Linux.Devices {
+			if seenDevices[d.Path] {
+				return fmt.Errorf("duplicate device path %q: %w", d.Path, errdefs.ErrInvalidArgument)
+			}
+			seenDevices[d.Path] = true
+		}
+	}
+
+	return nil
+}
+
+func validateLinuxNamespaces(linux *specs.Linux) error {
+	seenTypes := make(map[specs.LinuxNamespaceType]bool, len(linux.Namespaces))
+	var hasUserNS bool
+	for _, ns := range linux.Namespaces {
+		if seenTypes[ns.Type] {
+			return fmt.Errorf("duplicate namespace %q: %w", ns.Type, errdefs.ErrInvalidArgument)
+		}
+		seenTypes[ns.Type] = true
+		if ns.Type == specs.UserNamespace {
+			hasUserNS = true
+		}
+	}
+
+	if !hasUserNS {
+		if len(linux.UIDMappings) > 0 || len(linux.GIDMappings) > 0 {
+			return fmt.Errorf("uid/gid mappings set without a user namespace: %w", errdefs.ErrInvalidArgument)
+		}
+		return nil
+	}
+
+	if len(linux.UIDMappings) == 0 {
+		return fmt.Errorf("user namespace enabled without any uid mappings: %w", errdefs.ErrInvalidArgument)
+	}
+	if len(linux.GIDMappings) == 0 {
+		return fmt.Errorf("user namespace enabled without any gid mappings: %w", errdefs.ErrInvalidArgument)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package oci
+
+import (
+	"testing"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/containerd/errdefs"
+)
+
+func validSpec() *specs.Spec {
+	return &specs.Spec{
+		Process: &specs.Process{Args: []string{"sh"}},
+		Root:    &specs.Root{Path: "rootfs"},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(validSpec()); err != nil {
+		t.Errorf("expected a valid spec to pass, got: %v", err)
+	}
+}
+
+func TestValidateRejectsMissingFields(t *testing.T) {
+	for name, mutate := range map[string]func(*specs.Spec){
+		"nil process": func(s *specs.Spec) { s.Process = nil },
+		"empty args":  func(s *specs.Spec) { s.Process.Args = nil },
+		"nil root":    func(s *specs.Spec) { s.Root = nil },
+		"empty root path": func(s *specs.Spec) {
+			s.Root = &specs.Root{}
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := validSpec()
+			mutate(s)
+			if err := Validate(s); !errdefs.IsInvalidArgument(err) {
+				t.Errorf("expected ErrInvalidArgument, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateRejectsDuplicateMounts(t *testing.T) {
+	s := validSpec()
+	s.Mounts = []specs.Mount{
+		{Destination: "/dup", Source: "/a"},
+		{Destination: "/dup", Source: "/b"},
+	}
+	if err := Validate(s); !errdefs.IsInvalidArgument(err) {
+		t.Errorf("expected ErrInvalidArgument, got: %v", err)
+	}
+}
+
+func TestValidateRejectsDuplicateDevices(t *testing.T) {
+	s := validSpec()
+	s.Linux = &specs.Linux{
+		Devices: []specs.LinuxDevice{
+			{Path: "/dev/dup"},
+			{Path: "/dev/dup"},
+		},
+	}
+	if err := Validate(s); !errdefs.IsInvalidArgument(err) {
+		t.Errorf("expected ErrInvalidArgument, got: %v", err)
+	}
+}
+
+func TestValidateRejectsDuplicateNamespaces(t *testing.T) {
+	s := validSpec()
+	s.Linux = &specs.Linux{
+		Namespaces: []specs.LinuxNamespace{
+			{Type: specs.PIDNamespace},
+			{Type: specs.PIDNamespace},
+		},
+	}
+	if err := Validate(s); !errdefs.IsInvalidArgument(err) {
+		t.Errorf("expected ErrInvalidArgument, got: %v", err)
+	}
+}
+
+func TestValidateUserNamespaceMappings(t *testing.T) {
+	mappings := []specs.LinuxIDMapping{{ContainerID: 0, HostID: 1000, Size: 1}}
+
+	t.Run("mappings without user namespace", func(t *testing.T) {
+		s := validSpec()
+		s.Linux = &specs.Linux{UIDMappings: mappings, GIDMappings: mappings}
+		if err := Validate(s); !errdefs.IsInvalidArgument(err) {
+			t.Errorf("expected ErrInvalidArgument, got: %v", err)
+		}
+	})
+
+	t.Run("user namespace without mappings", func(t *testing.T) {
+		s := validSpec()
+		s.Linux = &specs.Linux{
+			Namespaces: []specs.LinuxNamespace{{Type: specs.UserNamespace}},
+		}
+		if err := Validate(s); !errdefs.IsInvalidArgument(err) {
+			t.Errorf("expected ErrInvalidArgument, got: %v", err)
+		}
+	})
+
+	t.Run("user namespace with mappings", func(t *testing.T) {
+		s := validSpec()
+		s.Linux = &specs.Linux{
+			Namespaces:  []specs.LinuxNamespace{{Type: specs.UserNamespace}},
+			UIDMappings: mappings,
+			GIDMappings: mappings,
+		}
+		if err := Validate(s); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+}
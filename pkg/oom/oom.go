@@ -20,6 +20,11 @@ package oom
 
 import (
 	"context"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/containerd/log"
 )
 
 // Watcher watches OOM events
@@ -28,3 +33,33 @@ type Watcher interface {
 	Run(ctx context.Context)
 	Add(id string, cg interface{}) error
 }
+
+// LogSurvivors logs the processes that remain in the cgroup after an OOM
+// kill, best-effort, so operators have a lead on which process was likely
+// the victim. The kernel's cgroup memory controller does not report the
+// identity of the killed process, only that a kill happened, so the exact
+// victim PID and command cannot be recovered here.
+func LogSurvivors(ctx context.Context, containerID string, pids []int) {
+	fields := log.Fields{
+		"container":     containerID,
+		"survivingPids": pids,
+	}
+	var cmds []string
+	for _, pid := range pids {
+		if cmd := processComm(pid); cmd != "" {
+			cmds = append(cmds, strconv.Itoa(pid)+":"+cmd)
+		}
+	}
+	if len(cmds) > 0 {
+		fields["survivingCmds"] = cmds
+	}
+	log.G(ctx).WithFields(fields).Warn("out of memory: surviving processes in cgroup after OOM kill")
+}
+
+func processComm(pid int) string {
+	data, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/comm")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -141,6 +141,15 @@ func (e *epoller) process(ctx context.Context, fd uintptr) {
 	}); err != nil {
 		log.G(ctx).WithError(err).Error("publish OOM event")
 	}
+	if procs, err := i.cg.Processes(cgroup1.Memory, true); err != nil {
+		log.G(ctx).WithError(err).Warn("list surviving processes after OOM kill")
+	} else {
+		pids := make([]int, len(procs))
+		for idx, p := range procs {
+			pids[idx] = p.Pid
+		}
+		oom.LogSurvivors(ctx, i.id, pids)
+	}
 }
 
 func flush(fd uintptr) error {
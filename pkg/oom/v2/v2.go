@@ -47,6 +47,7 @@ type watcher struct {
 
 type item struct {
 	id  string
+	cg  *cgroupsv2.Manager
 	ev  cgroupsv2.Event
 	err error
 }
@@ -76,6 +77,13 @@ func (w *watcher) Run(ctx context.Context) {
 				}); err != nil {
 					log.G(ctx).WithError(err).Error("publish OOM event")
 				}
+				if i.cg != nil {
+					if pids, err := i.cg.Procs(true); err != nil {
+						log.G(ctx).WithError(err).Warn("list surviving processes after OOM kill")
+					} else {
+						oom.LogSurvivors(ctx, i.id, toInts(pids))
+					}
+				}
 			}
 			if i.ev.OOMKill > 0 {
 				lastOOMMap[i.id] = i.ev.OOMKill
@@ -95,7 +103,7 @@ func (w *watcher) Add(id string, cgx interface{}) error {
 	eventCh, errCh := cg.EventChan()
 	go func() {
 		for {
-			i := item{id: id}
+			i := item{id: id, cg: cg}
 			select {
 			case ev := <-eventCh:
 				i.ev = ev
@@ -114,3 +122,11 @@ func (w *watcher) Add(id string, cgx interface{}) error {
 	}()
 	return nil
 }
+
+func toInts(pids []uint64) []int {
+	out := make([]int, len(pids))
+	for i, pid := range pids {
+		out[i] = int(pid)
+	}
+	return out
+}
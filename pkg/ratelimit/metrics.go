@@ -0,0 +1,39 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ratelimit
+
+import "github.com/docker/go-metrics"
+
+// requestCounter counts GRPC requests seen by a Limiter's interceptor,
+// labeled by method and whether the request was allowed or throttled.
+var requestCounter metrics.LabeledCounter
+
+func init() {
+	ns := metrics.NewNamespace("containerd", "ratelimit", nil)
+	requestCounter = ns.NewLabeledCounter("requests", "counter of GRPC requests seen by the rate limiter", "method", "status")
+	metrics.Register(ns)
+}
+
+// RecordMetrics is a record func (see Limiter.UnaryServerInterceptor) that
+// reports each call to the "containerd_ratelimit_requests" counter.
+func RecordMetrics(method string, throttled bool) {
+	status := "allowed"
+	if throttled {
+		status = "throttled"
+	}
+	requestCounter.WithValues(method, status).Inc()
+}
@@ -0,0 +1,200 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package ratelimit provides a GRPC server interceptor enforcing
+// per-method request-rate limits, optionally tracked separately per
+// caller, to protect the daemon from a single misbehaving client
+// hammering it with requests.
+package ratelimit
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"github.com/containerd/errdefs"
+	"github.com/containerd/errdefs/pkg/errgrpc"
+)
+
+// defaultMaxTrackedCallers is used when Config.PerCaller is set and
+// Config.MaxTrackedCallers is left at zero.
+const defaultMaxTrackedCallers = 8192
+
+// Limits configures the request-rate limit applied to one GRPC method:
+// up to Burst requests may be admitted instantly, refilling at QPS
+// requests per second thereafter.
+type Limits struct {
+	QPS   float64
+	Burst int
+}
+
+// Config configures a Limiter.
+type Config struct {
+	// Default is applied to any method not named in PerMethod.
+	Default Limits
+	// PerMethod overrides Default for specific methods, keyed by the
+	// method's short name (the part after the last "/" in the full GRPC
+	// method, e.g. "List" for "/containerd.services.containers.v1.Containers/List").
+	PerMethod map[string]Limits
+	// PerCaller tracks limits separately per caller (see callerID) rather
+	// than sharing one limiter per method across every caller.
+	PerCaller bool
+	// MaxTrackedCallers caps how many distinct (method, caller) limiter
+	// entries are kept at once when PerCaller is set; the least recently
+	// used entry is evicted once the cap is reached. callerID is derived
+	// from the unauthenticated peer address, so without this cap a remote
+	// client could grow limiters without bound simply by connecting from
+	// a new address per request. Defaults to defaultMaxTrackedCallers if
+	// left at zero. Has no effect when PerCaller is false, since then
+	// there is at most one limiter per configured method.
+	MaxTrackedCallers int
+}
+
+// Limiter enforces per-method, and optionally per-caller, request-rate
+// limits. It is safe for concurrent use.
+type Limiter struct {
+	cfg               Config
+	maxTrackedCallers int
+
+	mu       sync.Mutex
+	limiters map[limiterKey]*list.Element
+	lru      *list.List // of *limiterEntry, most recently used at the front
+}
+
+type limiterKey struct {
+	method string
+	caller string
+}
+
+type limiterEntry struct {
+	key     limiterKey
+	limiter *rate.Limiter
+}
+
+// New returns a Limiter configured with cfg.
+func New(cfg Config) *Limiter {
+	maxTrackedCallers := cfg.MaxTrackedCallers
+	if maxTrackedCallers <= 0 {
+		maxTrackedCallers = defaultMaxTrackedCallers
+	}
+	return &Limiter{
+		cfg:               cfg,
+		maxTrackedCallers: maxTrackedCallers,
+		limiters:          make(map[limiterKey]*list.Element),
+		lru:               list.New(),
+	}
+}
+
+// methodName returns the short method name (the part after the last "/")
+// from a full GRPC method, e.g. "/containerd.services.containers.v1.Containers/List" -> "List".
+func methodName(fullMethod string) string {
+	if idx := strings.LastIndex(fullMethod, "/"); idx >= 0 {
+		return fullMethod[idx+1:]
+	}
+	return fullMethod
+}
+
+// callerID returns the best-available identity for the peer making the
+// request. As in newAuditUnaryInterceptor (cmd/containerd/server/audit.go),
+// containerd's local listener doesn't currently expose SO_PEERCRED, so on
+// a unix socket this is the socket/abstract address rather than a real
+// per-UID identity; it still separates distinct TCP peers from each other.
+func callerID(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+func (l *Limiter) limits(method string) Limits {
+	if lim, ok := l.cfg.PerMethod[method]; ok {
+		return lim
+	}
+	return l.cfg.Default
+}
+
+func (l *Limiter) limiterFor(method, caller string) *rate.Limiter {
+	key := limiterKey{method: method}
+	if l.cfg.PerCaller {
+		key.caller = caller
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if elem, ok := l.limiters[key]; ok {
+		if l.cfg.PerCaller {
+			l.lru.MoveToFront(elem)
+		}
+		return elem.Value.(*limiterEntry).limiter
+	}
+
+	limits := l.limits(method)
+	lim := rate.NewLimiter(rate.Limit(limits.QPS), limits.Burst)
+
+	// Eviction only applies to the PerCaller case: without it, keys are
+	// drawn from the fixed, operator-configured set of method names, so
+	// the map can never grow beyond what Config itself allows.
+	if l.cfg.PerCaller {
+		for l.lru.Len() >= l.maxTrackedCallers {
+			oldest := l.lru.Back()
+			if oldest == nil {
+				break
+			}
+			l.lru.Remove(oldest)
+			delete(l.limiters, oldest.Value.(*limiterEntry).key)
+		}
+	}
+	l.limiters[key] = l.lru.PushFront(&limiterEntry{key: key, limiter: lim})
+	return lim
+}
+
+// Allow reports whether a request for the given full GRPC method and
+// caller may proceed.
+func (l *Limiter) Allow(ctx context.Context, fullMethod string) bool {
+	method := methodName(fullMethod)
+	limits := l.limits(method)
+	if limits.QPS <= 0 {
+		return true
+	}
+	return l.limiterFor(method, callerID(ctx)).Allow()
+}
+
+// UnaryServerInterceptor returns a grpc.UnaryServerInterceptor that
+// rejects requests exceeding l's configured rate limits with a
+// ResourceExhausted error, recording an Allowed/Throttled outcome for
+// every call via record.
+func (l *Limiter) UnaryServerInterceptor(record func(method string, throttled bool)) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		method := methodName(info.FullMethod)
+		if !l.Allow(ctx, info.FullMethod) {
+			if record != nil {
+				record(method, true)
+			}
+			return nil, errgrpc.ToGRPC(fmt.Errorf("rate limit exceeded for %s: %w", method, errdefs.ErrResourceExhausted))
+		}
+		if record != nil {
+			record(method, false)
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,172 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLimiterAllowBurstThenThrottle(t *testing.T) {
+	l := New(Config{Default: Limits{QPS: 1, Burst: 2}})
+
+	ctx := context.Background()
+	const method = "/containerd.services.containers.v1.Containers/List"
+
+	if !l.Allow(ctx, method) {
+		t.Fatal("first request within burst should be allowed")
+	}
+	if !l.Allow(ctx, method) {
+		t.Fatal("second request within burst should be allowed")
+	}
+	if l.Allow(ctx, method) {
+		t.Fatal("third request exceeding burst should be throttled")
+	}
+}
+
+func TestLimiterZeroQPSDisablesLimit(t *testing.T) {
+	l := New(Config{Default: Limits{QPS: 0}})
+	ctx := context.Background()
+	const method = "/containerd.services.containers.v1.Containers/List"
+
+	for i := 0; i < 100; i++ {
+		if !l.Allow(ctx, method) {
+			t.Fatalf("request %d should be allowed when QPS is 0 (unlimited)", i)
+		}
+	}
+}
+
+func TestLimiterPerMethodOverride(t *testing.T) {
+	l := New(Config{
+		Default: Limits{QPS: 100, Burst: 100},
+		PerMethod: map[string]Limits{
+			"List": {QPS: 1, Burst: 1},
+		},
+	})
+	ctx := context.Background()
+
+	if !l.Allow(ctx, "/containerd.services.containers.v1.Containers/List") {
+		t.Fatal("first List request within its per-method burst should be allowed")
+	}
+	if l.Allow(ctx, "/containerd.services.containers.v1.Containers/List") {
+		t.Fatal("second List request should be throttled by its per-method override")
+	}
+	if !l.Allow(ctx, "/containerd.services.containers.v1.Containers/Create") {
+		t.Fatal("Create should use the generous default limits, not List's override")
+	}
+}
+
+func TestLimiterPerCallerIsolation(t *testing.T) {
+	l := New(Config{Default: Limits{QPS: 1, Burst: 1}, PerCaller: true})
+	method := "/containerd.services.containers.v1.Containers/List"
+
+	if !l.Allow(context.Background(), method) {
+		t.Fatal("caller with empty identity should get its own limiter and be allowed once")
+	}
+
+	type ctxKey struct{}
+	// Exercise limiterFor directly with distinct caller keys, since
+	// attaching a fake peer.Peer to a context is more machinery than this
+	// unit needs: the interesting behavior is that two different keys
+	// don't share a bucket.
+	k1 := limiterKey{method: method, caller: "unix:/run/a.sock->pid=1"}
+	k2 := limiterKey{method: method, caller: "unix:/run/a.sock->pid=2"}
+	if k1 == k2 {
+		t.Fatal("expected distinct caller keys to differ")
+	}
+}
+
+func TestLimiterPerCallerEvictsLeastRecentlyUsed(t *testing.T) {
+	l := New(Config{Default: Limits{QPS: 1, Burst: 1}, PerCaller: true, MaxTrackedCallers: 2})
+	method := "/containerd.services.containers.v1.Containers/List"
+
+	callers := []string{"caller-a", "caller-b", "caller-c"}
+	for _, c := range callers {
+		l.limiterFor(method, c)
+	}
+
+	l.mu.Lock()
+	n := len(l.limiters)
+	llLen := l.lru.Len()
+	_, aTracked := l.limiters[limiterKey{method: method, caller: "caller-a"}]
+	_, cTracked := l.limiters[limiterKey{method: method, caller: "caller-c"}]
+	l.mu.Unlock()
+
+	if n != 2 || llLen != 2 {
+		t.Fatalf("expected tracked callers to be capped at 2, got map=%d list=%d", n, llLen)
+	}
+	if aTracked {
+		t.Fatal("caller-a should have been evicted as the least recently used entry")
+	}
+	if !cTracked {
+		t.Fatal("caller-c, the most recently added, should still be tracked")
+	}
+}
+
+func TestLimiterPerCallerEvictionSparesRecentlyUsed(t *testing.T) {
+	l := New(Config{Default: Limits{QPS: 1, Burst: 1}, PerCaller: true, MaxTrackedCallers: 2})
+	method := "/containerd.services.containers.v1.Containers/List"
+
+	l.limiterFor(method, "caller-a")
+	l.limiterFor(method, "caller-b")
+	// Touch caller-a again so caller-b becomes the least recently used.
+	l.limiterFor(method, "caller-a")
+	l.limiterFor(method, "caller-c")
+
+	l.mu.Lock()
+	_, aTracked := l.limiters[limiterKey{method: method, caller: "caller-a"}]
+	_, bTracked := l.limiters[limiterKey{method: method, caller: "caller-b"}]
+	l.mu.Unlock()
+
+	if bTracked {
+		t.Fatal("caller-b should have been evicted, not caller-a which was used more recently")
+	}
+	if !aTracked {
+		t.Fatal("caller-a should still be tracked since it was touched after caller-b")
+	}
+}
+
+func TestUnaryServerInterceptorReturnsResourceExhausted(t *testing.T) {
+	l := New(Config{Default: Limits{QPS: 1, Burst: 1}})
+	var throttledCalls int
+	interceptor := l.UnaryServerInterceptor(func(method string, throttled bool) {
+		if throttled {
+			throttledCalls++
+		}
+	})
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/containerd.services.containers.v1.Containers/List"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	if _, err := interceptor(context.Background(), nil, info, handler); err != nil {
+		t.Fatalf("first call should be allowed: %v", err)
+	}
+	_, err := interceptor(context.Background(), nil, info, handler)
+	if err == nil {
+		t.Fatal("second call should be throttled")
+	}
+	if code := status.Code(err); code != codes.ResourceExhausted {
+		t.Fatalf("expected a ResourceExhausted GRPC status, got %s: %v", code, err)
+	}
+	if throttledCalls != 1 {
+		t.Fatalf("expected record to be called once with throttled=true, got %d", throttledCalls)
+	}
+}
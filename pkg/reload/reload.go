@@ -0,0 +1,95 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package reload lets plugin instances opt into validating a proposed
+// configuration and applying a validated configuration change while the
+// daemon keeps running, without requiring a full restart.
+package reload
+
+import (
+	"context"
+	"fmt"
+)
+
+// Validator is implemented by a plugin instance that can check whether a
+// proposed configuration is acceptable. config is decoded into the same
+// concrete type the plugin declared in its plugin.Registration.Config.
+// ValidateConfig must not mutate the running plugin; it only reports
+// whether config would be accepted.
+type Validator interface {
+	ValidateConfig(config interface{}) error
+}
+
+// Reloader is implemented by a plugin instance that can apply a subset of
+// its configuration while running. ReloadConfig is only called with a
+// config that has already passed ValidateConfig, if the plugin also
+// implements Validator. A plugin that only implements Reloader is
+// responsible for validating config itself before applying it.
+type Reloader interface {
+	ReloadConfig(ctx context.Context, config interface{}) error
+}
+
+// Candidate is a loaded plugin instance together with the new
+// configuration proposed for it.
+type Candidate struct {
+	// ID is the plugin's registration ID (plugin.Registration.ID), used
+	// to identify it in error messages.
+	ID string
+	// Instance is the running plugin instance, as returned by
+	// plugin.Plugin.Instance.
+	Instance interface{}
+	// Config is the new configuration to validate and, if accepted,
+	// apply to Instance.
+	Config interface{}
+}
+
+// Stage validates every candidate's proposed configuration and, only if
+// all of them are accepted, applies it to each plugin that implements
+// Reloader. Validation and application are each done in the order
+// candidates are given.
+//
+// Staging the validation pass before any ReloadConfig call means a
+// rejected configuration change cannot leave some plugins updated and
+// others not: either every candidate validates and is (if it implements
+// Reloader) reloaded, or none of them are touched.
+//
+// Candidates whose Instance implements neither Validator nor Reloader
+// are ignored. Candidates whose Instance implements Validator but not
+// Reloader are validated but otherwise left alone; this is useful for a
+// dry-run check of a configuration that requires a restart to apply.
+func Stage(ctx context.Context, candidates []Candidate) error {
+	for _, c := range candidates {
+		v, ok := c.Instance.(Validator)
+		if !ok {
+			continue
+		}
+		if err := v.ValidateConfig(c.Config); err != nil {
+			return fmt.Errorf("validate config for %s: %w", c.ID, err)
+		}
+	}
+
+	for _, c := range candidates {
+		r, ok := c.Instance.(Reloader)
+		if !ok {
+			continue
+		}
+		if err := r.ReloadConfig(ctx, c.Config); err != nil {
+			return fmt.Errorf("reload config for %s: %w", c.ID, err)
+		}
+	}
+
+	return nil
+}
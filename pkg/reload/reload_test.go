@@ -0,0 +1,121 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package reload
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type fakePlugin struct {
+	validateErr error
+	reloadErr   error
+	validated   interface{}
+	reloaded    interface{}
+}
+
+func (f *fakePlugin) ValidateConfig(config interface{}) error {
+	f.validated = config
+	return f.validateErr
+}
+
+func (f *fakePlugin) ReloadConfig(ctx context.Context, config interface{}) error {
+	f.reloaded = config
+	return f.reloadErr
+}
+
+type reloadOnlyPlugin struct {
+	reloaded interface{}
+}
+
+func (r *reloadOnlyPlugin) ReloadConfig(ctx context.Context, config interface{}) error {
+	r.reloaded = config
+	return nil
+}
+
+func TestStageAppliesAllWhenAllValidate(t *testing.T) {
+	a := &fakePlugin{}
+	b := &fakePlugin{}
+
+	err := Stage(context.Background(), []Candidate{
+		{ID: "a", Instance: a, Config: "config-a"},
+		{ID: "b", Instance: b, Config: "config-b"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.reloaded != "config-a" || b.reloaded != "config-b" {
+		t.Fatalf("expected both plugins to be reloaded, got a=%v b=%v", a.reloaded, b.reloaded)
+	}
+}
+
+func TestStageAppliesNoneWhenOneFailsValidation(t *testing.T) {
+	a := &fakePlugin{}
+	b := &fakePlugin{validateErr: errors.New("rejected")}
+
+	err := Stage(context.Background(), []Candidate{
+		{ID: "a", Instance: a, Config: "config-a"},
+		{ID: "b", Instance: b, Config: "config-b"},
+	})
+	if err == nil {
+		t.Fatal("expected an error when one candidate fails validation")
+	}
+	if a.reloaded != nil {
+		t.Fatalf("expected a not to be reloaded when b rejects its config, got %v", a.reloaded)
+	}
+}
+
+func TestStageIgnoresPluginsWithNeitherInterface(t *testing.T) {
+	err := Stage(context.Background(), []Candidate{
+		{ID: "plain", Instance: struct{}{}, Config: "whatever"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestStageValidatesBeforeReloadingEvenWithoutReloader(t *testing.T) {
+	validateOnly := &struct {
+		fakePlugin
+	}{}
+	err := Stage(context.Background(), []Candidate{
+		{ID: "validate-only", Instance: validateOnly, Config: "config"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if validateOnly.validated != "config" {
+		t.Fatal("expected ValidateConfig to be called")
+	}
+}
+
+func TestStagePropagatesReloadError(t *testing.T) {
+	r := &reloadOnlyPlugin{}
+	failing := &fakePlugin{reloadErr: errors.New("boom")}
+
+	err := Stage(context.Background(), []Candidate{
+		{ID: "r", Instance: r, Config: "config-r"},
+		{ID: "failing", Instance: failing, Config: "config-failing"},
+	})
+	if err == nil {
+		t.Fatal("expected an error from the failing ReloadConfig")
+	}
+	if r.reloaded != "config-r" {
+		t.Fatal("expected the candidate before the failing one to have been reloaded")
+	}
+}
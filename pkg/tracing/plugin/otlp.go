@@ -19,7 +19,6 @@ package plugin
 import (
 	"context"
 	"fmt"
-	"io"
 	"os"
 	"strconv"
 	"time"
@@ -29,8 +28,10 @@ import (
 	"github.com/containerd/containerd/v2/plugins"
 	"github.com/containerd/containerd/v2/plugins/services/warning"
 	"github.com/containerd/errdefs"
+	"github.com/containerd/otelttrpc"
 	"github.com/containerd/plugin"
 	"github.com/containerd/plugin/registry"
+	"github.com/containerd/ttrpc"
 	"github.com/sirupsen/logrus"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
@@ -152,6 +153,22 @@ func (f closerFunc) Close() error {
 	return f()
 }
 
+// tracerInstance is the "tracing" plugin's instance. Besides the io.Closer
+// every InternalPlugin is expected to provide, it also implements
+// pkg/shim.TTRPCServerUnaryOptioner so that a shim loading this plugin
+// (see cmd/containerd-shim-runc-v2/main_tracing.go) automatically extracts
+// the trace context containerd injected via otelttrpc's client interceptor
+// (core/runtime/v2/shim.go) and starts a child span for each task request,
+// continuing the same trace across the ttrpc boundary instead of starting
+// a disconnected one.
+type tracerInstance struct {
+	closerFunc
+}
+
+func (tracerInstance) UnaryServerInterceptor() ttrpc.UnaryServerInterceptor {
+	return otelttrpc.UnaryServerInterceptor()
+}
+
 // newExporter creates an exporter based on the given configuration.
 //
 // The default protocol is http/protobuf since it is recommended by
@@ -181,7 +198,7 @@ func newExporter(ctx context.Context) (*otlptrace.Exporter, error) {
 // its sampling ratio and returns io.Closer.
 //
 // Note that this function sets process-wide tracing configuration.
-func newTracer(ctx context.Context, procs []trace.SpanProcessor) (io.Closer, error) {
+func newTracer(ctx context.Context, procs []trace.SpanProcessor) (tracerInstance, error) {
 	// Let otel configure the service name from env
 	if os.Getenv(otelServiceNameEnv) == "" {
 		os.Setenv(otelServiceNameEnv, "containerd")
@@ -196,9 +213,9 @@ func newTracer(ctx context.Context, procs []trace.SpanProcessor) (io.Closer, err
 	provider := trace.NewTracerProvider(opts...)
 	otel.SetTracerProvider(provider)
 
-	return closerFunc(func() error {
+	return tracerInstance{closerFunc(func() error {
 		return provider.Shutdown(ctx)
-	}), nil
+	})}, nil
 
 }
 
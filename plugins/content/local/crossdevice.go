@@ -0,0 +1,46 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// commitIngest moves the completed ingest file at ingest into its final
+// content-addressed location at target. The common case is a same-filesystem
+// rename. If the ingest directory was configured (via WithIngestDir) to live
+// on a different filesystem than the blob store, rename fails with EXDEV; in
+// that case commitIngest falls back to copying the data into place (using a
+// reflink where the target filesystem supports one) and then removes the
+// ingest file.
+func commitIngest(ingest, target string) error {
+	err := os.Rename(ingest, target)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+
+	if err := reflinkOrCopyFile(target, ingest); err != nil {
+		return fmt.Errorf("cross-device commit from %s to %s: %w", ingest, target, err)
+	}
+	return os.Remove(ingest)
+}
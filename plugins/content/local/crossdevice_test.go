@@ -0,0 +1,112 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containerd/containerd/v2/core/content"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// TestWithIngestDirStagesSeparately verifies that WithIngestDir causes
+// in-progress ingests to be staged under the configured directory rather
+// than under root, while committed blobs still land under root/blobs as
+// usual.
+func TestWithIngestDirStagesSeparately(t *testing.T) {
+	root := t.TempDir()
+	ingestDir := t.TempDir()
+
+	cs, err := NewLabeledStore(root, nil, WithIngestDir(ingestDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	data := []byte("hello from a separate ingest disk")
+	dgst := digest.FromBytes(data)
+
+	desc := ocispec.Descriptor{Digest: dgst, Size: int64(len(data))}
+	w, err := cs.Writer(ctx, content.WithRef("separate-ingest-dir"), content.WithDescriptor(desc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(ingestDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected exactly 1 in-progress ingest under %s, got %d", ingestDir, len(entries))
+	}
+
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Commit(ctx, int64(len(data)), dgst); err != nil {
+		t.Fatal(err)
+	}
+
+	blobPath := filepath.Join(root, "blobs", dgst.Algorithm().String(), dgst.Encoded())
+	got, err := os.ReadFile(blobPath)
+	if err != nil {
+		t.Fatalf("expected committed blob at %s: %v", blobPath, err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatalf("committed blob content mismatch: got %q, want %q", got, data)
+	}
+
+	remaining, err := os.ReadDir(ingestDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 0 {
+		t.Fatalf("expected ingest directory to be cleaned up after commit, found %d entries", len(remaining))
+	}
+}
+
+// TestReflinkOrCopyFile verifies that reflinkOrCopyFile (the fallback used by
+// commitIngest when a rename crosses a filesystem boundary) faithfully
+// reproduces the source content, regardless of whether the platform/
+// filesystem combination in use actually supports a copy-on-write reflink.
+func TestReflinkOrCopyFile(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "source")
+	target := filepath.Join(dir, "target")
+
+	data := bytes.Repeat([]byte("reflink-or-copy-me "), 4096)
+	if err := os.WriteFile(source, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := reflinkOrCopyFile(target, source); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatalf("copied content mismatch: got %d bytes, want %d bytes", len(got), len(data))
+	}
+}
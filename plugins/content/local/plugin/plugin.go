@@ -17,6 +17,8 @@
 package plugin
 
 import (
+	"errors"
+
 	"github.com/containerd/plugin"
 	"github.com/containerd/plugin/registry"
 
@@ -24,14 +26,36 @@ import (
 	"github.com/containerd/containerd/v2/plugins/content/local"
 )
 
+// Config represents configuration for the content plugin.
+type Config struct {
+	// IngestDir is the directory used to stage ingests (in-progress
+	// writes) separately from the content store's root. Leave empty to
+	// stage ingests under the root directory, as before. Setting this to
+	// a directory on faster storage (e.g. local NVMe) can speed up pulls
+	// on nodes where the root directory lives on slower persistent disk.
+	IngestDir string `toml:"ingest_dir"`
+}
+
 func init() {
 	registry.Register(&plugin.Registration{
-		Type: plugins.ContentPlugin,
-		ID:   "content",
+		Type:   plugins.ContentPlugin,
+		ID:     "content",
+		Config: &Config{},
 		InitFn: func(ic *plugin.InitContext) (interface{}, error) {
 			root := ic.Properties[plugins.PropertyRootDir]
 			ic.Meta.Exports["root"] = root
-			return local.NewStore(root)
+
+			config, ok := ic.Config.(*Config)
+			if !ok {
+				return nil, errors.New("invalid content store configuration")
+			}
+
+			var opts []local.StoreOpt
+			if config.IngestDir != "" {
+				opts = append(opts, local.WithIngestDir(config.IngestDir))
+			}
+
+			return local.NewStore(root, opts...)
 		},
 	})
 }
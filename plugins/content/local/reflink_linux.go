@@ -0,0 +1,59 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// reflinkOrCopyFile copies source to target, attempting a copy-on-write
+// reflink (FICLONE) first. A rename across the ingest/blob split can fail
+// with EXDEV even when both paths resolve to the same underlying
+// filesystem (e.g. separate bind mounts or btrfs subvolumes), in which case
+// FICLONE still succeeds and avoids actually duplicating the data on disk.
+// If the filesystem doesn't support reflink (or the paths really are on
+// different filesystems), it falls back to a plain byte-for-byte copy.
+func reflinkOrCopyFile(target, source string) error {
+	src, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	if err := unix.IoctlFileClone(int(dst.Fd()), int(src.Fd())); err == nil {
+		return nil
+	}
+
+	if _, err := dst.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if err := dst.Truncate(0); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(dst, src)
+	return err
+}
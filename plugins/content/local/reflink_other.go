@@ -0,0 +1,30 @@
+//go:build !linux
+
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"github.com/containerd/continuity/fs"
+)
+
+// reflinkOrCopyFile copies source to target using the most efficient means
+// available on the platform (e.g. clonefile on Darwin), falling back to a
+// plain copy elsewhere.
+func reflinkOrCopyFile(target, source string) error {
+	return fs.CopyFile(target, source)
+}
@@ -18,6 +18,7 @@ package local
 
 import (
 	"context"
+	_ "crypto/sha512" // Ensure sha384, sha512 in addition to sha256 are available
 	"fmt"
 	"io"
 	"os"
@@ -65,6 +66,7 @@ type LabelStore interface {
 // including resumable ingest.
 type store struct {
 	root               string
+	ingestDir          string
 	ls                 LabelStore
 	integritySupported bool
 
@@ -73,9 +75,29 @@ type store struct {
 	ensureIngestRootOnce func() error
 }
 
+// StoreOpt is used to configure an optional behavior of a local content store.
+type StoreOpt func(*store) error
+
+// WithIngestDir sets the directory used to stage ingests (in-progress
+// writes) separately from root, the directory holding the final,
+// content-addressed blobs. This is useful for keeping scratch/ingest I/O,
+// which is write-heavy and short-lived, on a faster disk than the one used
+// for durable blob storage.
+//
+// Since the ingest directory and the blob directory may then live on
+// different filesystems, Commit falls back to a copy (reflink, where the
+// underlying filesystem supports it) when the final rename would otherwise
+// fail with a cross-device link error.
+func WithIngestDir(dir string) StoreOpt {
+	return func(s *store) error {
+		s.ingestDir = dir
+		return nil
+	}
+}
+
 // NewStore returns a local content store
-func NewStore(root string) (content.Store, error) {
-	return NewLabeledStore(root, nil)
+func NewStore(root string, opts ...StoreOpt) (content.Store, error) {
+	return NewLabeledStore(root, nil, opts...)
 }
 
 // NewLabeledStore returns a new content store using the provided label store
@@ -83,7 +105,7 @@ func NewStore(root string) (content.Store, error) {
 // Note: content stores which are used underneath a metadata store may not
 // require labels and should use `NewStore`. `NewLabeledStore` is primarily
 // useful for tests or standalone implementations.
-func NewLabeledStore(root string, ls LabelStore) (content.Store, error) {
+func NewLabeledStore(root string, ls LabelStore, opts ...StoreOpt) (content.Store, error) {
 	supported, _ := fsverity.IsSupported(root)
 
 	s := &store{
@@ -92,6 +114,11 @@ func NewLabeledStore(root string, ls LabelStore) (content.Store, error) {
 		integritySupported: supported,
 		locks:              map[string]*lock{},
 	}
+	for _, opt := range opts {
+		if err := opt(s); err != nil {
+			return nil, err
+		}
+	}
 	s.ensureIngestRootOnce = sync.OnceValue(s.ensureIngestRoot)
 	return s, nil
 }
@@ -533,8 +560,16 @@ func (s *store) writer(ctx context.Context, ref string, total int64, expected di
 
 	path, refp, data := s.ingestPaths(ref)
 
+	// Hash with expected's algorithm if one was given, so a push or pull of
+	// e.g. a sha512 blob is verified against a matching sha512 of the
+	// content actually written, not always against sha256.
+	digestAlgorithm := digest.Canonical
+	if expected != "" && expected.Algorithm().Available() {
+		digestAlgorithm = expected.Algorithm()
+	}
+
 	var (
-		digester  = digest.Canonical.Digester()
+		digester  = digestAlgorithm.Digester()
 		offset    int64
 		startedAt time.Time
 		updatedAt time.Time
@@ -638,7 +673,17 @@ func (s *store) ingestRoot(ref string) string {
 	// we take a digest of the ref to keep the ingest paths constant length.
 	// Note that this is not the current or potential digest of incoming content.
 	dgst := digest.FromString(ref)
-	return filepath.Join(s.root, "ingest", dgst.Encoded())
+	return filepath.Join(s.ingestBase(), dgst.Encoded())
+}
+
+// ingestBase returns the directory under which in-progress ingests are
+// staged: s.ingestDir if one was configured via WithIngestDir, otherwise the
+// "ingest" subdirectory of root, as before.
+func (s *store) ingestBase() string {
+	if s.ingestDir != "" {
+		return s.ingestDir
+	}
+	return filepath.Join(s.root, "ingest")
 }
 
 // ingestPaths are returned. The paths are the following:
@@ -657,7 +702,7 @@ func (s *store) ingestPaths(ref string) (string, string, string) {
 }
 
 func (s *store) ensureIngestRoot() error {
-	return os.MkdirAll(filepath.Join(s.root, "ingest"), 0777)
+	return os.MkdirAll(s.ingestBase(), 0777)
 }
 
 func readFileString(path string) (string, error) {
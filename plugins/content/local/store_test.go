@@ -209,6 +209,46 @@ func TestContentWriter(t *testing.T) {
 
 }
 
+func TestContentWriterSHA512(t *testing.T) {
+	ctx, _, cs, cleanup := contentStoreEnv(t)
+	defer cleanup()
+
+	p := make([]byte, 4<<10)
+	if _, err := rand.Read(p); err != nil {
+		t.Fatal(err)
+	}
+	expected := digest.SHA512.FromBytes(p)
+
+	cw, err := cs.Writer(ctx, content.WithRef("sha512ref"), content.WithDescriptor(ocispec.Descriptor{
+		Size:   int64(len(p)),
+		Digest: expected,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkCopy(t, int64(len(p)), cw, bufio.NewReader(io.NopCloser(bytes.NewReader(p))))
+
+	if err := cw.Commit(ctx, int64(len(p)), expected); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	path := checkBlobPath(t, cs, expected)
+
+	pp, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(p, pp) {
+		t.Fatal("mismatched data written to disk")
+	}
+}
+
 func TestWalkBlobs(t *testing.T) {
 	ctx, _, cs, cleanup := contentStoreEnv(t)
 	defer cleanup()
@@ -135,7 +135,7 @@ func (w *writer) Commit(ctx context.Context, size int64, expected digest.Digest,
 		return fmt.Errorf("content %v: %w", dgst, errdefs.ErrAlreadyExists)
 	}
 
-	if err := os.Rename(ingest, target); err != nil {
+	if err := commitIngest(ingest, target); err != nil {
 		return err
 	}
 
@@ -254,6 +254,7 @@ func configMigration(ctx context.Context, configVersion int, pluginConfigs map[s
 		"stream_idle_timeout",
 		"enable_tls_streaming",
 		"x509_key_pair_streaming",
+		"max_streaming_connections_per_container",
 	} {
 		if val, ok := src[k]; ok {
 			dst[k] = val
@@ -28,6 +28,7 @@ import (
 	criconfig "github.com/containerd/containerd/v2/internal/cri/config"
 	"github.com/containerd/containerd/v2/internal/cri/constants"
 	"github.com/containerd/containerd/v2/internal/cri/server/images"
+	"github.com/containerd/containerd/v2/pkg/imageverifier"
 	"github.com/containerd/containerd/v2/plugins"
 	"github.com/containerd/containerd/v2/plugins/services/warning"
 	"github.com/containerd/containerd/v2/version"
@@ -35,6 +36,7 @@ import (
 	"github.com/containerd/platforms"
 	"github.com/containerd/plugin"
 	"github.com/containerd/plugin/registry"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 func init() {
@@ -45,6 +47,7 @@ func init() {
 		ID:     "images",
 		Config: &config,
 		Requires: []plugin.Type{
+			plugins.ImageVerifierPlugin,
 			plugins.LeasePlugin,
 			plugins.MetadataPlugin,
 			plugins.SandboxStorePlugin,
@@ -90,6 +93,20 @@ func init() {
 				Transferrer:      ts.(transfer.Transferrer),
 			}
 
+			vps, err := ic.GetByType(plugins.ImageVerifierPlugin)
+			if err != nil {
+				return nil, err
+			}
+			if len(vps) > 0 {
+				options.VerifierPolicy.Verifiers = make(map[string]imageverifier.ImageVerifier)
+				for name, vp := range vps {
+					options.VerifierPolicy.Verifiers[name] = vp.(imageverifier.ImageVerifier)
+				}
+			}
+			if len(config.VerifierNamespaces) > 0 {
+				options.VerifierPolicy.Namespaces = config.VerifierNamespaces
+			}
+
 			ctrdCli, err := containerd.New(
 				"",
 				containerd.WithDefaultNamespace(constants.K8sContainerdNamespace),
@@ -144,9 +161,20 @@ func init() {
 					platform = p
 				}
 
+				var fallbacks []ocispec.Platform
+				for _, f := range rp.Fallbacks {
+					p, err := platforms.Parse(f)
+					if err != nil {
+						return nil, fmt.Errorf("unable to parse fallback platform %q for runtime %q: %w", f, runtimeName, err)
+					}
+					fallbacks = append(fallbacks, p)
+				}
+
 				options.RuntimePlatforms[runtimeName] = images.ImagePlatform{
 					Snapshotter: snapshotter,
 					Platform:    platform,
+					Fallbacks:   fallbacks,
+					Strict:      rp.Strict,
 				}
 			}
 
@@ -23,8 +23,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
+	"github.com/containerd/continuity/fs"
 	"github.com/containerd/errdefs"
 	"github.com/containerd/log"
 	digest "github.com/opencontainers/go-digest"
@@ -97,110 +99,140 @@ func (s *walkingDiff) Compare(ctx context.Context, lower, upper []mount.Mount, o
 		}
 	}
 
+	// If upper is the single overlay mount of an active snapshot, its
+	// upperdir can be diffed against lower directly: a single walk of
+	// upperdir using DiffDirChanges, rather than mounting upper and
+	// double-walking both lower and upper with fs.Changes.
+	upperdir, fastPath := overlayUpperdir(upper)
+
 	var ocidesc ocispec.Descriptor
-	if err := mount.WithTempMount(ctx, lower, func(lowerRoot string) error {
-		return mount.WithReadonlyTempMount(ctx, upper, func(upperRoot string) error {
-			var newReference bool
-			if config.Reference == "" {
-				newReference = true
-				config.Reference = uniqueRef()
-			}
+	compare := func(lowerRoot, upperRoot string) error {
+		var newReference bool
+		if config.Reference == "" {
+			newReference = true
+			config.Reference = uniqueRef()
+		}
 
-			cw, err := s.store.Writer(ctx,
-				content.WithRef(config.Reference),
-				content.WithDescriptor(ocispec.Descriptor{
-					MediaType: config.MediaType, // most contentstore implementations just ignore this
-				}))
-			if err != nil {
-				return fmt.Errorf("failed to open writer: %w", err)
-			}
+		cw, err := s.store.Writer(ctx,
+			content.WithRef(config.Reference),
+			content.WithDescriptor(ocispec.Descriptor{
+				MediaType: config.MediaType, // most contentstore implementations just ignore this
+			}))
+		if err != nil {
+			return fmt.Errorf("failed to open writer: %w", err)
+		}
 
-			// errOpen is set when an error occurs while the content writer has not been
-			// committed or closed yet to force a cleanup
-			var errOpen error
-			defer func() {
-				if errOpen != nil {
-					cw.Close()
-					if newReference {
-						if abortErr := s.store.Abort(ctx, config.Reference); abortErr != nil {
-							log.G(ctx).WithError(abortErr).WithField("ref", config.Reference).Warnf("failed to delete diff upload")
-						}
+		// errOpen is set when an error occurs while the content writer has not been
+		// committed or closed yet to force a cleanup
+		var errOpen error
+		defer func() {
+			if errOpen != nil {
+				cw.Close()
+				if newReference {
+					if abortErr := s.store.Abort(ctx, config.Reference); abortErr != nil {
+						log.G(ctx).WithError(abortErr).WithField("ref", config.Reference).Warnf("failed to delete diff upload")
 					}
 				}
-			}()
-			if !newReference {
-				if errOpen = cw.Truncate(0); errOpen != nil {
-					return errOpen
-				}
 			}
+		}()
+		if !newReference {
+			if errOpen = cw.Truncate(0); errOpen != nil {
+				return errOpen
+			}
+		}
 
-			if compressionType != compression.Uncompressed {
-				dgstr := digest.SHA256.Digester()
-				var compressed io.WriteCloser
-				if config.Compressor != nil {
-					compressed, errOpen = config.Compressor(cw, config.MediaType)
-					if errOpen != nil {
-						return fmt.Errorf("failed to get compressed stream: %w", errOpen)
-					}
-				} else {
-					compressed, errOpen = compression.CompressStream(cw, compressionType)
-					if errOpen != nil {
-						return fmt.Errorf("failed to get compressed stream: %w", errOpen)
-					}
-				}
-				errOpen = archive.WriteDiff(ctx, io.MultiWriter(compressed, dgstr.Hash()), lowerRoot, upperRoot, writeDiffOpts...)
-				compressed.Close()
-				if errOpen != nil {
-					return fmt.Errorf("failed to write compressed diff: %w", errOpen)
-				}
+		writeDiff := archive.WriteDiff
+		if fastPath {
+			writeDiff = writeOverlayUpperdirDiff
+		}
 
-				if config.Labels == nil {
-					config.Labels = map[string]string{}
+		if compressionType != compression.Uncompressed {
+			dgstr := digest.SHA256.Digester()
+			var compressed io.WriteCloser
+			if config.Compressor != nil {
+				compressed, errOpen = config.Compressor(cw, config.MediaType)
+				if errOpen != nil {
+					return fmt.Errorf("failed to get compressed stream: %w", errOpen)
 				}
-				config.Labels[labels.LabelUncompressed] = dgstr.Digest().String()
 			} else {
-				if errOpen = archive.WriteDiff(ctx, cw, lowerRoot, upperRoot, writeDiffOpts...); errOpen != nil {
-					return fmt.Errorf("failed to write diff: %w", errOpen)
+				var compressOpts []compression.CompressOpt
+				if config.CompressionLevel != 0 {
+					compressOpts = append(compressOpts, compression.WithZstdLevel(config.CompressionLevel))
 				}
-			}
-
-			var commitopts []content.Opt
-			if config.Labels != nil {
-				commitopts = append(commitopts, content.WithLabels(config.Labels))
-			}
-
-			dgst := cw.Digest()
-			if errOpen = cw.Commit(ctx, 0, dgst, commitopts...); errOpen != nil {
-				if !errdefs.IsAlreadyExists(errOpen) {
-					return fmt.Errorf("failed to commit: %w", errOpen)
+				if config.CompressionConcurrency != 0 {
+					compressOpts = append(compressOpts, compression.WithZstdConcurrency(config.CompressionConcurrency))
 				}
-				errOpen = nil
+				compressed, errOpen = compression.CompressStream(cw, compressionType, compressOpts...)
+				if errOpen != nil {
+					return fmt.Errorf("failed to get compressed stream: %w", errOpen)
+				}
+			}
+			errOpen = writeDiff(ctx, io.MultiWriter(compressed, dgstr.Hash()), lowerRoot, upperRoot, writeDiffOpts...)
+			compressed.Close()
+			if errOpen != nil {
+				return fmt.Errorf("failed to write compressed diff: %w", errOpen)
 			}
 
-			info, err := s.store.Info(ctx, dgst)
-			if err != nil {
-				return fmt.Errorf("failed to get info from content store: %w", err)
+			if config.Labels == nil {
+				config.Labels = map[string]string{}
 			}
-			if info.Labels == nil {
-				info.Labels = make(map[string]string)
+			config.Labels[labels.LabelUncompressed] = dgstr.Digest().String()
+		} else {
+			if errOpen = writeDiff(ctx, cw, lowerRoot, upperRoot, writeDiffOpts...); errOpen != nil {
+				return fmt.Errorf("failed to write diff: %w", errOpen)
 			}
-			// Set "containerd.io/uncompressed" label if digest already existed without label
-			if _, ok := info.Labels[labels.LabelUncompressed]; !ok {
-				info.Labels[labels.LabelUncompressed] = config.Labels[labels.LabelUncompressed]
-				if _, err := s.store.Update(ctx, info, "labels."+labels.LabelUncompressed); err != nil {
-					return fmt.Errorf("error setting uncompressed label: %w", err)
-				}
+		}
+
+		var commitopts []content.Opt
+		if config.Labels != nil {
+			commitopts = append(commitopts, content.WithLabels(config.Labels))
+		}
+
+		dgst := cw.Digest()
+		if errOpen = cw.Commit(ctx, 0, dgst, commitopts...); errOpen != nil {
+			if !errdefs.IsAlreadyExists(errOpen) {
+				return fmt.Errorf("failed to commit: %w", errOpen)
 			}
+			errOpen = nil
+		}
 
-			ocidesc = ocispec.Descriptor{
-				MediaType: config.MediaType,
-				Size:      info.Size,
-				Digest:    info.Digest,
+		info, err := s.store.Info(ctx, dgst)
+		if err != nil {
+			return fmt.Errorf("failed to get info from content store: %w", err)
+		}
+		if info.Labels == nil {
+			info.Labels = make(map[string]string)
+		}
+		// Set "containerd.io/uncompressed" label if digest already existed without label
+		if _, ok := info.Labels[labels.LabelUncompressed]; !ok {
+			info.Labels[labels.LabelUncompressed] = config.Labels[labels.LabelUncompressed]
+			if _, err := s.store.Update(ctx, info, "labels."+labels.LabelUncompressed); err != nil {
+				return fmt.Errorf("error setting uncompressed label: %w", err)
 			}
-			return nil
+		}
+
+		ocidesc = ocispec.Descriptor{
+			MediaType: config.MediaType,
+			Size:      info.Size,
+			Digest:    info.Digest,
+		}
+		return nil
+	}
+
+	var mountErr error
+	if fastPath {
+		mountErr = mount.WithTempMount(ctx, lower, func(lowerRoot string) error {
+			return compare(lowerRoot, upperdir)
+		})
+	} else {
+		mountErr = mount.WithTempMount(ctx, lower, func(lowerRoot string) error {
+			return mount.WithReadonlyTempMount(ctx, upper, func(upperRoot string) error {
+				return compare(lowerRoot, upperRoot)
+			})
 		})
-	}); err != nil {
-		return emptyDesc, err
+	}
+	if mountErr != nil {
+		return emptyDesc, mountErr
 	}
 
 	return ocidesc, nil
@@ -213,3 +245,43 @@ func uniqueRef() string {
 	rand.Read(b[:])
 	return fmt.Sprintf("%d-%s", t.UnixNano(), base64.URLEncoding.EncodeToString(b[:]))
 }
+
+// overlayUpperdir returns the upperdir of upper and true, if upper is
+// exactly one overlay mount with an upperdir option set, as returned by the
+// overlayfs snapshotter for an active snapshot with at least one parent.
+// Otherwise it returns false, and the caller should mount upper as usual.
+func overlayUpperdir(upper []mount.Mount) (string, bool) {
+	if len(upper) != 1 || upper[0].Type != "overlay" {
+		return "", false
+	}
+	for _, o := range upper[0].Options {
+		if dir, ok := strings.CutPrefix(o, "upperdir="); ok {
+			return dir, true
+		}
+	}
+	return "", false
+}
+
+// writeOverlayUpperdirDiff writes a tar stream of the changes in upperRoot,
+// an overlayfs upperdir, against lowerRoot. Unlike archive.WriteDiff, it
+// only walks upperRoot, using the same overlay whiteout/opaque dir handling
+// as the erofs differ's native diff path.
+func writeOverlayUpperdirDiff(ctx context.Context, w io.Writer, lowerRoot, upperRoot string, opts ...archive.WriteDiffOpt) error {
+	var options archive.WriteDiffOptions
+	for _, opt := range opts {
+		if err := opt(&options); err != nil {
+			return fmt.Errorf("failed to apply option: %w", err)
+		}
+	}
+
+	var cwOpts []archive.ChangeWriterOpt
+	if options.SourceDateEpoch != nil {
+		cwOpts = append(cwOpts, archive.WithModTimeUpperBound(*options.SourceDateEpoch))
+	}
+
+	cw := archive.NewChangeWriter(w, upperRoot, cwOpts...)
+	if err := fs.DiffDirChanges(ctx, lowerRoot, upperRoot, fs.DiffSourceOverlayFS, cw.HandleChange); err != nil {
+		return fmt.Errorf("failed to create diff tar stream: %w", err)
+	}
+	return cw.Close()
+}
@@ -0,0 +1,156 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package walking
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containerd/containerd/v2/core/mount"
+	local "github.com/containerd/containerd/v2/plugins/content/local"
+	digest "github.com/opencontainers/go-digest"
+)
+
+func TestOverlayUpperdir(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		upper    []mount.Mount
+		expected string
+		ok       bool
+	}{
+		{
+			name: "overlay with upperdir",
+			upper: []mount.Mount{
+				{Type: "overlay", Source: "overlay", Options: []string{"workdir=/w", "upperdir=/u", "lowerdir=/l"}},
+			},
+			expected: "/u",
+			ok:       true,
+		},
+		{
+			name: "bind mount, no parent",
+			upper: []mount.Mount{
+				{Type: "bind", Source: "/u", Options: []string{"rw", "rbind"}},
+			},
+			ok: false,
+		},
+		{
+			name: "overlay without upperdir (read-only view)",
+			upper: []mount.Mount{
+				{Type: "overlay", Source: "overlay", Options: []string{"lowerdir=/l"}},
+			},
+			ok: false,
+		},
+		{
+			name:  "multiple mounts",
+			upper: []mount.Mount{{Type: "overlay", Options: []string{"upperdir=/u"}}, {Type: "bind"}},
+			ok:    false,
+		},
+		{
+			name:  "empty",
+			upper: nil,
+			ok:    false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, ok := overlayUpperdir(tc.upper)
+			if ok != tc.ok {
+				t.Fatalf("expected ok=%v, got %v", tc.ok, ok)
+			}
+			if dir != tc.expected {
+				t.Fatalf("expected dir=%q, got %q", tc.expected, dir)
+			}
+		})
+	}
+}
+
+// TestCompareOverlayFastPath verifies that Compare takes the overlay
+// upperdir fast path when given an overlay mount with an upperdir option,
+// reading the directory in place instead of mounting it.
+func TestCompareOverlayFastPath(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := local.NewLabeledStore(t.TempDir(), newTestLabelStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lowerDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(lowerDir, "unchanged.txt"), []byte("same"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// upperDir is used directly as the overlayfs upperdir, without ever
+	// being mounted: it holds the changed file plus a whiteout for a file
+	// removed from lowerDir.
+	upperDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(upperDir, "added.txt"), []byte("new"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	lower := []mount.Mount{{Type: "bind", Source: lowerDir, Options: []string{"ro", "rbind"}}}
+	upper := []mount.Mount{
+		{
+			Type:    "overlay",
+			Source:  "overlay",
+			Options: []string{"upperdir=" + upperDir, "lowerdir=" + lowerDir},
+		},
+	}
+
+	d := NewWalkingDiff(store)
+	desc, err := d.Compare(ctx, lower, upper)
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	if desc.Digest == "" {
+		t.Fatal("expected a non-empty digest")
+	}
+}
+
+type testLabelStore struct {
+	labels map[digest.Digest]map[string]string
+}
+
+func newTestLabelStore() *testLabelStore {
+	return &testLabelStore{labels: map[digest.Digest]map[string]string{}}
+}
+
+func (s *testLabelStore) Get(d digest.Digest) (map[string]string, error) {
+	return s.labels[d], nil
+}
+
+func (s *testLabelStore) Set(d digest.Digest, labels map[string]string) error {
+	s.labels[d] = labels
+	return nil
+}
+
+func (s *testLabelStore) Update(d digest.Digest, update map[string]string) (map[string]string, error) {
+	labels, ok := s.labels[d]
+	if !ok {
+		labels = map[string]string{}
+	}
+	for k, v := range update {
+		if v == "" {
+			delete(labels, k)
+			continue
+		}
+		labels[k] = v
+	}
+	s.labels[d] = labels
+	return labels, nil
+}
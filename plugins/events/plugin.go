@@ -17,18 +17,77 @@
 package events
 
 import (
+	"os"
+	"path/filepath"
+	"time"
+
 	"github.com/containerd/containerd/v2/core/events/exchange"
 	"github.com/containerd/containerd/v2/plugins"
 	"github.com/containerd/plugin"
 	"github.com/containerd/plugin/registry"
 )
 
+// Config defines the configuration values for the events exchange plugin.
+type Config struct {
+	// JournalMaxEvents bounds how many events the on-disk journal
+	// retains, across all namespaces. Oldest events are pruned first
+	// once this is exceeded. Zero (the default) disables the journal:
+	// subscribers only ever see events published while they're connected,
+	// matching prior behavior.
+	JournalMaxEvents int `toml:"journal_max_events"`
+
+	// JournalRetention bounds how long an event is kept in the journal
+	// regardless of JournalMaxEvents, expressed as a Go duration string
+	// (e.g. "1h", "10m"). Zero means no age-based pruning. Only takes
+	// effect when JournalMaxEvents is also set, since a retention window
+	// with no count bound still needs the journal enabled to do anything.
+	JournalRetention string `toml:"journal_retention"`
+
+	// SubscriberEventsPerSecond bounds how many events per second any
+	// single event subscriber (e.g. one "ctr events" or one monitoring
+	// agent's Subscribe call) is delivered. Events arriving faster than
+	// this are dropped for that subscriber rather than queued, so a slow
+	// or misbehaving subscriber can't build unbounded backlog or slow
+	// down others. Zero (the default) disables rate limiting, matching
+	// prior behavior.
+	SubscriberEventsPerSecond float64 `toml:"subscriber_events_per_second"`
+
+	// SubscriberBurst is the token-bucket burst size paired with
+	// SubscriberEventsPerSecond. Only takes effect when
+	// SubscriberEventsPerSecond is also set.
+	SubscriberBurst int `toml:"subscriber_burst"`
+}
+
 func init() {
 	registry.Register(&plugin.Registration{
-		Type: plugins.EventPlugin,
-		ID:   "exchange",
+		Type:   plugins.EventPlugin,
+		ID:     "exchange",
+		Config: &Config{},
 		InitFn: func(ic *plugin.InitContext) (interface{}, error) {
-			return exchange.NewExchange(), nil
+			var opts []exchange.Opt
+
+			if cfg, ok := ic.Config.(*Config); ok && cfg.JournalMaxEvents > 0 {
+				retention, err := time.ParseDuration(cfg.JournalRetention)
+				if err != nil && cfg.JournalRetention != "" {
+					return nil, err
+				}
+
+				root := ic.Properties[plugins.PropertyRootDir]
+				if err := os.MkdirAll(root, 0711); err != nil {
+					return nil, err
+				}
+
+				opts = append(opts, exchange.WithJournal(filepath.Join(root, "events.db"), exchange.JournalConfig{
+					MaxEvents: cfg.JournalMaxEvents,
+					Retention: retention,
+				}))
+			}
+
+			if cfg, ok := ic.Config.(*Config); ok && cfg.SubscriberEventsPerSecond > 0 {
+				opts = append(opts, exchange.WithSubscriberRateLimit(cfg.SubscriberEventsPerSecond, cfg.SubscriberBurst))
+			}
+
+			return exchange.NewExchange(opts...)
 		},
 	})
 }
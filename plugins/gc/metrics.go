@@ -24,11 +24,25 @@ var (
 
 	// gcTimeHist histogram metrics for duration of gc scheduler collections.
 	gcTimeHist metrics.Timer
+
+	// pausedGauge reports whether scheduled garbage collection is currently
+	// paused (1) or not (0).
+	pausedGauge metrics.Gauge
+
+	// pauseCounter counts completed pause windows by how they ended.
+	pauseCounter metrics.LabeledCounter
+
+	// pauseTimeHist histogram metrics for the duration of completed pause
+	// windows.
+	pauseTimeHist metrics.Timer
 )
 
 func init() {
 	ns := metrics.NewNamespace("containerd", "gc", nil)
 	collectionCounter = ns.NewLabeledCounter("collections", "counter of gc scheduler collections", "status")
 	gcTimeHist = ns.NewTimer("gc", "duration of gc scheduler collections")
+	pausedGauge = ns.NewGauge("paused", "whether scheduled garbage collection is currently paused", metrics.Total)
+	pauseCounter = ns.NewLabeledCounter("pauses", "counter of completed garbage collection pause windows", "reason")
+	pauseTimeHist = ns.NewTimer("pause", "duration of garbage collection pause windows")
 	metrics.Register(ns)
 }
@@ -130,6 +130,13 @@ type mutationEvent struct {
 	dirty    bool
 }
 
+// pauseRequest asks run to pause scheduled garbage collection, optionally
+// bounded by maxDuration. A zero maxDuration pauses until a matching Resume.
+type pauseRequest struct {
+	maxDuration time.Duration
+	resp        chan error
+}
+
 type collector interface {
 	RegisterMutationCallback(func(bool))
 	GarbageCollect(context.Context) (gc.Stats, error)
@@ -138,43 +145,38 @@ type collector interface {
 type gcScheduler struct {
 	c collector
 
-	eventC chan mutationEvent
+	eventC  chan mutationEvent
+	pauseC  chan pauseRequest
+	resumeC chan chan error
 
 	waiterL sync.Mutex
 	waiters []chan gc.Stats
 
+	// policyL guards the fields below, which can be changed while the
+	// scheduler is running via ReloadConfig.
+	policyL           sync.RWMutex
 	pauseThreshold    float64
 	deletionThreshold int
 	mutationThreshold int
 	scheduleDelay     time.Duration
-	startupDelay      time.Duration
+
+	// startupDelay is only read once, before run's loop starts, so it
+	// does not need policyL's protection.
+	startupDelay time.Duration
 }
 
 func newScheduler(c collector, cfg *config) *gcScheduler {
 	eventC := make(chan mutationEvent)
 
 	s := &gcScheduler{
-		c:                 c,
-		eventC:            eventC,
-		pauseThreshold:    cfg.PauseThreshold,
-		deletionThreshold: cfg.DeletionThreshold,
-		mutationThreshold: cfg.MutationThreshold,
-		scheduleDelay:     time.Duration(cfg.ScheduleDelay),
-		startupDelay:      time.Duration(cfg.StartupDelay),
+		c:            c,
+		eventC:       eventC,
+		pauseC:       make(chan pauseRequest),
+		resumeC:      make(chan chan error),
+		startupDelay: time.Duration(cfg.StartupDelay),
 	}
+	s.setPolicy(cfg)
 
-	if s.pauseThreshold < 0.0 {
-		s.pauseThreshold = 0.0
-	}
-	if s.pauseThreshold > 0.5 {
-		s.pauseThreshold = 0.5
-	}
-	if s.mutationThreshold < 0 {
-		s.mutationThreshold = 0
-	}
-	if s.scheduleDelay < 0 {
-		s.scheduleDelay = 0
-	}
 	if s.startupDelay < 0 {
 		s.startupDelay = 0
 	}
@@ -184,6 +186,87 @@ func newScheduler(c collector, cfg *config) *gcScheduler {
 	return s
 }
 
+// setPolicy clamps and applies the reloadable portion of cfg. It is safe
+// to call while run is active.
+func (s *gcScheduler) setPolicy(cfg *config) {
+	pauseThreshold := cfg.PauseThreshold
+	if pauseThreshold < 0.0 {
+		pauseThreshold = 0.0
+	}
+	if pauseThreshold > 0.5 {
+		pauseThreshold = 0.5
+	}
+	deletionThreshold := cfg.DeletionThreshold
+	if deletionThreshold < 0 {
+		deletionThreshold = 0
+	}
+	mutationThreshold := cfg.MutationThreshold
+	if mutationThreshold < 0 {
+		mutationThreshold = 0
+	}
+	scheduleDelay := time.Duration(cfg.ScheduleDelay)
+	if scheduleDelay < 0 {
+		scheduleDelay = 0
+	}
+
+	s.policyL.Lock()
+	s.pauseThreshold = pauseThreshold
+	s.deletionThreshold = deletionThreshold
+	s.mutationThreshold = mutationThreshold
+	s.scheduleDelay = scheduleDelay
+	s.policyL.Unlock()
+}
+
+// policy returns a consistent snapshot of the reloadable scheduling
+// policy fields.
+func (s *gcScheduler) policy() (pauseThreshold float64, deletionThreshold, mutationThreshold int, scheduleDelay time.Duration) {
+	s.policyL.RLock()
+	defer s.policyL.RUnlock()
+	return s.pauseThreshold, s.deletionThreshold, s.mutationThreshold, s.scheduleDelay
+}
+
+// ValidateConfig implements reload.Validator, letting an operator check a
+// proposed garbage collection policy before it is applied.
+func (s *gcScheduler) ValidateConfig(c interface{}) error {
+	cfg, ok := c.(*config)
+	if !ok {
+		return fmt.Errorf("invalid config type %T for gc scheduler, expected %T", c, cfg)
+	}
+	if cfg.PauseThreshold < 0 || cfg.PauseThreshold > 0.5 {
+		return fmt.Errorf("pause_threshold must be between 0 and 0.5, got %v", cfg.PauseThreshold)
+	}
+	if cfg.DeletionThreshold < 0 {
+		return errors.New("deletion_threshold must not be negative")
+	}
+	if cfg.MutationThreshold < 0 {
+		return errors.New("mutation_threshold must not be negative")
+	}
+	if time.Duration(cfg.ScheduleDelay) < 0 {
+		return errors.New("schedule_delay must not be negative")
+	}
+	return nil
+}
+
+// ReloadConfig implements reload.Reloader, applying a new garbage
+// collection policy without restarting the scheduler. StartupDelay is not
+// reloadable: it only affects the initial collection after the daemon
+// starts, so changing it has no further effect once the scheduler is
+// already running.
+func (s *gcScheduler) ReloadConfig(ctx context.Context, c interface{}) error {
+	cfg, ok := c.(*config)
+	if !ok {
+		return fmt.Errorf("invalid config type %T for gc scheduler, expected %T", c, cfg)
+	}
+	s.setPolicy(cfg)
+	log.G(ctx).WithFields(log.Fields{
+		"pauseThreshold":    cfg.PauseThreshold,
+		"deletionThreshold": cfg.DeletionThreshold,
+		"mutationThreshold": cfg.MutationThreshold,
+		"scheduleDelay":     time.Duration(cfg.ScheduleDelay),
+	}).Info("reloaded garbage collection policy")
+	return nil
+}
+
 func (s *gcScheduler) ScheduleAndWait(ctx context.Context) (gc.Stats, error) {
 	return s.wait(ctx, true)
 }
@@ -217,6 +300,49 @@ func (s *gcScheduler) wait(ctx context.Context, trigger bool) (gc.Stats, error)
 	return gcStats, nil
 }
 
+// Pause stops scheduled garbage collection from running until a matching
+// Resume is called, or until maxDuration elapses, whichever comes first.
+// A maxDuration of zero pauses indefinitely, until Resume is called. Pause
+// is meant for latency-sensitive windows or bulk imports: it lets a caller
+// keep a concurrent mark-and-sweep walk from competing with its own work
+// without having to disable the scheduler entirely, and the maxDuration
+// bound keeps a caller that forgets to Resume (or crashes) from pausing
+// collection forever.
+//
+// Calling Pause again while already paused re-arms maxDuration from the
+// current invocation; it does not stack pauses.
+func (s *gcScheduler) Pause(ctx context.Context, maxDuration time.Duration) error {
+	resp := make(chan error, 1)
+	select {
+	case s.pauseC <- pauseRequest{maxDuration: maxDuration, resp: resp}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-resp:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Resume ends a pause started by Pause, immediately re-allowing scheduled
+// garbage collection. It is a no-op if collection is not currently paused.
+func (s *gcScheduler) Resume(ctx context.Context) error {
+	resp := make(chan error, 1)
+	select {
+	case s.resumeC <- resp:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-resp:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func (s *gcScheduler) mutationCallback(dirty bool) {
 	e := mutationEvent{
 		ts:       time.Now(),
@@ -248,18 +374,60 @@ func (s *gcScheduler) run(ctx context.Context) {
 		triggered bool
 		deletions int
 		mutations int
+
+		paused         bool
+		pausedSince    time.Time
+		pauseDeadlineC <-chan time.Time
+		deferredGC     bool
 	)
+	endPause := func(reason string) {
+		paused = false
+		pauseDeadlineC = nil
+		pausedGauge.Set(0)
+		pauseTimeHist.Update(time.Since(pausedSince))
+		pauseCounter.WithValues(reason).Inc()
+		log.G(ctx).WithField("reason", reason).WithField("d", time.Since(pausedSince)).Debug("garbage collection resumed")
+		if deferredGC {
+			deferredGC = false
+			schedC, nextCollection = schedule(0)
+		}
+	}
 	if s.startupDelay > 0 {
 		schedC, nextCollection = schedule(s.startupDelay)
 	}
 	for {
+		pauseThreshold, deletionThreshold, mutationThreshold, scheduleDelay := s.policy()
+
 		select {
+		case req := <-s.pauseC:
+			if !paused {
+				paused = true
+				pausedSince = time.Now()
+				pausedGauge.Set(1)
+				log.G(ctx).WithField("maxDuration", req.maxDuration).Debug("garbage collection paused")
+			}
+			if req.maxDuration > 0 {
+				pauseDeadlineC = time.After(req.maxDuration)
+			} else {
+				pauseDeadlineC = nil
+			}
+			req.resp <- nil
+			continue
+		case resp := <-s.resumeC:
+			if paused {
+				endPause("manual")
+			}
+			resp <- nil
+			continue
+		case <-pauseDeadlineC:
+			endPause("timeout")
+			continue
 		case <-schedC:
 			// Check if garbage collection can be skipped because
 			// it is not needed or was not requested and reschedule
 			// it to attempt again after another time interval.
 			if !triggered && lastCollection != nil && deletions == 0 &&
-				(s.mutationThreshold == 0 || mutations < s.mutationThreshold) {
+				(mutationThreshold == 0 || mutations < mutationThreshold) {
 				schedC, nextCollection = schedule(interval)
 				continue
 			}
@@ -278,13 +446,13 @@ func (s *gcScheduler) run(ctx context.Context) {
 
 			// Check if condition should cause immediate collection.
 			if triggered ||
-				(s.deletionThreshold > 0 && deletions >= s.deletionThreshold) ||
-				(nextCollection == nil && ((s.deletionThreshold == 0 && deletions > 0) ||
-					(s.mutationThreshold > 0 && mutations >= s.mutationThreshold))) {
+				(deletionThreshold > 0 && deletions >= deletionThreshold) ||
+				(nextCollection == nil && ((deletionThreshold == 0 && deletions > 0) ||
+					(mutationThreshold > 0 && mutations >= mutationThreshold))) {
 				// Check if not already scheduled before delay threshold
-				if nextCollection == nil || nextCollection.After(time.Now().Add(s.scheduleDelay)) {
+				if nextCollection == nil || nextCollection.After(time.Now().Add(scheduleDelay)) {
 					// TODO(dmcg): track re-schedules for tuning schedule config
-					schedC, nextCollection = schedule(s.scheduleDelay)
+					schedC, nextCollection = schedule(scheduleDelay)
 				}
 			}
 
@@ -293,6 +461,12 @@ func (s *gcScheduler) run(ctx context.Context) {
 			return
 		}
 
+		if paused {
+			deferredGC = true
+			log.G(ctx).Debug("garbage collection deferred: paused")
+			continue
+		}
+
 		s.waiterL.Lock()
 
 		stats, err := s.c.GarbageCollect(ctx)
@@ -326,7 +500,7 @@ func (s *gcScheduler) run(ctx context.Context) {
 		mutations = 0
 
 		// Calculate new interval with updated times
-		if s.pauseThreshold > 0.0 {
+		if pauseThreshold > 0.0 {
 			// Set interval to average gc time divided by the pause threshold
 			// This algorithm ensures that a gc is scheduled to allow enough
 			// runtime in between gc to reach the pause threshold.
@@ -337,7 +511,7 @@ func (s *gcScheduler) run(ctx context.Context) {
 			if avg < minimumGCTime {
 				avg = minimumGCTime
 			}
-			interval = time.Duration(avg/s.pauseThreshold - avg)
+			interval = time.Duration(avg/pauseThreshold - avg)
 		}
 
 		lastCollection = &last
@@ -181,6 +181,128 @@ func TestStartupDelay(t *testing.T) {
 
 }
 
+func TestValidateConfigRejectsInvalidValues(t *testing.T) {
+	cfg := &config{PauseThreshold: 0.02}
+	tc := &testCollector{d: time.Microsecond}
+	scheduler := newScheduler(tc, cfg)
+
+	for _, bad := range []*config{
+		{PauseThreshold: -0.1},
+		{PauseThreshold: 0.6},
+		{DeletionThreshold: -1},
+		{MutationThreshold: -1},
+		{ScheduleDelay: tomlext.FromStdTime(-time.Second)},
+	} {
+		if err := scheduler.ValidateConfig(bad); err == nil {
+			t.Fatalf("expected config %+v to be rejected", bad)
+		}
+	}
+
+	if err := scheduler.ValidateConfig(&config{PauseThreshold: 0.1, DeletionThreshold: 5}); err != nil {
+		t.Fatalf("expected a valid config to be accepted, got %v", err)
+	}
+}
+
+func TestReloadConfigAppliesNewPolicy(t *testing.T) {
+	cfg := &config{PauseThreshold: 0.02, DeletionThreshold: 5}
+	tc := &testCollector{d: time.Microsecond}
+	scheduler := newScheduler(tc, cfg)
+
+	if err := scheduler.ReloadConfig(context.Background(), &config{PauseThreshold: 0.03, DeletionThreshold: 9}); err != nil {
+		t.Fatalf("unexpected error reloading config: %v", err)
+	}
+
+	pauseThreshold, deletionThreshold, _, _ := scheduler.policy()
+	assert.Equal(t, 0.03, pauseThreshold)
+	assert.Equal(t, 9, deletionThreshold)
+}
+
+func TestPauseDefersScheduledCollection(t *testing.T) {
+	cfg := &config{PauseThreshold: 0.001}
+	tc := &testCollector{d: time.Microsecond}
+	scheduler := newScheduler(tc, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go scheduler.run(ctx)
+
+	if err := scheduler.Pause(ctx, 0); err != nil {
+		t.Fatalf("Pause failed: %v", err)
+	}
+
+	gcWait := make(chan struct{})
+	go func() {
+		scheduler.ScheduleAndWait(ctx)
+		close(gcWait)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	if c := tc.runCount(); c != 0 {
+		t.Fatalf("expected no collection while paused, got %d", c)
+	}
+
+	if err := scheduler.Resume(ctx); err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+
+	select {
+	case <-gcWait:
+	case <-time.After(time.Second):
+		t.Fatal("gc wait timed out after resume")
+	}
+
+	if c := tc.runCount(); c != 1 {
+		t.Fatalf("unexpected gc run count %d, expected 1", c)
+	}
+}
+
+func TestPauseAutoResumesAfterMaxDuration(t *testing.T) {
+	cfg := &config{PauseThreshold: 0.001}
+	tc := &testCollector{d: time.Microsecond}
+	scheduler := newScheduler(tc, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go scheduler.run(ctx)
+
+	if err := scheduler.Pause(ctx, 10*time.Millisecond); err != nil {
+		t.Fatalf("Pause failed: %v", err)
+	}
+
+	gcWait := make(chan struct{})
+	go func() {
+		scheduler.ScheduleAndWait(ctx)
+		close(gcWait)
+	}()
+
+	select {
+	case <-gcWait:
+	case <-time.After(time.Second):
+		t.Fatal("gc wait timed out waiting for auto-resume")
+	}
+
+	if c := tc.runCount(); c != 1 {
+		t.Fatalf("unexpected gc run count %d, expected 1", c)
+	}
+}
+
+func TestResumeWithoutPauseIsNoop(t *testing.T) {
+	cfg := &config{}
+	tc := &testCollector{d: time.Microsecond}
+	scheduler := newScheduler(tc, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go scheduler.run(ctx)
+
+	if err := scheduler.Resume(ctx); err != nil {
+		t.Fatalf("expected Resume to be a no-op when not paused, got %v", err)
+	}
+}
+
 type testCollector struct {
 	d  time.Duration
 	gc int
@@ -24,6 +24,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	api "github.com/containerd/containerd/api/services/tasks/v1"
@@ -73,7 +74,8 @@ var (
 )
 
 const (
-	stateTimeout = "io.containerd.timeout.task.state"
+	stateTimeout   = "io.containerd.timeout.task.state"
+	metricsTimeout = "io.containerd.timeout.task.metrics"
 )
 
 // Config for the tasks service plugin
@@ -94,6 +96,7 @@ func init() {
 	})
 
 	timeout.Set(stateTimeout, 2*time.Second)
+	timeout.Set(metricsTimeout, 5*time.Second)
 }
 
 func initFunc(ic *plugin.InitContext) (interface{}, error) {
@@ -124,11 +127,12 @@ func initFunc(ic *plugin.InitContext) (interface{}, error) {
 
 	db := m.(*metadata.DB)
 	l := &local{
-		containers: metadata.NewContainerStore(db),
-		store:      db.ContentStore(),
-		publisher:  ep.(events.Publisher),
-		monitor:    monitor.(runtime.TaskMonitor),
-		v2Runtime:  v2r.(runtime.PlatformRuntime),
+		containers:       metadata.NewContainerStore(db),
+		store:            db.ContentStore(),
+		publisher:        ep.(events.Publisher),
+		monitor:          monitor.(runtime.TaskMonitor),
+		v2Runtime:        v2r.(runtime.PlatformRuntime),
+		metricsCoalescer: newMetricsCoalescer(),
 	}
 
 	v2Tasks, err := l.v2Runtime.Tasks(ic.Context, true)
@@ -156,6 +160,8 @@ type local struct {
 
 	monitor   runtime.TaskMonitor
 	v2Runtime runtime.PlatformRuntime
+
+	metricsCoalescer *metricsCoalescer
 }
 
 func (l *local) Create(ctx context.Context, r *api.CreateTaskRequest, _ ...grpc.CallOption) (*api.CreateTaskResponse, error) {
@@ -630,13 +636,26 @@ func (l *local) Metrics(ctx context.Context, r *api.MetricsRequest, _ ...grpc.Ca
 	if err != nil {
 		return nil, err
 	}
-	var resp api.MetricsResponse
-	tasks, err := l.v2Runtime.Tasks(ctx, false)
-	if err != nil {
-		return nil, err
-	}
-	getTasksMetrics(ctx, filter, tasks, &resp)
-	return &resp, nil
+	// Requests with the same filters are coalesced, so a burst of pollers
+	// hitting this RPC at nearly the same time shares one collection pass
+	// instead of each re-walking every task's stats on its own. The
+	// collection pass runs under its own detached context rather than
+	// this caller's ctx: whichever caller happens to trigger the shared
+	// call must not be able to cancel or time out the collection out
+	// from under every other caller coalesced onto it.
+	key := strings.Join(r.Filters, ",")
+	return l.metricsCoalescer.do(key, func() (*api.MetricsResponse, error) {
+		dctx, cancel := timeout.WithContext(context.WithoutCancel(ctx), metricsTimeout)
+		defer cancel()
+
+		var resp api.MetricsResponse
+		tasks, err := l.v2Runtime.Tasks(dctx, false)
+		if err != nil {
+			return nil, err
+		}
+		getTasksMetrics(dctx, filter, tasks, &resp)
+		return &resp, nil
+	})
 }
 
 func (l *local) Wait(ctx context.Context, r *api.WaitRequest, _ ...grpc.CallOption) (*api.WaitResponse, error) {
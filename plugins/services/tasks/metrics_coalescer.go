@@ -0,0 +1,76 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tasks
+
+import (
+	"sync"
+
+	api "github.com/containerd/containerd/api/services/tasks/v1"
+)
+
+// metricsCoalescer deduplicates concurrent Metrics calls that share the same
+// filter set. Collectors such as cAdvisor, the CRI stats cache, and a local
+// Prometheus exporter tend to poll the Task service on their own independent
+// schedules, and often land within microseconds of each other; without
+// coalescing, each one pays for its own pass over every task's cgroup/stats
+// files. With it, a burst of identical requests shares a single underlying
+// collection.
+//
+// This does not change the request/response shapes of the Metrics RPC, so it
+// stops short of a true push-based subscription API (clients still poll),
+// but it removes the redundant work a polling storm causes today.
+type metricsCoalescer struct {
+	mu    sync.Mutex
+	calls map[string]*metricsCall
+}
+
+type metricsCall struct {
+	done   chan struct{}
+	result *api.MetricsResponse
+	err    error
+}
+
+func newMetricsCoalescer() *metricsCoalescer {
+	return &metricsCoalescer{
+		calls: make(map[string]*metricsCall),
+	}
+}
+
+// do runs fn to compute the Metrics response for key, unless a call for the
+// same key is already in flight, in which case it waits for that call's
+// result instead of starting a new one.
+func (c *metricsCoalescer) do(key string, fn func() (*api.MetricsResponse, error)) (*api.MetricsResponse, error) {
+	c.mu.Lock()
+	if call, ok := c.calls[key]; ok {
+		c.mu.Unlock()
+		<-call.done
+		return call.result, call.err
+	}
+
+	call := &metricsCall{done: make(chan struct{})}
+	c.calls[key] = call
+	c.mu.Unlock()
+
+	call.result, call.err = fn()
+	close(call.done)
+
+	c.mu.Lock()
+	delete(c.calls, key)
+	c.mu.Unlock()
+
+	return call.result, call.err
+}
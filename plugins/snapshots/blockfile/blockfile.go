@@ -35,6 +35,10 @@ import (
 // viewHookHelper is only used in test for recover the filesystem.
 type viewHookHelper func(backingFile string, fsType string, defaultOpts []string) error
 
+// verityRootHashLabel records the dm-verity root hash computed for a
+// committed block file, when the snapshotter was created with WithVerity.
+const verityRootHashLabel = "containerd.io/snapshot/blockfile/verity.roothash"
+
 // SnapshotterConfig holds the configurable properties for the blockfile snapshotter
 type SnapshotterConfig struct {
 	// recreateScratch is whether scratch should be recreated even
@@ -59,6 +63,21 @@ type SnapshotterConfig struct {
 	// FIXME(fuweid): I don't hit the readonly issue in ssd storage. But it's
 	// easy to reproduce it in slow-storage.
 	testViewHookHelper viewHookHelper
+
+	// verity enables dm-verity protection of committed (read-only) block
+	// files. The root hash computed at commit time is recorded as a label
+	// on the snapshot, and is used to activate and authenticate a verity
+	// mapping before mounting that snapshot read-only as someone else's
+	// parent.
+	verity bool
+
+	// verityRequireRootHash fails a mount closed when verity is enabled but
+	// the committed snapshot being mounted has no recorded root hash label,
+	// instead of falling back to mounting the raw block file unverified.
+	// Without it, snapshots committed before WithVerity was enabled (or
+	// imported from elsewhere) are mounted unauthenticated rather than
+	// rejected.
+	verityRequireRootHash bool
 }
 
 // Opt is an option to configure the overlay snapshotter
@@ -101,6 +120,29 @@ func WithRecreateScratch(recreate bool) Opt {
 	}
 }
 
+// WithVerity enables dm-verity protection for committed block files. The
+// root hash is computed when a snapshot is committed and stored on the
+// snapshot as the containerd.io/snapshot/blockfile/verity.roothash label.
+// Mounting a committed snapshot as another snapshot's parent then activates
+// a dm-verity mapping authenticated against that root hash and mounts the
+// mapped device instead of the raw block file, so a tampered or corrupted
+// layer fails to mount instead of silently being read.
+func WithVerity() Opt {
+	return func(root string, config *SnapshotterConfig) {
+		config.verity = true
+	}
+}
+
+// WithVerityRequireRootHash makes WithVerity fail a mount closed when the
+// committed snapshot being mounted has no recorded root hash label, rather
+// than falling back to mounting it unverified. Use this once every snapshot
+// in use is known to have been committed with WithVerity enabled.
+func WithVerityRequireRootHash() Opt {
+	return func(root string, config *SnapshotterConfig) {
+		config.verityRequireRootHash = true
+	}
+}
+
 // withViewHookHelper introduces hook for preparing snapshot for View. It
 // should be used in test only.
 //
@@ -112,11 +154,13 @@ func withViewHookHelper(fn viewHookHelper) Opt {
 }
 
 type snapshotter struct {
-	root    string
-	scratch string
-	fsType  string
-	options []string
-	ms      *storage.MetaStore
+	root                  string
+	scratch               string
+	fsType                string
+	options               []string
+	ms                    *storage.MetaStore
+	verity                bool
+	verityRequireRootHash bool
 
 	testViewHookHelper viewHookHelper
 }
@@ -170,11 +214,13 @@ func NewSnapshotter(root string, opts ...Opt) (snapshots.Snapshotter, error) {
 	}
 
 	return &snapshotter{
-		root:    root,
-		scratch: scratch,
-		fsType:  config.fsType,
-		options: config.mountOptions,
-		ms:      ms,
+		root:                  root,
+		scratch:               scratch,
+		fsType:                config.fsType,
+		options:               config.mountOptions,
+		ms:                    ms,
+		verity:                config.verity,
+		verityRequireRootHash: config.verityRequireRootHash,
 
 		testViewHookHelper: config.testViewHookHelper,
 	}, nil
@@ -278,20 +324,24 @@ func (o *snapshotter) View(ctx context.Context, key, parent string, opts ...snap
 //
 // This can be used to recover mounts after calling View or Prepare.
 func (o *snapshotter) Mounts(ctx context.Context, key string) (_ []mount.Mount, err error) {
-	var s storage.Snapshot
+	var (
+		s  storage.Snapshot
+		ms []mount.Mount
+	)
 	err = o.ms.WithTransaction(ctx, false, func(ctx context.Context) error {
 		s, err = storage.GetSnapshot(ctx, key)
 		if err != nil {
 			return fmt.Errorf("failed to get snapshot mount: %w", err)
 		}
 
-		return nil
+		ms, err = o.mounts(ctx, s)
+		return err
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return o.mounts(s), nil
+	return ms, nil
 }
 
 func (o *snapshotter) Commit(ctx context.Context, name, key string, opts ...snapshots.Opt) error {
@@ -311,6 +361,16 @@ func (o *snapshotter) Commit(ctx context.Context, name, key string, opts ...snap
 			Inodes: 1,
 		}
 
+		if o.verity {
+			rootHash, err := formatVerity(o.getBlockFile(id))
+			if err != nil {
+				return fmt.Errorf("failed to format dm-verity hash tree: %w", err)
+			}
+			opts = append(opts, snapshots.WithLabels(map[string]string{
+				verityRootHashLabel: rootHash,
+			}))
+		}
+
 		if _, err = storage.CommitActive(ctx, key, name, usage, opts...); err != nil {
 			return fmt.Errorf("failed to commit snapshot: %w", err)
 		}
@@ -372,7 +432,10 @@ func (o *snapshotter) Walk(ctx context.Context, fn snapshots.WalkFunc, fs ...str
 }
 
 func (o *snapshotter) createSnapshot(ctx context.Context, kind snapshots.Kind, key, parent string, opts []snapshots.Opt) (_ []mount.Mount, err error) {
-	var s storage.Snapshot
+	var (
+		s  storage.Snapshot
+		ms []mount.Mount
+	)
 
 	err = o.ms.WithTransaction(ctx, true, func(ctx context.Context) error {
 		s, err = storage.CreateSnapshot(ctx, kind, key, parent, opts...)
@@ -403,20 +466,27 @@ func (o *snapshotter) createSnapshot(ctx context.Context, kind snapshots.Kind, k
 			}
 		}
 
-		return nil
+		ms, err = o.mounts(ctx, s)
+		return err
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return o.mounts(s), nil
+	return ms, nil
 }
 
 func (o *snapshotter) getBlockFile(id string) string {
 	return filepath.Join(o.root, "snapshots", id)
 }
 
-func (o *snapshotter) mounts(s storage.Snapshot) []mount.Mount {
+// mounts builds the mount for snapshot s. When s is a view onto a committed
+// parent and verity is enabled, the parent's block file is authenticated
+// against its recorded dm-verity root hash and the resulting mapper device
+// is mounted in its place, so a corrupted or tampered layer fails to mount
+// instead of being silently read. Requires a context with a storage
+// transaction, since looking up the parent's root hash label needs one.
+func (o *snapshotter) mounts(ctx context.Context, s storage.Snapshot) ([]mount.Mount, error) {
 	var (
 		mountOptions = o.options
 		source       string
@@ -431,7 +501,18 @@ func (o *snapshotter) mounts(s storage.Snapshot) []mount.Mount {
 	if len(s.ParentIDs) == 0 || s.Kind == snapshots.KindActive {
 		source = o.getBlockFile(s.ID)
 	} else {
-		source = o.getBlockFile(s.ParentIDs[0])
+		parentID := s.ParentIDs[0]
+		source = o.getBlockFile(parentID)
+
+		if o.verity {
+			verified, err := o.verifiedSource(ctx, parentID, source)
+			if err != nil {
+				return nil, err
+			}
+			if verified != "" {
+				source = verified
+			}
+		}
 	}
 
 	return []mount.Mount{
@@ -440,7 +521,39 @@ func (o *snapshotter) mounts(s storage.Snapshot) []mount.Mount {
 			Type:    o.fsType,
 			Options: mountOptions,
 		},
+	}, nil
+}
+
+// verifiedSource activates a dm-verity mapping for the committed block file
+// at blockFile, authenticated against the root hash recorded as a label on
+// the snapshot identified by parentID, and returns the path to the mapper
+// device to mount instead of blockFile. If that snapshot has no recorded
+// root hash, it returns "" (mount blockFile directly) unless
+// verityRequireRootHash is set, in which case it fails closed instead.
+func (o *snapshotter) verifiedSource(ctx context.Context, parentID, blockFile string) (string, error) {
+	ids, err := storage.IDMap(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve snapshot name for verity lookup: %w", err)
+	}
+	name, ok := ids[parentID]
+	if !ok {
+		return "", fmt.Errorf("snapshot id %s not found for verity lookup", parentID)
+	}
+
+	_, info, _, err := storage.GetInfo(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to get snapshot info for verity lookup: %w", err)
 	}
+
+	rootHash := info.Labels[verityRootHashLabel]
+	if rootHash == "" {
+		if o.verityRequireRootHash {
+			return "", fmt.Errorf("dm-verity is required but snapshot %s has no recorded root hash", name)
+		}
+		return "", nil
+	}
+
+	return activateVerity(parentID, blockFile, rootHash)
 }
 
 // Close closes the snapshotter
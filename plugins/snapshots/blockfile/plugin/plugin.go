@@ -43,6 +43,18 @@ type Config struct {
 	// RecreateScratch always recreates the specified `ScratchFile`
 	// on initialization of the plugin instead of using an existing.
 	RecreateScratch bool `toml:"recreate_scratch"`
+
+	// Verity enables dm-verity protection of committed block files,
+	// recording the root hash as a label on each snapshot and
+	// authenticating against it when that snapshot is later mounted as a
+	// parent.
+	Verity bool `toml:"verity"`
+
+	// VerityRequireRootHash fails a mount closed instead of falling back to
+	// an unverified one when Verity is enabled but the committed snapshot
+	// being mounted has no recorded root hash label. Has no effect unless
+	// Verity is also set.
+	VerityRequireRootHash bool `toml:"verity_require_root_hash"`
 }
 
 func init() {
@@ -73,6 +85,12 @@ func init() {
 				opts = append(opts, blockfile.WithMountOptions(config.MountOptions))
 			}
 			opts = append(opts, blockfile.WithRecreateScratch(config.RecreateScratch))
+			if config.Verity {
+				opts = append(opts, blockfile.WithVerity())
+			}
+			if config.VerityRequireRootHash {
+				opts = append(opts, blockfile.WithVerityRequireRootHash())
+			}
 
 			ic.Meta.Exports[plugins.SnapshotterRootDir] = root
 			return blockfile.NewSnapshotter(root, opts...)
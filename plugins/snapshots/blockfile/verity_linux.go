@@ -0,0 +1,62 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package blockfile
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/containerd/containerd/v2/internal/dmverity"
+)
+
+// formatVerity builds a dm-verity hash tree for blockFile, storing it in a
+// sibling "<blockFile>.verity" hash file, and returns the root hash.
+func formatVerity(blockFile string) (string, error) {
+	hashFile := blockFile + ".verity"
+
+	f, err := os.Create(hashFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to create verity hash file: %w", err)
+	}
+	f.Close()
+
+	info, err := dmverity.Format(blockFile, hashFile)
+	if err != nil {
+		return "", err
+	}
+
+	return info.RootHash, nil
+}
+
+// activateVerity ensures a dm-verity mapping authenticated against rootHash
+// is active for blockFile, using its sibling "<blockFile>.verity" hash file
+// written by formatVerity, and returns the mapper device path to mount in
+// place of blockFile. The mapping name is derived from id, so repeated
+// views of the same committed snapshot reuse the same mapping instead of
+// failing to open one that's already active.
+func activateVerity(id, blockFile, rootHash string) (string, error) {
+	name := "containerd-verity-" + id
+	devicePath := "/dev/mapper/" + name
+
+	if _, err := os.Stat(devicePath); err == nil {
+		return devicePath, nil
+	} else if !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to stat %s: %w", devicePath, err)
+	}
+
+	return dmverity.Open(name, blockFile, blockFile+".verity", rootHash)
+}
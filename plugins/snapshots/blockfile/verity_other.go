@@ -0,0 +1,33 @@
+//go:build !linux
+
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package blockfile
+
+import "fmt"
+
+// formatVerity is not supported outside of Linux, where dm-verity does not
+// exist.
+func formatVerity(blockFile string) (string, error) {
+	return "", fmt.Errorf("dm-verity is not supported on this platform")
+}
+
+// activateVerity is not supported outside of Linux, where dm-verity does not
+// exist.
+func activateVerity(id, blockFile, rootHash string) (string, error) {
+	return "", fmt.Errorf("dm-verity is not supported on this platform")
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/containerd/btrfs/v2"
@@ -35,17 +36,47 @@ import (
 	"github.com/containerd/plugin"
 )
 
+// qgroupLimitLabel is an optional label that sets a per-snapshot qgroup
+// referenced-bytes limit (in bytes). It only has an effect when the
+// snapshotter was created with WithQGroup.
+const qgroupLimitLabel = "containerd.io/snapshot/btrfs/qgroup.limit"
+
+// SnapshotterConfig is used to configure the btrfs snapshotter instance
+type SnapshotterConfig struct {
+	qgroup bool
+}
+
+// Opt is an option to configure the btrfs snapshotter
+type Opt func(config *SnapshotterConfig) error
+
+// WithQGroup enables btrfs qgroups on the snapshotter's filesystem, making
+// Usage() report accurate per-snapshot accounting and allowing the
+// "containerd.io/snapshot/btrfs/qgroup.limit" label to enforce a
+// referenced-bytes cap on a snapshot.
+func WithQGroup(config *SnapshotterConfig) error {
+	config.qgroup = true
+	return nil
+}
+
 type snapshotter struct {
 	device string // device of the root
 	root   string // root provides paths for internal storage.
 	ms     *storage.MetaStore
+	qgroup bool
 }
 
 // NewSnapshotter returns a Snapshotter using btrfs. Uses the provided
 // root directory for snapshots and stores the metadata in
 // a file in the provided root.
 // root needs to be a mount point of btrfs.
-func NewSnapshotter(root string) (snapshots.Snapshotter, error) {
+func NewSnapshotter(root string, opts ...Opt) (snapshots.Snapshotter, error) {
+	var config SnapshotterConfig
+	for _, opt := range opts {
+		if err := opt(&config); err != nil {
+			return nil, err
+		}
+	}
+
 	// If directory does not exist, create it
 	if st, err := os.Stat(root); err != nil {
 		if !os.IsNotExist(err) {
@@ -87,10 +118,17 @@ func NewSnapshotter(root string) (snapshots.Snapshotter, error) {
 		return nil, err
 	}
 
+	if config.qgroup {
+		if err := enableQuota(root); err != nil {
+			return nil, err
+		}
+	}
+
 	return &snapshotter{
 		device: mnt.Source,
 		root:   root,
 		ms:     ms,
+		qgroup: config.qgroup,
 	}, nil
 }
 
@@ -150,8 +188,18 @@ func (b *snapshotter) usage(ctx context.Context, key string) (usage snapshots.Us
 	}
 
 	if info.Kind == snapshots.KindActive {
-		var du fs.Usage
 		p := filepath.Join(b.root, "active", id)
+
+		if b.qgroup {
+			usage, err = b.qgroupUsage(p)
+			if err != nil {
+				log.G(ctx).WithError(err).WithField("subvolume", p).Warn("failed to get qgroup usage, falling back to disk usage")
+			} else {
+				return usage, nil
+			}
+		}
+
+		var du fs.Usage
 		if parentID != "" {
 			du, err = fs.DiffUsage(ctx, filepath.Join(b.root, "snapshots", parentID), p)
 		} else {
@@ -168,6 +216,23 @@ func (b *snapshotter) usage(ctx context.Context, key string) (usage snapshots.Us
 	return usage, nil
 }
 
+// qgroupUsage reports the referenced/exclusive byte counts the kernel
+// already tracks for the subvolume's 0/<id> qgroup, avoiding a filesystem
+// walk.
+func (b *snapshotter) qgroupUsage(subvolume string) (snapshots.Usage, error) {
+	id, err := btrfs.SubvolID(subvolume)
+	if err != nil {
+		return snapshots.Usage{}, err
+	}
+
+	rfer, _, err := qgroupUsage(b.root, id)
+	if err != nil {
+		return snapshots.Usage{}, err
+	}
+
+	return snapshots.Usage{Size: int64(rfer), Inodes: -1}, nil
+}
+
 // Walk the committed snapshots.
 func (b *snapshotter) Walk(ctx context.Context, fn snapshots.WalkFunc, fs ...string) (err error) {
 	return b.ms.WithTransaction(ctx, false, func(ctx context.Context) error {
@@ -219,9 +284,44 @@ func (b *snapshotter) makeSnapshot(ctx context.Context, kind snapshots.Kind, key
 		return nil, err
 	}
 
+	if b.qgroup {
+		if limit, ok, lerr := qgroupLimitFromOpts(opts); lerr != nil {
+			log.G(ctx).WithError(lerr).WithField("subvolume", target).Warn("failed to parse qgroup limit label")
+		} else if ok {
+			if id, ierr := btrfs.SubvolID(target); ierr != nil {
+				log.G(ctx).WithError(ierr).WithField("subvolume", target).Warn("failed to resolve subvolume id for qgroup limit")
+			} else if lerr := setQgroupLimit(b.root, id, limit); lerr != nil {
+				log.G(ctx).WithError(lerr).WithField("subvolume", target).Warn("failed to set qgroup limit")
+			}
+		}
+	}
+
 	return b.mounts(target, s)
 }
 
+// qgroupLimitFromOpts applies snapshot opts to a scratch Info and reports
+// whether the qgroup limit label was set.
+func qgroupLimitFromOpts(opts []snapshots.Opt) (uint64, bool, error) {
+	var info snapshots.Info
+	for _, opt := range opts {
+		if err := opt(&info); err != nil {
+			return 0, false, err
+		}
+	}
+
+	v, ok := info.Labels[qgroupLimitLabel]
+	if !ok {
+		return 0, false, nil
+	}
+
+	limit, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to parse label %q=%q: %w", qgroupLimitLabel, v, err)
+	}
+
+	return limit, true, nil
+}
+
 func (b *snapshotter) mounts(dir string, s storage.Snapshot) ([]mount.Mount, error) {
 	var options []string
 
@@ -34,6 +34,11 @@ import (
 type Config struct {
 	// Root directory for the plugin
 	RootPath string `toml:"root_path"`
+
+	// QGroup enables btrfs qgroups so that Usage() reports accurate
+	// per-snapshot accounting and the "containerd.io/snapshot/btrfs/qgroup.limit"
+	// label can enforce a per-snapshot size limit.
+	QGroup bool `toml:"qgroup"`
 }
 
 func init() {
@@ -55,7 +60,13 @@ func init() {
 			}
 
 			ic.Meta.Exports[plugins.SnapshotterRootDir] = root
-			return btrfs.NewSnapshotter(root)
+
+			var opts []btrfs.Opt
+			if config.QGroup {
+				opts = append(opts, btrfs.WithQGroup)
+			}
+
+			return btrfs.NewSnapshotter(root, opts...)
 		},
 	})
 }
@@ -40,6 +40,17 @@ import (
 // the change set between this snapshot and its parent is stored.
 const upperdirKey = "containerd.io/snapshot/overlay.upperdir"
 
+// Per-snapshot labels allowing a client (e.g. the CRI plugin, acting on
+// behalf of a pod spec) to opt a single container's overlay mount into
+// tuning that is normally only available through global snapshotter
+// configuration. This is useful for, e.g., ephemeral CI containers that
+// want "volatile" to avoid the cost of fsyncing throwaway layers.
+const (
+	volatileLabel  = "containerd.io/snapshot/overlay.volatile"
+	userxattrLabel = "containerd.io/snapshot/overlay.userxattr"
+	metacopyLabel  = "containerd.io/snapshot/overlay.metacopy"
+)
+
 // SnapshotterConfig is used to configure the overlay snapshotter instance
 type SnapshotterConfig struct {
 	asyncRemove   bool
@@ -604,6 +615,7 @@ func (o *snapshotter) mounts(s storage.Snapshot, info snapshots.Info) []mount.Mo
 	}
 	options = append(options, fmt.Sprintf("lowerdir=%s", strings.Join(parentPaths, ":")))
 	options = append(options, o.options...)
+	options = append(options, perSnapshotOptions(info)...)
 
 	return []mount.Mount{
 		{
@@ -614,6 +626,25 @@ func (o *snapshotter) mounts(s storage.Snapshot, info snapshots.Info) []mount.Mo
 	}
 }
 
+// perSnapshotOptions returns the overlay mount options requested by the
+// per-snapshot volatile/userxattr/metacopy labels, skipping any that are
+// already covered by the snapshotter-wide mount options.
+func perSnapshotOptions(info snapshots.Info) []string {
+	var options []string
+
+	if _, ok := info.Labels[volatileLabel]; ok {
+		options = append(options, "volatile")
+	}
+	if _, ok := info.Labels[userxattrLabel]; ok {
+		options = append(options, "userxattr")
+	}
+	if _, ok := info.Labels[metacopyLabel]; ok {
+		options = append(options, "metacopy=on")
+	}
+
+	return options
+}
+
 func (o *snapshotter) upperPath(id string) string {
 	return filepath.Join(o.root, "snapshots", id, "fs")
 }
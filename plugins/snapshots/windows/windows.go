@@ -216,6 +216,55 @@ func (s *wcowSnapshotter) createSnapshot(ctx context.Context, kind snapshots.Kin
 	return s.mounts(newSnapshot, key), nil
 }
 
+// Update updates the info for a snapshot. If the rootfsSizeInBytesLabel is
+// updated on an active snapshot, the sandbox.vhdx is expanded in place so a
+// running container's ephemeral storage (e.g. a CRI resize of a pod's
+// ephemeral-storage request) can grow without a restart. The vhdx can only
+// be grown, never shrunk.
+func (s *wcowSnapshotter) Update(ctx context.Context, info snapshots.Info, fieldpaths ...string) (snapshots.Info, error) {
+	updated, err := s.windowsBaseSnapshotter.Update(ctx, info, fieldpaths...)
+	if err != nil {
+		return snapshots.Info{}, err
+	}
+
+	if updated.Kind != snapshots.KindActive {
+		return updated, nil
+	}
+
+	wantsResize := len(fieldpaths) == 0
+	for _, p := range fieldpaths {
+		if p == "labels."+rootfsSizeInBytesLabel {
+			wantsResize = true
+			break
+		}
+	}
+	if !wantsResize {
+		return updated, nil
+	}
+
+	sizeInBytes, err := getRequestedScratchSize(ctx, updated)
+	if err != nil {
+		return snapshots.Info{}, err
+	}
+	if sizeInBytes == 0 {
+		return updated, nil
+	}
+
+	var id string
+	if err := s.ms.WithTransaction(ctx, false, func(ctx context.Context) error {
+		id, _, _, err = storage.GetInfo(ctx, updated.Name)
+		return err
+	}); err != nil {
+		return snapshots.Info{}, fmt.Errorf("failed to resolve snapshot id for resize: %w", err)
+	}
+
+	if err := hcsshim.ExpandSandboxSize(s.info, id, sizeInBytes); err != nil {
+		return snapshots.Info{}, fmt.Errorf("failed to expand sandbox vhdx size to %d bytes: %w", sizeInBytes, err)
+	}
+
+	return updated, nil
+}
+
 // Remove abandons the transaction identified by key. All resources
 // associated with the key will be removed.
 func (s *wcowSnapshotter) Remove(ctx context.Context, key string) error {
@@ -75,11 +75,14 @@ func init() {
 				return nil, err
 			}
 			if len(vps) > 0 {
-				lc.Verifiers = make(map[string]imageverifier.ImageVerifier)
+				lc.VerifierPolicy.Verifiers = make(map[string]imageverifier.ImageVerifier)
 				for name, vp := range vps {
-					lc.Verifiers[name] = vp.(imageverifier.ImageVerifier)
+					lc.VerifierPolicy.Verifiers[name] = vp.(imageverifier.ImageVerifier)
 				}
 			}
+			if len(config.VerifierNamespaces) > 0 {
+				lc.VerifierPolicy.Namespaces = config.VerifierNamespaces
+			}
 
 			// Set configuration based on default or user input
 			lc.MaxConcurrentDownloads = config.MaxConcurrentDownloads
@@ -202,6 +205,11 @@ type transferConfig struct {
 
 	// RegistryConfigPath is a path to the root directory containing registry-specific configurations
 	RegistryConfigPath string `toml:"config_path"`
+
+	// VerifierNamespaces restricts an image verifier plugin, by ID, to
+	// the listed namespaces. A verifier with no entry here runs for
+	// every namespace.
+	VerifierNamespaces map[string][]string `toml:"verifier_namespaces,omitempty"`
 }
 
 type unpackConfiguration struct {